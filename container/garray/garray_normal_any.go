@@ -794,6 +794,45 @@ func (a *Array) Walk(f func(value interface{}) interface{}) *Array {
 	return a
 }
 
+// Map applies <f> to every item of array and returns a new array of the results.
+// It does not modify current array.
+func (a *Array) Map(f func(value interface{}) interface{}) *Array {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	array := make([]interface{}, len(a.array))
+	for i, v := range a.array {
+		array[i] = f(v)
+	}
+	return NewArrayFrom(array, a.mu.IsSafe())
+}
+
+// Filter returns a new array containing only the items for which <f> returns true.
+// It does not modify current array.
+func (a *Array) Filter(f func(value interface{}) bool) *Array {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	array := make([]interface{}, 0)
+	for _, v := range a.array {
+		if f(v) {
+			array = append(array, v)
+		}
+	}
+	return NewArrayFrom(array, a.mu.IsSafe())
+}
+
+// Reduce applies <f> against an accumulator and each item of array from left to right,
+// and returns the final accumulator value. The <initial> is the initial accumulator value.
+// It returns <initial> if the array is empty.
+func (a *Array) Reduce(initial interface{}, f func(acc, value interface{}) interface{}) interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	acc := initial
+	for _, v := range a.array {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
 // IsEmpty checks whether the array is empty.
 func (a *Array) IsEmpty() bool {
 	return a.Len() == 0
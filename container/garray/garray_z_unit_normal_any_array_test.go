@@ -757,3 +757,68 @@ func TestArray_Walk(t *testing.T) {
 		}), g.Slice{"key-1", "key-2"})
 	})
 }
+
+func TestArray_Map(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArrayFrom(g.Slice{1, 2, 3})
+		newArray := array.Map(func(value interface{}) interface{} {
+			return gconv.Int(value) * 2
+		})
+		t.Assert(newArray, g.Slice{2, 4, 6})
+		t.Assert(array, g.Slice{1, 2, 3})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArrayFrom(g.Slice{1, "a", 2.5})
+		t.Assert(array.Map(func(value interface{}) interface{} {
+			return value
+		}), g.Slice{1, "a", 2.5})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.New()
+		t.Assert(array.Map(func(value interface{}) interface{} {
+			return value
+		}).Len(), 0)
+	})
+}
+
+func TestArray_Filter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArrayFrom(g.Slice{1, 2, 3, 4})
+		newArray := array.Filter(func(value interface{}) bool {
+			return gconv.Int(value)%2 == 0
+		})
+		t.Assert(newArray, g.Slice{2, 4})
+		t.Assert(array, g.Slice{1, 2, 3, 4})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArrayFrom(g.Slice{1, 2, 3, 4})
+		newArray := array.Filter(func(value interface{}) bool {
+			return false
+		})
+		t.Assert(newArray.Len(), 0)
+		t.Assert(newArray.IsEmpty(), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.New()
+		t.Assert(array.Filter(func(value interface{}) bool {
+			return true
+		}).Len(), 0)
+	})
+}
+
+func TestArray_Reduce(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArrayFrom(g.Slice{1, 2, 3, 4})
+		sum := array.Reduce(0, func(acc, value interface{}) interface{} {
+			return gconv.Int(acc) + gconv.Int(value)
+		})
+		t.Assert(sum, 10)
+		t.Assert(array, g.Slice{1, 2, 3, 4})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.New()
+		t.Assert(array.Reduce(100, func(acc, value interface{}) interface{} {
+			return gconv.Int(acc) + gconv.Int(value)
+		}), 100)
+	})
+}
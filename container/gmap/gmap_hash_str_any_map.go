@@ -451,6 +451,63 @@ func (m *StrAnyMap) Merge(other *StrAnyMap) {
 	}
 }
 
+// Union returns a new hash map containing all keys of both <m> and <other>,
+// the values of <other> take precedence on conflict.
+// It does not modify <m> and <other>.
+func (m *StrAnyMap) Union(other *StrAnyMap) *StrAnyMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if other != m {
+		other.mu.RLock()
+		defer other.mu.RUnlock()
+	}
+	data := make(map[string]interface{}, len(m.data)+len(other.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	for k, v := range other.data {
+		data[k] = v
+	}
+	return NewStrAnyMapFrom(data, m.mu.IsSafe())
+}
+
+// Intersection returns a new hash map containing only the keys present in both <m> and <other>,
+// with the values of <m>.
+// It does not modify <m> and <other>.
+func (m *StrAnyMap) Intersection(other *StrAnyMap) *StrAnyMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if other != m {
+		other.mu.RLock()
+		defer other.mu.RUnlock()
+	}
+	data := make(map[string]interface{})
+	for k, v := range m.data {
+		if _, ok := other.data[k]; ok {
+			data[k] = v
+		}
+	}
+	return NewStrAnyMapFrom(data, m.mu.IsSafe())
+}
+
+// Subtract returns a new hash map containing the keys of <m> which are not present in <other>.
+// It does not modify <m> and <other>.
+func (m *StrAnyMap) Subtract(other *StrAnyMap) *StrAnyMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if other != m {
+		other.mu.RLock()
+		defer other.mu.RUnlock()
+	}
+	data := make(map[string]interface{})
+	for k, v := range m.data {
+		if _, ok := other.data[k]; !ok {
+			data[k] = v
+		}
+	}
+	return NewStrAnyMapFrom(data, m.mu.IsSafe())
+}
+
 // String returns the map as a string.
 func (m *StrAnyMap) String() string {
 	b, _ := m.MarshalJSON()
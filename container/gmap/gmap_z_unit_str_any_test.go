@@ -352,3 +352,45 @@ func TestStrAnyMap_UnmarshalValue(t *testing.T) {
 		t.Assert(v.Map.Get("k2"), "v2")
 	})
 }
+
+func Test_StrAnyMap_Union_Intersection_Subtract(t *testing.T) {
+	// Disjoint maps.
+	gtest.C(t, func(t *gtest.T) {
+		m1 := gmap.NewStrAnyMapFrom(g.MapStrAny{"a": 1, "b": 2})
+		m2 := gmap.NewStrAnyMapFrom(g.MapStrAny{"c": 3, "d": 4})
+		t.Assert(m1.Union(m2).Map(), g.MapStrAny{"a": 1, "b": 2, "c": 3, "d": 4})
+		t.Assert(m1.Intersection(m2).Size(), 0)
+		t.Assert(m1.Subtract(m2).Map(), g.MapStrAny{"a": 1, "b": 2})
+		t.Assert(m2.Subtract(m1).Map(), g.MapStrAny{"c": 3, "d": 4})
+	})
+	// Overlapping maps.
+	gtest.C(t, func(t *gtest.T) {
+		m1 := gmap.NewStrAnyMapFrom(g.MapStrAny{"a": 1, "b": 2, "c": 3}, true)
+		m2 := gmap.NewStrAnyMapFrom(g.MapStrAny{"b": 20, "c": 30, "d": 40}, true)
+		t.Assert(m1.Union(m2).Map(), g.MapStrAny{"a": 1, "b": 20, "c": 30, "d": 40})
+		t.Assert(m2.Union(m1).Map(), g.MapStrAny{"a": 1, "b": 2, "c": 3, "d": 40})
+		t.Assert(m1.Intersection(m2).Map(), g.MapStrAny{"b": 2, "c": 3})
+		t.Assert(m2.Intersection(m1).Map(), g.MapStrAny{"b": 20, "c": 30})
+		t.Assert(m1.Subtract(m2).Map(), g.MapStrAny{"a": 1})
+		t.Assert(m2.Subtract(m1).Map(), g.MapStrAny{"d": 40})
+		// Receivers are not modified.
+		t.Assert(m1.Map(), g.MapStrAny{"a": 1, "b": 2, "c": 3})
+		t.Assert(m2.Map(), g.MapStrAny{"b": 20, "c": 30, "d": 40})
+	})
+	// Identical maps.
+	gtest.C(t, func(t *gtest.T) {
+		m1 := gmap.NewStrAnyMapFrom(g.MapStrAny{"a": 1, "b": 2})
+		m2 := m1.Clone()
+		t.Assert(m1.Union(m2).Map(), m1.Map())
+		t.Assert(m1.Intersection(m2).Map(), m1.Map())
+		t.Assert(m1.Subtract(m2).Size(), 0)
+		t.Assert(m1.Union(m1).Map(), m1.Map())
+		t.Assert(m1.Intersection(m1).Map(), m1.Map())
+		t.Assert(m1.Subtract(m1).Size(), 0)
+
+		// The result is a new map.
+		u := m1.Union(m2)
+		u.Set("c", 3)
+		t.Assert(m1.Contains("c"), false)
+	})
+}
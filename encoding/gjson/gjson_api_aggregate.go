@@ -0,0 +1,140 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Wildcard key in aggregation path, which matches all items of array or map.
+	aggregateWildcard = "*"
+)
+
+// Aggregate selects numeric values by <path> and aggregates them with function <fn>,
+// which is one of "sum", "avg", "min", "max" and "count".
+//
+// The <path> supports wildcard "*" matching all items of array or map, eg:
+// "orders.*.amount" selects the amounts of all orders, and "orders.*.items.*.price"
+// selects the prices of all items of all orders. If the selected value is an array,
+// its items are aggregated, eg: "scores" selects all items of array "scores".
+//
+// For empty selection, "sum" and "count" return 0, and "avg", "min", "max" return nil.
+// It returns error if any selected value is not numeric, except for "count".
+// The "count" returns int, and the others return float64.
+func (j *Json) Aggregate(path, fn string) (interface{}, error) {
+	switch fn {
+	case "sum", "avg", "min", "max", "count":
+	default:
+		return nil, errors.New(fmt.Sprintf(`unsupported aggregate function "%s"`, fn))
+	}
+	j.mu.RLock()
+	values := aggregateSelect(*j.p, strings.Split(path, string(j.c)))
+	j.mu.RUnlock()
+
+	if fn == "count" {
+		return len(values), nil
+	}
+	numbers := make([]float64, len(values))
+	for i, v := range values {
+		n, ok := aggregateNumber(v)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf(`non-numeric value "%v" of type "%T" for aggregation`, v, v))
+		}
+		numbers[i] = n
+	}
+	if len(numbers) == 0 {
+		if fn == "sum" {
+			return float64(0), nil
+		}
+		return nil, nil
+	}
+	result := numbers[0]
+	switch fn {
+	case "sum", "avg":
+		for _, n := range numbers[1:] {
+			result += n
+		}
+		if fn == "avg" {
+			result /= float64(len(numbers))
+		}
+	case "min":
+		for _, n := range numbers[1:] {
+			if n < result {
+				result = n
+			}
+		}
+	case "max":
+		for _, n := range numbers[1:] {
+			if n > result {
+				result = n
+			}
+		}
+	}
+	return result, nil
+}
+
+// aggregateSelect selects and returns the values of <data> by path <keys>.
+func aggregateSelect(data interface{}, keys []string) []interface{} {
+	if len(keys) == 0 || (len(keys) == 1 && keys[0] == "") {
+		// Items of the final array are selected.
+		if array, ok := data.([]interface{}); ok {
+			return array
+		}
+		if data == nil {
+			return nil
+		}
+		return []interface{}{data}
+	}
+	var (
+		key    = keys[0]
+		values []interface{}
+	)
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if key == aggregateWildcard {
+			for _, item := range v {
+				values = append(values, aggregateSelect(item, keys[1:])...)
+			}
+		} else if item, ok := v[key]; ok {
+			values = aggregateSelect(item, keys[1:])
+		}
+	case []interface{}:
+		if key == aggregateWildcard {
+			for _, item := range v {
+				values = append(values, aggregateSelect(item, keys[1:])...)
+			}
+		} else if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(v) {
+			values = aggregateSelect(v[index], keys[1:])
+		}
+	}
+	return values
+}
+
+// aggregateNumber converts numeric <value> to float64.
+// The returned <ok> is false if <value> is not numeric.
+func aggregateNumber(value interface{}) (n float64, ok bool) {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(reflectValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true
+	}
+	return 0, false
+}
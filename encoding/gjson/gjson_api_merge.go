@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MergeJSON merges the data of <other> into current Json object, both of which should be JSON objects.
+//
+// If <deep> is false, the top-level keys of <other> overwrite the ones of current Json object.
+// If <deep> is true, the nested objects are merged recursively, but the arrays are replaced, not appended.
+// The merged values are copied from <other>, so later changes of <other> do not affect current Json object.
+func (j *Json) MergeJSON(other *Json, deep bool) error {
+	if other == nil || other == j {
+		return nil
+	}
+	other.mu.RLock()
+	src, ok := (*other.p).(map[string]interface{})
+	if !ok && *other.p != nil {
+		other.mu.RUnlock()
+		return errors.New(fmt.Sprintf(`merged data should be JSON object, but given "%T"`, *other.p))
+	}
+	src, _ = copyMergeValue(src).(map[string]interface{})
+	other.mu.RUnlock()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if *j.p == nil {
+		*j.p = make(map[string]interface{})
+	}
+	dst, ok := (*j.p).(map[string]interface{})
+	if !ok {
+		return errors.New(fmt.Sprintf(`merging data should be JSON object, but given "%T"`, *j.p))
+	}
+	mergeMap(dst, src, deep)
+	return nil
+}
+
+// MergeAll deep merges all JSON objects <docs> in sequence into a new Json object,
+// which means the latter ones take priority for the same keys. The nil items of <docs> are ignored.
+// See Json.MergeJSON.
+func MergeAll(docs ...*Json) (*Json, error) {
+	j := New(make(map[string]interface{}))
+	for _, doc := range docs {
+		if err := j.MergeJSON(doc, true); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// mergeMap merges <src> into <dst>.
+// It merges the nested maps recursively if <deep> is true.
+func mergeMap(dst, src map[string]interface{}, deep bool) {
+	for k, v := range src {
+		if deep {
+			srcMap, srcOk := v.(map[string]interface{})
+			dstMap, dstOk := dst[k].(map[string]interface{})
+			if srcOk && dstOk {
+				mergeMap(dstMap, srcMap, deep)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// copyMergeValue returns a deep copy of <value> for the JSON object or array.
+// The other types are returned as it is.
+func copyMergeValue(value interface{}) interface{} {
+	switch r := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(r))
+		for k, v := range r {
+			m[k] = copyMergeValue(v)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(r))
+		for i, v := range r {
+			a[i] = copyMergeValue(v)
+		}
+		return a
+	default:
+		return value
+	}
+}
@@ -176,7 +176,7 @@ func LoadContent(data interface{}, safe ...bool) (*Json, error) {
 
 // LoadContentType creates a Json object from given type and content,
 // supporting data content type as follows:
-// JSON, XML, INI, YAML and TOML.
+// JSON, JSON5, XML, INI, YAML and TOML.
 func LoadContentType(dataType string, data interface{}, safe ...bool) (*Json, error) {
 	content := gconv.Bytes(data)
 	if len(content) == 0 {
@@ -202,7 +202,7 @@ func IsValidDataType(dataType string) bool {
 		dataType = dataType[1:]
 	}
 	switch dataType {
-	case "json", "js", "xml", "yaml", "yml", "toml", "ini":
+	case "json", "js", "json5", "xml", "yaml", "yml", "toml", "ini":
 		return true
 	}
 	return false
@@ -230,7 +230,7 @@ func loadContentTypeWithOption(dataType string, data interface{}, option Option)
 
 // doLoadContent creates a Json object from given content.
 // It supports data content type as follows:
-// JSON, XML, INI, YAML and TOML.
+// JSON, JSON5, XML, INI, YAML and TOML.
 func doLoadContentWithOption(dataType string, data []byte, option Option) (*Json, error) {
 	var (
 		err    error
@@ -245,6 +245,11 @@ func doLoadContentWithOption(dataType string, data []byte, option Option) (*Json
 	switch dataType {
 	case "json", ".json", ".js":
 
+	case "json5", ".json5":
+		if data, err = json5ToJson(data); err != nil {
+			return nil, err
+		}
+
 	case "xml", ".xml":
 		if data, err = gxml.ToJson(data); err != nil {
 			return nil, err
@@ -0,0 +1,71 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// StripComments removes the single-line comments "//" and block comments "/* */"
+// from JSON5-like string <input>, producing standard JSON that can be decoded by tools
+// not understanding comments.
+//
+// The comment-like sequences inside string literals, which are quoted by either
+// double or single quotes, are not comments and are kept as they are.
+// Block comments are not nested like JSON5, which means a block comment ends at the
+// first "*/" after its beginning "/*".
+//
+// The line feeds of the comments are kept, so the line numbers of the result are the same
+// as <input>, which is friendly for error reporting of the later decoding.
+// It returns error if there's unterminated block comment or string literal in <input>.
+func StripComments(input string) (string, error) {
+	var (
+		buffer = bytes.NewBuffer(make([]byte, 0, len(input)))
+		length = len(input)
+	)
+	for i := 0; i < length; i++ {
+		switch c := input[i]; {
+		case c == '"' || c == '\'':
+			start := i
+			for i++; i < length && input[i] != c; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+			if i >= length {
+				return "", errors.New(fmt.Sprintf(`unterminated string literal at offset %d`, start))
+			}
+			buffer.WriteString(input[start : i+1])
+
+		case c == '/' && i+1 < length && input[i+1] == '/':
+			for i += 2; i < length && input[i] != '\n'; i++ {
+			}
+			if i < length {
+				buffer.WriteByte('\n')
+			}
+
+		case c == '/' && i+1 < length && input[i+1] == '*':
+			start := i
+			for i += 2; i < length && !(input[i] == '*' && i+1 < length && input[i+1] == '/'); i++ {
+				if input[i] == '\n' {
+					buffer.WriteByte('\n')
+				}
+			}
+			if i >= length {
+				return "", errors.New(fmt.Sprintf(`unterminated block comment at offset %d`, start))
+			}
+			// Skip the '/' of "*/".
+			i++
+
+		default:
+			buffer.WriteByte(c)
+		}
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// json5ToJson converts JSON5 content <data> to standard JSON.
+//
+// It supports the commonly used JSON5 extensions as follows:
+// 1. Single-line and block comments, see StripComments;
+// 2. Trailing commas of objects and arrays;
+// 3. Unquoted object keys which are valid identifiers;
+// 4. Single quoted strings;
+// 5. Hexadecimal numbers and numbers with leading '+'.
+func json5ToJson(data []byte) ([]byte, error) {
+	content, err := StripComments(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		buffer = bytes.NewBuffer(make([]byte, 0, len(content)))
+		length = len(content)
+	)
+	for i := 0; i < length; i++ {
+		c := content[i]
+		switch {
+		case c == '"':
+			start := i
+			for i++; i < length && content[i] != '"'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+			buffer.WriteString(content[start : i+1])
+
+		case c == '\'':
+			// Converts single quoted string to double quoted string.
+			buffer.WriteByte('"')
+			for i++; i < length && content[i] != '\''; i++ {
+				switch content[i] {
+				case '\\':
+					if i+1 < length && content[i+1] == '\'' {
+						buffer.WriteByte('\'')
+					} else if i+1 < length {
+						buffer.WriteString(content[i : i+2])
+					}
+					i++
+				case '"':
+					buffer.WriteString(`\"`)
+				default:
+					buffer.WriteByte(content[i])
+				}
+			}
+			buffer.WriteByte('"')
+
+		case c == ',':
+			// Trailing comma.
+			if next := nextNonSpace(content, i+1); next < length && (content[next] == '}' || content[next] == ']') {
+				continue
+			}
+			buffer.WriteByte(c)
+
+		case isIdentifierStart(c):
+			start := i
+			for i+1 < length && isIdentifierPart(content[i+1]) {
+				i++
+			}
+			word := content[start : i+1]
+			if next := nextNonSpace(content, i+1); next < length && content[next] == ':' {
+				buffer.WriteString(strconv.Quote(word))
+			} else {
+				buffer.WriteString(word)
+			}
+
+		case c == '+' && i+1 < length && (isDigit(content[i+1]) || content[i+1] == '.'):
+			// Leading '+' of number is removed.
+
+		case c == '0' && i+1 < length && (content[i+1] == 'x' || content[i+1] == 'X'):
+			start := i
+			for i += 2; i < length && isHexDigit(content[i]); i++ {
+			}
+			number, err := strconv.ParseInt(content[start+2:i], 16, 64)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf(`invalid hexadecimal number "%s" at offset %d`, content[start:i], start))
+			}
+			buffer.WriteString(strconv.FormatInt(number, 10))
+			i--
+
+		default:
+			buffer.WriteByte(c)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// nextNonSpace returns the position of the next non-space character of <s> from <pos>.
+// It returns the length of <s> if there's no non-space character.
+func nextNonSpace(s string, pos int) int {
+	for pos < len(s) && (s[pos] == ' ' || s[pos] == '\t' || s[pos] == '\n' || s[pos] == '\r') {
+		pos++
+	}
+	return pos
+}
+
+func isIdentifierStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '$'
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
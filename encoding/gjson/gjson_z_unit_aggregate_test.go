@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ichunt2019/gf/encoding/gjson"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_Aggregate(t *testing.T) {
+	data := `{
+	"orders": [
+		{"id": 1, "amount": 10.5, "items": [{"price": 1}, {"price": 2}]},
+		{"id": 2, "amount": 20,   "items": [{"price": 3}]},
+		{"id": 3, "amount": 30.5, "items": []}
+	],
+	"scores": [90, 80, 70],
+	"empty":  [],
+	"names":  ["john", "smith"]
+}`
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.DecodeToJson(data)
+		t.Assert(err, nil)
+
+		v, err := j.Aggregate("orders.*.amount", "sum")
+		t.Assert(err, nil)
+		t.Assert(v, 61)
+		v, err = j.Aggregate("orders.*.amount", "avg")
+		t.Assert(err, nil)
+		t.Assert(v, 61.0/3)
+		v, err = j.Aggregate("orders.*.amount", "min")
+		t.Assert(err, nil)
+		t.Assert(v, 10.5)
+		v, err = j.Aggregate("orders.*.amount", "max")
+		t.Assert(err, nil)
+		t.Assert(v, 30.5)
+		v, err = j.Aggregate("orders.*.amount", "count")
+		t.Assert(err, nil)
+		t.AssertEQ(v, 3)
+
+		// Array value.
+		v, err = j.Aggregate("scores", "avg")
+		t.Assert(err, nil)
+		t.Assert(v, 80)
+		v, err = j.Aggregate("orders.1.amount", "sum")
+		t.Assert(err, nil)
+		t.Assert(v, 20)
+	})
+	// Nested array paths.
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.DecodeToJson(data)
+		t.Assert(err, nil)
+
+		v, err := j.Aggregate("orders.*.items.*.price", "sum")
+		t.Assert(err, nil)
+		t.Assert(v, 6)
+		v, err = j.Aggregate("orders.*.items.*.price", "count")
+		t.Assert(err, nil)
+		t.Assert(v, 3)
+		v, err = j.Aggregate("orders.*.items.*.price", "max")
+		t.Assert(err, nil)
+		t.Assert(v, 3)
+	})
+	// Empty arrays.
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.DecodeToJson(data)
+		t.Assert(err, nil)
+
+		v, err := j.Aggregate("empty", "sum")
+		t.Assert(err, nil)
+		t.AssertEQ(v, float64(0))
+		v, err = j.Aggregate("empty", "count")
+		t.Assert(err, nil)
+		t.AssertEQ(v, 0)
+		for _, fn := range []string{"avg", "min", "max"} {
+			v, err = j.Aggregate("empty", fn)
+			t.Assert(err, nil)
+			t.Assert(v, nil)
+		}
+		v, err = j.Aggregate("orders.2.items.*.price", "sum")
+		t.Assert(err, nil)
+		t.AssertEQ(v, float64(0))
+		v, err = j.Aggregate("none.*.amount", "max")
+		t.Assert(err, nil)
+		t.Assert(v, nil)
+	})
+	// Non-numeric values.
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.DecodeToJson(data)
+		t.Assert(err, nil)
+
+		_, err = j.Aggregate("names", "sum")
+		t.AssertNE(err, nil)
+		_, err = j.Aggregate("orders", "max")
+		t.AssertNE(err, nil)
+		v, err := j.Aggregate("names", "count")
+		t.Assert(err, nil)
+		t.Assert(v, 2)
+		_, err = j.Aggregate("scores", "median")
+		t.AssertNE(err, nil)
+	})
+	// Numbers of various types.
+	gtest.C(t, func(t *gtest.T) {
+		j := gjson.New(map[string]interface{}{
+			"a": []interface{}{json.Number("1"), 2, uint8(3), float32(0.5)},
+		})
+		v, err := j.Aggregate("a", "sum")
+		t.Assert(err, nil)
+		t.Assert(v, 6.5)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/encoding/gjson"
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_StripComments(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		input := `{
+	// single-line comment
+	"name": "john", // trailing comment
+	/* block
+	   comment */
+	"age": /* inline */ 18
+}`
+		result, err := gjson.StripComments(input)
+		t.Assert(err, nil)
+		t.Assert(gjson.Valid(result), true)
+		t.Assert(result, "{\n\t\n\t\"name\": \"john\", \n\t\n\n\t\"age\":  18\n}")
+
+		j, err := gjson.DecodeToJson(result)
+		t.Assert(err, nil)
+		t.Assert(j.Map(), g.Map{"name": "john", "age": 18})
+	})
+	// Comment-like sequences in string literals.
+	gtest.C(t, func(t *gtest.T) {
+		input := `{"url": "http://goframe.org", "glob": "/* not comment */", 'single': '// kept', "escaped": "\"// kept /*"}`
+		result, err := gjson.StripComments(input)
+		t.Assert(err, nil)
+		t.Assert(result, input)
+	})
+	// Block comments are not nested, which end at the first "*/".
+	gtest.C(t, func(t *gtest.T) {
+		result, err := gjson.StripComments(`{/* outer /* inner */ "k": "v"}`)
+		t.Assert(err, nil)
+		t.Assert(result, `{ "k": "v"}`)
+
+		result, err = gjson.StripComments(`[1, /* outer /* inner */ 2 */]`)
+		t.Assert(err, nil)
+		t.Assert(result, `[1,  2 */]`)
+		t.Assert(gjson.Valid(result), false)
+
+		result, err = gjson.StripComments(`[1 /* // */, 2 // /* */ end`)
+		t.Assert(err, nil)
+		t.Assert(result, `[1 , 2 `)
+	})
+	// Errors.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gjson.StripComments(`{"k": "v"} /* unterminated`)
+		t.AssertNE(err, nil)
+		_, err = gjson.StripComments(`{"k": "v}`)
+		t.AssertNE(err, nil)
+		_, err = gjson.StripComments(`{"k": "v\"}`)
+		t.AssertNE(err, nil)
+
+		result, err := gjson.StripComments("")
+		t.Assert(err, nil)
+		t.Assert(result, "")
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/encoding/gjson"
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_LoadContentType_Json5(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		content := `
+// Server configuration.
+{
+	name: 'gf "server"',
+	address: ":8199", /* inline */
+	$version: +1.5,
+	mask: 0xFF,
+	url: 'http://goframe.org/*path*/',
+	escaped: 'it\'s',
+	tags: ['a', 'b',],
+	nested: {
+		enabled: true,
+		empty: null,
+	},
+}`
+		j, err := gjson.LoadContentType("json5", content)
+		t.Assert(err, nil)
+		t.Assert(j.GetString("name"), `gf "server"`)
+		t.Assert(j.GetString("address"), ":8199")
+		t.Assert(j.GetFloat64("$version"), 1.5)
+		t.Assert(j.GetInt("mask"), 255)
+		t.Assert(j.GetString("url"), "http://goframe.org/*path*/")
+		t.Assert(j.GetString("escaped"), "it's")
+		t.Assert(j.GetStrings("tags"), g.SliceStr{"a", "b"})
+		t.Assert(j.GetBool("nested.enabled"), true)
+		t.Assert(j.Get("nested.empty"), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gjson.IsValidDataType("json5"), true)
+		t.Assert(gjson.IsValidDataType(".json5"), true)
+
+		_, err := gjson.LoadContentType("json5", `{k: 'v' /* unterminated`)
+		t.AssertNE(err, nil)
+		_, err = gjson.LoadContentType("json5", `{k: v}`)
+		t.AssertNE(err, nil)
+	})
+}
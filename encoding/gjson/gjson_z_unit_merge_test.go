@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gjson_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/encoding/gjson"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_MergeJSON_Flat(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j1, err := gjson.DecodeToJson(`{"a":1,"b":2}`)
+		t.Assert(err, nil)
+		j2, err := gjson.DecodeToJson(`{"b":3,"c":4}`)
+		t.Assert(err, nil)
+		t.Assert(j1.MergeJSON(j2, false), nil)
+		t.Assert(j1.Get("a"), 1)
+		t.Assert(j1.Get("b"), 3)
+		t.Assert(j1.Get("c"), 4)
+		// The other object is not changed.
+		t.Assert(j2.Contains("a"), false)
+	})
+}
+
+func Test_MergeJSON_Nested(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j1, _ := gjson.DecodeToJson(`{"db":{"host":"127.0.0.1","port":3306},"name":"app"}`)
+		j2, _ := gjson.DecodeToJson(`{"db":{"port":3307,"user":"root"}}`)
+		t.Assert(j1.MergeJSON(j2, true), nil)
+		t.Assert(j1.Get("db.host"), "127.0.0.1")
+		t.Assert(j1.Get("db.port"), 3307)
+		t.Assert(j1.Get("db.user"), "root")
+		t.Assert(j1.Get("name"), "app")
+
+		// Later changes of the other object do not affect the merged one.
+		t.Assert(j2.Set("db.user", "admin"), nil)
+		t.Assert(j1.Get("db.user"), "root")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j1, _ := gjson.DecodeToJson(`{"db":{"host":"127.0.0.1","port":3306}}`)
+		j2, _ := gjson.DecodeToJson(`{"db":{"port":3307}}`)
+		t.Assert(j1.MergeJSON(j2, false), nil)
+		t.Assert(j1.Contains("db.host"), false)
+		t.Assert(j1.Get("db.port"), 3307)
+	})
+}
+
+func Test_MergeJSON_ArrayOverObject(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j1, _ := gjson.DecodeToJson(`{"items":{"a":1},"list":[1,2,3]}`)
+		j2, _ := gjson.DecodeToJson(`{"items":[1,2],"list":[4]}`)
+		t.Assert(j1.MergeJSON(j2, true), nil)
+		t.Assert(j1.Get("items"), []interface{}{1, 2})
+		t.Assert(j1.Get("list"), []interface{}{4})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j1, _ := gjson.DecodeToJson(`{"a":1}`)
+		j2, _ := gjson.DecodeToJson(`[1,2]`)
+		t.AssertNE(j1.MergeJSON(j2, true), nil)
+		t.AssertNE(j2.MergeJSON(j1, true), nil)
+	})
+}
+
+func Test_MergeAll(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j1, _ := gjson.DecodeToJson(`{"a":1,"m":{"x":1}}`)
+		j2, _ := gjson.DecodeToJson(`{"b":2,"m":{"y":2}}`)
+		j3, _ := gjson.DecodeToJson(`{"a":3,"m":{"x":3}}`)
+		j, err := gjson.MergeAll(j1, nil, j2, j3)
+		t.Assert(err, nil)
+		t.Assert(j.Get("a"), 3)
+		t.Assert(j.Get("b"), 2)
+		t.Assert(j.Get("m.x"), 3)
+		t.Assert(j.Get("m.y"), 2)
+		t.Assert(j1.Get("a"), 1)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j, err := gjson.MergeAll()
+		t.Assert(err, nil)
+		t.Assert(j.Map(), map[string]interface{}{})
+	})
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/ichunt2019/gf/container/gmap"
 	"github.com/ichunt2019/gf/encoding/gjson"
 	"github.com/ichunt2019/gf/os/gfile"
-	"github.com/ichunt2019/gf/os/gfsnotify"
 	"github.com/ichunt2019/gf/os/glog"
 	"github.com/ichunt2019/gf/os/gspath"
 )
@@ -36,11 +35,14 @@ type Config struct {
 	defaultName   string           // Default configuration file name.
 	searchPaths   *garray.StrArray // Searching path array.
 	jsonMap       *gmap.StrAnyMap  // The pared JSON objects for configuration files.
+	aliases       *gmap.StrStrMap  // Alias key to canonical key mapping for configuration keys.
+	watchers      *gmap.StrAnyMap  // Configuration file name to its file watching callback mapping.
+	callbacks     *garray.Array    // Callback functions called when the configuration changes.
 	violenceCheck bool             // Whether do violence check in value index searching. It affects the performance when set true(false in default).
 }
 
 var (
-	supportedFileTypes = []string{"toml", "yaml", "json", "ini", "xml"}
+	supportedFileTypes = []string{"toml", "yaml", "json", "json5", "ini", "xml", "env"}
 	resourceTryFiles   = []string{"", "/", "config/", "config", "/config", "/config/"}
 )
 
@@ -60,6 +62,9 @@ func New(file ...string) *Config {
 		defaultName: name,
 		searchPaths: garray.NewStrArray(true),
 		jsonMap:     gmap.NewStrAnyMap(true),
+		aliases:     gmap.NewStrStrMap(true),
+		watchers:    gmap.NewStrAnyMap(true),
+		callbacks:   garray.New(true),
 	}
 	// Customized dir path from env/cmd.
 	if customPath := gcmd.GetOptWithEnv(fmt.Sprintf("%s.path", cmdEnvKey)).String(); customPath != "" {
@@ -85,7 +90,24 @@ func New(file ...string) *Config {
 	return c
 }
 
-func (c *Config) getSearchPaths() []string {
+// Clone creates and returns a new configuration object, which has the same search paths,
+// default file name, key aliases and violence check setting as current object, but an empty configuration cache.
+// The returned object can be changed independently without affecting current object.
+func (c *Config) Clone() *Config {
+	return &Config{
+		defaultName:   c.defaultName,
+		searchPaths:   c.searchPaths.Clone(),
+		jsonMap:       gmap.NewStrAnyMap(true),
+		aliases:       gmap.NewStrStrMapFrom(c.aliases.Map(), true),
+		watchers:      gmap.NewStrAnyMap(true),
+		callbacks:     garray.New(true),
+		violenceCheck: c.violenceCheck,
+	}
+}
+
+// GetSearchPaths returns the directory paths in which the configuration files are searched.
+// The main package path is prepended if it is not in the search paths.
+func (c *Config) GetSearchPaths() []string {
 	var (
 		searchPaths = c.searchPaths.Slice()
 		mainPkgPath = gfile.MainPkgPath()
@@ -107,7 +129,7 @@ func (c *Config) filePath(file ...string) (path string) {
 	path = c.FilePath(name)
 	if path == "" {
 		var (
-			searchPaths = c.getSearchPaths()
+			searchPaths = c.GetSearchPaths()
 			buffer      = bytes.NewBuffer(nil)
 		)
 
@@ -272,6 +294,39 @@ func (c *Config) AddPath(path string) error {
 	return nil
 }
 
+// RemovePath removes a absolute or relative path from the search paths.
+// It also clears the configuration cache, just like SetPath does.
+func (c *Config) RemovePath(path string) error {
+	realPath := ""
+	if c.searchPaths.Contains(path) {
+		realPath = path
+	} else if p := gfile.RealPath(path); p != "" && c.searchPaths.Contains(p) {
+		// Absolute path.
+		realPath = p
+	} else {
+		// Relative path.
+		c.searchPaths.RLockFunc(func(array []string) {
+			for _, v := range array {
+				if p, _ := gspath.Search(v, path); p != "" && gstr.InArray(array, p) {
+					realPath = p
+					break
+				}
+			}
+		})
+	}
+	if realPath == "" {
+		err := fmt.Errorf(`[gcfg] RemovePath failed: path "%s" is not in the search paths`, path)
+		if errorPrint() {
+			glog.Error(err)
+		}
+		return err
+	}
+	c.searchPaths.RemoveValue(realPath)
+	c.jsonMap.Clear()
+	intlog.Print("RemovePath:", realPath)
+	return nil
+}
+
 // GetFilePath returns the absolute path of the specified configuration file.
 // If <file> is not passed, it returns the configuration file path of the default name.
 // If the specified configuration file does not exist,
@@ -281,7 +336,7 @@ func (c *Config) FilePath(file ...string) (path string) {
 	if len(file) > 0 {
 		name = file[0]
 	}
-	searchPaths := c.getSearchPaths()
+	searchPaths := c.GetSearchPaths()
 	// Searching resource manager.
 	if !gres.IsEmpty() {
 		for _, v := range resourceTryFiles {
@@ -374,7 +429,12 @@ func (c *Config) getJson(file ...string) *gjson.Json {
 			err error
 		)
 		dataType := gfile.ExtName(name)
-		if gjson.IsValidDataType(dataType) && !isFromConfigContent {
+		if dataType == envFileType && !isFromConfigContent {
+			var m map[string]interface{}
+			if m, err = parseEnvContent(content); err == nil {
+				j = gjson.New(m, true)
+			}
+		} else if gjson.IsValidDataType(dataType) && !isFromConfigContent {
 			j, err = gjson.LoadContentType(dataType, content, true)
 		} else {
 			j, err = gjson.LoadContent(content, true)
@@ -384,10 +444,7 @@ func (c *Config) getJson(file ...string) *gjson.Json {
 			// Add monitor for this configuration file,
 			// any changes of this file will refresh its cache in Config object.
 			if filePath != "" && !gres.Contains(filePath) {
-				_, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
-					c.jsonMap.Remove(name)
-				})
-				if err != nil && errorPrint() {
+				if err = c.watchFile(name, filePath); err != nil && errorPrint() {
 					glog.Error(err)
 				}
 			}
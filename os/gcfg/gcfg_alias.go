@@ -0,0 +1,45 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcfg
+
+import (
+	"github.com/ichunt2019/gf/os/glog"
+)
+
+// AddAlias adds an alias key <alias> for the canonical configuration key <canonical>,
+// which is commonly used for renaming configuration keys while keeping the old keys working.
+//
+// Eg: after AddAlias("db_host", "database.host"), GetString("db_host") returns
+// the same value as GetString("database.host").
+func (c *Config) AddAlias(alias, canonical string) {
+	c.aliases.Set(alias, canonical)
+}
+
+// RemoveAlias removes the alias key <alias>.
+func (c *Config) RemoveAlias(alias string) {
+	c.aliases.Remove(alias)
+}
+
+// ListAliases returns a copy of all aliases, the key is the alias and the value is the canonical key.
+func (c *Config) ListAliases() map[string]string {
+	return c.aliases.Map()
+}
+
+// resolveAlias returns the canonical key for <pattern> if it is an alias key,
+// or else it returns <pattern> itself.
+func (c *Config) resolveAlias(pattern string) string {
+	if c.aliases.IsEmpty() {
+		return pattern
+	}
+	if canonical, ok := c.aliases.Search(pattern); ok {
+		if errorPrint() {
+			glog.Warningf(`Configuration key "%s" is an alias of "%s", please use "%s" instead`, pattern, canonical, canonical)
+		}
+		return canonical
+	}
+	return pattern
+}
@@ -19,9 +19,13 @@ import (
 // Set sets value with specified <pattern>.
 // It supports hierarchical data access by char separator, which is '.' in default.
 // It is commonly used for updates certain configuration value in runtime.
+// The callbacks registered by OnChange are called if it succeeds.
 func (c *Config) Set(pattern string, value interface{}) error {
 	if j := c.getJson(); j != nil {
-		return j.Set(pattern, value)
+		if err := j.Set(c.resolveAlias(pattern), value); err != nil {
+			return err
+		}
+		c.notifyChange(c.defaultName)
 	}
 	return nil
 }
@@ -36,7 +40,7 @@ func (c *Config) Set(pattern string, value interface{}) error {
 // It returns a default value specified by <def> if value for <pattern> is not found.
 func (c *Config) Get(pattern string, def ...interface{}) interface{} {
 	if j := c.getJson(); j != nil {
-		return j.Get(pattern, def...)
+		return j.Get(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -44,7 +48,7 @@ func (c *Config) Get(pattern string, def ...interface{}) interface{} {
 // GetVar returns a gvar.Var with value by given <pattern>.
 func (c *Config) GetVar(pattern string, def ...interface{}) *gvar.Var {
 	if j := c.getJson(); j != nil {
-		return j.GetVar(pattern, def...)
+		return j.GetVar(c.resolveAlias(pattern), def...)
 	}
 	return gvar.New(nil)
 }
@@ -52,7 +56,7 @@ func (c *Config) GetVar(pattern string, def ...interface{}) *gvar.Var {
 // Contains checks whether the value by specified <pattern> exist.
 func (c *Config) Contains(pattern string) bool {
 	if j := c.getJson(); j != nil {
-		return j.Contains(pattern)
+		return j.Contains(c.resolveAlias(pattern))
 	}
 	return false
 }
@@ -60,7 +64,7 @@ func (c *Config) Contains(pattern string) bool {
 // GetMap retrieves and returns the value by specified <pattern> as map[string]interface{}.
 func (c *Config) GetMap(pattern string, def ...interface{}) map[string]interface{} {
 	if j := c.getJson(); j != nil {
-		return j.GetMap(pattern, def...)
+		return j.GetMap(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -68,7 +72,7 @@ func (c *Config) GetMap(pattern string, def ...interface{}) map[string]interface
 // GetMapStrStr retrieves and returns the value by specified <pattern> as map[string]string.
 func (c *Config) GetMapStrStr(pattern string, def ...interface{}) map[string]string {
 	if j := c.getJson(); j != nil {
-		return j.GetMapStrStr(pattern, def...)
+		return j.GetMapStrStr(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -77,7 +81,7 @@ func (c *Config) GetMapStrStr(pattern string, def ...interface{}) map[string]str
 // and converts it to a slice of []interface{}.
 func (c *Config) GetArray(pattern string, def ...interface{}) []interface{} {
 	if j := c.getJson(); j != nil {
-		return j.GetArray(pattern, def...)
+		return j.GetArray(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -85,7 +89,7 @@ func (c *Config) GetArray(pattern string, def ...interface{}) []interface{} {
 // GetBytes retrieves the value by specified <pattern> and converts it to []byte.
 func (c *Config) GetBytes(pattern string, def ...interface{}) []byte {
 	if j := c.getJson(); j != nil {
-		return j.GetBytes(pattern, def...)
+		return j.GetBytes(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -93,7 +97,7 @@ func (c *Config) GetBytes(pattern string, def ...interface{}) []byte {
 // GetString retrieves the value by specified <pattern> and converts it to string.
 func (c *Config) GetString(pattern string, def ...interface{}) string {
 	if j := c.getJson(); j != nil {
-		return j.GetString(pattern, def...)
+		return j.GetString(c.resolveAlias(pattern), def...)
 	}
 	return ""
 }
@@ -101,7 +105,7 @@ func (c *Config) GetString(pattern string, def ...interface{}) string {
 // GetStrings retrieves the value by specified <pattern> and converts it to []string.
 func (c *Config) GetStrings(pattern string, def ...interface{}) []string {
 	if j := c.getJson(); j != nil {
-		return j.GetStrings(pattern, def...)
+		return j.GetStrings(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -110,7 +114,7 @@ func (c *Config) GetStrings(pattern string, def ...interface{}) []string {
 // See GetArray.
 func (c *Config) GetInterfaces(pattern string, def ...interface{}) []interface{} {
 	if j := c.getJson(); j != nil {
-		return j.GetInterfaces(pattern, def...)
+		return j.GetInterfaces(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -121,7 +125,7 @@ func (c *Config) GetInterfaces(pattern string, def ...interface{}) []interface{}
 // or returns true instead.
 func (c *Config) GetBool(pattern string, def ...interface{}) bool {
 	if j := c.getJson(); j != nil {
-		return j.GetBool(pattern, def...)
+		return j.GetBool(c.resolveAlias(pattern), def...)
 	}
 	return false
 }
@@ -129,7 +133,7 @@ func (c *Config) GetBool(pattern string, def ...interface{}) bool {
 // GetFloat32 retrieves the value by specified <pattern> and converts it to float32.
 func (c *Config) GetFloat32(pattern string, def ...interface{}) float32 {
 	if j := c.getJson(); j != nil {
-		return j.GetFloat32(pattern, def...)
+		return j.GetFloat32(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -137,7 +141,7 @@ func (c *Config) GetFloat32(pattern string, def ...interface{}) float32 {
 // GetFloat64 retrieves the value by specified <pattern> and converts it to float64.
 func (c *Config) GetFloat64(pattern string, def ...interface{}) float64 {
 	if j := c.getJson(); j != nil {
-		return j.GetFloat64(pattern, def...)
+		return j.GetFloat64(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -145,7 +149,7 @@ func (c *Config) GetFloat64(pattern string, def ...interface{}) float64 {
 // GetFloats retrieves the value by specified <pattern> and converts it to []float64.
 func (c *Config) GetFloats(pattern string, def ...interface{}) []float64 {
 	if j := c.getJson(); j != nil {
-		return j.GetFloats(pattern, def...)
+		return j.GetFloats(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -153,7 +157,7 @@ func (c *Config) GetFloats(pattern string, def ...interface{}) []float64 {
 // GetInt retrieves the value by specified <pattern> and converts it to int.
 func (c *Config) GetInt(pattern string, def ...interface{}) int {
 	if j := c.getJson(); j != nil {
-		return j.GetInt(pattern, def...)
+		return j.GetInt(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -161,7 +165,7 @@ func (c *Config) GetInt(pattern string, def ...interface{}) int {
 // GetInt8 retrieves the value by specified <pattern> and converts it to int8.
 func (c *Config) GetInt8(pattern string, def ...interface{}) int8 {
 	if j := c.getJson(); j != nil {
-		return j.GetInt8(pattern, def...)
+		return j.GetInt8(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -169,7 +173,7 @@ func (c *Config) GetInt8(pattern string, def ...interface{}) int8 {
 // GetInt16 retrieves the value by specified <pattern> and converts it to int16.
 func (c *Config) GetInt16(pattern string, def ...interface{}) int16 {
 	if j := c.getJson(); j != nil {
-		return j.GetInt16(pattern, def...)
+		return j.GetInt16(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -177,7 +181,7 @@ func (c *Config) GetInt16(pattern string, def ...interface{}) int16 {
 // GetInt32 retrieves the value by specified <pattern> and converts it to int32.
 func (c *Config) GetInt32(pattern string, def ...interface{}) int32 {
 	if j := c.getJson(); j != nil {
-		return j.GetInt32(pattern, def...)
+		return j.GetInt32(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -185,7 +189,7 @@ func (c *Config) GetInt32(pattern string, def ...interface{}) int32 {
 // GetInt64 retrieves the value by specified <pattern> and converts it to int64.
 func (c *Config) GetInt64(pattern string, def ...interface{}) int64 {
 	if j := c.getJson(); j != nil {
-		return j.GetInt64(pattern, def...)
+		return j.GetInt64(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -193,7 +197,7 @@ func (c *Config) GetInt64(pattern string, def ...interface{}) int64 {
 // GetInts retrieves the value by specified <pattern> and converts it to []int.
 func (c *Config) GetInts(pattern string, def ...interface{}) []int {
 	if j := c.getJson(); j != nil {
-		return j.GetInts(pattern, def...)
+		return j.GetInts(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -201,7 +205,7 @@ func (c *Config) GetInts(pattern string, def ...interface{}) []int {
 // GetUint retrieves the value by specified <pattern> and converts it to uint.
 func (c *Config) GetUint(pattern string, def ...interface{}) uint {
 	if j := c.getJson(); j != nil {
-		return j.GetUint(pattern, def...)
+		return j.GetUint(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -209,7 +213,7 @@ func (c *Config) GetUint(pattern string, def ...interface{}) uint {
 // GetUint8 retrieves the value by specified <pattern> and converts it to uint8.
 func (c *Config) GetUint8(pattern string, def ...interface{}) uint8 {
 	if j := c.getJson(); j != nil {
-		return j.GetUint8(pattern, def...)
+		return j.GetUint8(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -217,7 +221,7 @@ func (c *Config) GetUint8(pattern string, def ...interface{}) uint8 {
 // GetUint16 retrieves the value by specified <pattern> and converts it to uint16.
 func (c *Config) GetUint16(pattern string, def ...interface{}) uint16 {
 	if j := c.getJson(); j != nil {
-		return j.GetUint16(pattern, def...)
+		return j.GetUint16(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -225,7 +229,7 @@ func (c *Config) GetUint16(pattern string, def ...interface{}) uint16 {
 // GetUint32 retrieves the value by specified <pattern> and converts it to uint32.
 func (c *Config) GetUint32(pattern string, def ...interface{}) uint32 {
 	if j := c.getJson(); j != nil {
-		return j.GetUint32(pattern, def...)
+		return j.GetUint32(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -233,7 +237,7 @@ func (c *Config) GetUint32(pattern string, def ...interface{}) uint32 {
 // GetUint64 retrieves the value by specified <pattern> and converts it to uint64.
 func (c *Config) GetUint64(pattern string, def ...interface{}) uint64 {
 	if j := c.getJson(); j != nil {
-		return j.GetUint64(pattern, def...)
+		return j.GetUint64(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -241,7 +245,7 @@ func (c *Config) GetUint64(pattern string, def ...interface{}) uint64 {
 // GetTime retrieves the value by specified <pattern> and converts it to time.Time.
 func (c *Config) GetTime(pattern string, format ...string) time.Time {
 	if j := c.getJson(); j != nil {
-		return j.GetTime(pattern, format...)
+		return j.GetTime(c.resolveAlias(pattern), format...)
 	}
 	return time.Time{}
 }
@@ -249,7 +253,7 @@ func (c *Config) GetTime(pattern string, format ...string) time.Time {
 // GetDuration retrieves the value by specified <pattern> and converts it to time.Duration.
 func (c *Config) GetDuration(pattern string, def ...interface{}) time.Duration {
 	if j := c.getJson(); j != nil {
-		return j.GetDuration(pattern, def...)
+		return j.GetDuration(c.resolveAlias(pattern), def...)
 	}
 	return 0
 }
@@ -257,7 +261,7 @@ func (c *Config) GetDuration(pattern string, def ...interface{}) time.Duration {
 // GetGTime retrieves the value by specified <pattern> and converts it to *gtime.Time.
 func (c *Config) GetGTime(pattern string, format ...string) *gtime.Time {
 	if j := c.getJson(); j != nil {
-		return j.GetGTime(pattern, format...)
+		return j.GetGTime(c.resolveAlias(pattern), format...)
 	}
 	return nil
 }
@@ -266,7 +270,7 @@ func (c *Config) GetGTime(pattern string, format ...string) *gtime.Time {
 // and converts it to a un-concurrent-safe Json object.
 func (c *Config) GetJson(pattern string, def ...interface{}) *gjson.Json {
 	if j := c.getJson(); j != nil {
-		return j.GetJson(pattern, def...)
+		return j.GetJson(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -275,7 +279,7 @@ func (c *Config) GetJson(pattern string, def ...interface{}) *gjson.Json {
 // and converts it to a slice of un-concurrent-safe Json object.
 func (c *Config) GetJsons(pattern string, def ...interface{}) []*gjson.Json {
 	if j := c.getJson(); j != nil {
-		return j.GetJsons(pattern, def...)
+		return j.GetJsons(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -284,7 +288,7 @@ func (c *Config) GetJsons(pattern string, def ...interface{}) []*gjson.Json {
 // and converts it to a map of un-concurrent-safe Json object.
 func (c *Config) GetJsonMap(pattern string, def ...interface{}) map[string]*gjson.Json {
 	if j := c.getJson(); j != nil {
-		return j.GetJsonMap(pattern, def...)
+		return j.GetJsonMap(c.resolveAlias(pattern), def...)
 	}
 	return nil
 }
@@ -293,7 +297,7 @@ func (c *Config) GetJsonMap(pattern string, def ...interface{}) map[string]*gjso
 // <pointer>. The <pointer> should be the pointer to an object.
 func (c *Config) GetStruct(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetStruct(pattern, pointer, mapping...)
+		return j.GetStruct(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -302,7 +306,7 @@ func (c *Config) GetStruct(pattern string, pointer interface{}, mapping ...map[s
 // Deprecated, use GetStruct instead.
 func (c *Config) GetStructDeep(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetStructDeep(pattern, pointer, mapping...)
+		return j.GetStructDeep(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -310,7 +314,7 @@ func (c *Config) GetStructDeep(pattern string, pointer interface{}, mapping ...m
 // GetStructs converts any slice to given struct slice.
 func (c *Config) GetStructs(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetStructs(pattern, pointer, mapping...)
+		return j.GetStructs(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -319,7 +323,7 @@ func (c *Config) GetStructs(pattern string, pointer interface{}, mapping ...map[
 // Deprecated, use GetStructs instead.
 func (c *Config) GetStructsDeep(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetStructsDeep(pattern, pointer, mapping...)
+		return j.GetStructsDeep(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -328,7 +332,7 @@ func (c *Config) GetStructsDeep(pattern string, pointer interface{}, mapping ...
 // See gconv.MapToMap.
 func (c *Config) GetMapToMap(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetMapToMap(pattern, pointer, mapping...)
+		return j.GetMapToMap(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -338,7 +342,7 @@ func (c *Config) GetMapToMap(pattern string, pointer interface{}, mapping ...map
 // See gconv.MapToMapDeep.
 func (c *Config) GetMapToMapDeep(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetMapToMapDeep(pattern, pointer, mapping...)
+		return j.GetMapToMapDeep(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -348,7 +352,7 @@ func (c *Config) GetMapToMapDeep(pattern string, pointer interface{}, mapping ..
 // See gconv.MapToMaps.
 func (c *Config) GetMapToMaps(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetMapToMaps(pattern, pointer, mapping...)
+		return j.GetMapToMaps(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -358,7 +362,7 @@ func (c *Config) GetMapToMaps(pattern string, pointer interface{}, mapping ...ma
 // See gconv.MapToMapsDeep.
 func (c *Config) GetMapToMapsDeep(pattern string, pointer interface{}, mapping ...map[string]string) error {
 	if j := c.getJson(); j != nil {
-		return j.GetMapToMapsDeep(pattern, pointer, mapping...)
+		return j.GetMapToMapsDeep(c.resolveAlias(pattern), pointer, mapping...)
 	}
 	return errors.New("configuration not found")
 }
@@ -0,0 +1,58 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcfg
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+const (
+	// File type for ".env" configuration file.
+	envFileType = "env"
+)
+
+// parseEnvContent parses the content of ".env" file format into a flat map.
+//
+// Each line of <content> is in format of KEY=VALUE, and the optional "export " prefix is ignored.
+// Empty lines and lines beginning with '#' are comments and are skipped.
+// Single or double quotes of the value are stripped.
+func parseEnvContent(content string) (map[string]interface{}, error) {
+	var (
+		m       = make(map[string]interface{})
+		scanner = bufio.NewScanner(strings.NewReader(content))
+		lineNum = 0
+	)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		pos := strings.IndexByte(line, '=')
+		if pos <= 0 {
+			return nil, fmt.Errorf(`invalid env format at line %d: %s`, lineNum, line)
+		}
+		var (
+			key   = strings.TrimSpace(line[:pos])
+			value = strings.TrimSpace(line[pos+1:])
+		)
+		if key == "" {
+			return nil, fmt.Errorf(`empty env key at line %d: %s`, lineNum, line)
+		}
+		if n := len(value); n > 1 && (value[0] == '"' || value[0] == '\'') && value[n-1] == value[0] {
+			value = value[1 : n-1]
+		}
+		m[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcfg
+
+import (
+	"github.com/ichunt2019/gf/os/gfsnotify"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/os/gres"
+)
+
+// WatchAll adds file watchers for all the configuration files currently loaded in cache,
+// any changes of a watched file invalidates only the cache of that file.
+// It is commonly used for re-enabling the file watching after StopWatch/StopWatchAll.
+//
+// The files that are already watched, and the configurations from resource manager
+// or custom content are ignored.
+func (c *Config) WatchAll() error {
+	for _, name := range c.jsonMap.Keys() {
+		filePath := c.filePath(name)
+		if filePath == "" || gres.Contains(filePath) {
+			continue
+		}
+		if err := c.watchFile(name, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StopWatch removes the file watchers of configuration files <file>,
+// or the default configuration file if <file> is not given.
+// The cache of the unwatched file is not refreshed any more when the file changes.
+func (c *Config) StopWatch(file ...string) {
+	if len(file) == 0 {
+		file = []string{c.defaultName}
+	}
+	for _, name := range file {
+		if v := c.watchers.Remove(name); v != nil {
+			if err := gfsnotify.RemoveCallback(v.(*gfsnotify.Callback).Id); err != nil && errorPrint() {
+				glog.Error(err)
+			}
+		}
+	}
+}
+
+// StopWatchAll removes the file watchers of all configuration files.
+func (c *Config) StopWatchAll() {
+	c.StopWatch(c.watchers.Keys()...)
+}
+
+// watchFile adds file watcher for configuration file <name> of path <filePath>, which removes
+// the cache of <name> if the file changes. It does nothing if the file is already watched.
+func (c *Config) watchFile(name, filePath string) (err error) {
+	c.watchers.GetOrSetFuncLock(name, func() interface{} {
+		var callback *gfsnotify.Callback
+		callback, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
+			c.jsonMap.Remove(name)
+			c.notifyChange(name)
+		})
+		if err != nil {
+			return nil
+		}
+		return callback
+	})
+	return
+}
+
+// OnChange registers callback function <callback>, which is called with the configuration file name
+// when the configuration changes, that is, a watched configuration file changes or Set is called.
+//
+// Note that the callback function may be called in another goroutine.
+func (c *Config) OnChange(callback func(file string)) {
+	c.callbacks.Append(callback)
+}
+
+// notifyChange calls the registered change callback functions with configuration file name <name>.
+func (c *Config) notifyChange(name string) {
+	for _, v := range c.callbacks.Slice() {
+		v.(func(file string))(name)
+	}
+}
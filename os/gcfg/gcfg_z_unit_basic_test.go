@@ -401,3 +401,121 @@ func TestCfg_With_UTF8_BOM(t *testing.T) {
 		t.Assert(cfg.GetString("test.testStr"), "test")
 	})
 }
+
+func TestCfg_Clone(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c1 := gcfg.New("c1.toml")
+		t.Assert(c1.SetPath("testdata"), nil)
+		t.Assert(c1.GetString("my-config"), "1")
+
+		c2 := c1.Clone()
+		t.Assert(c2.GetFileName(), "c1.toml")
+		t.Assert(c2.GetString("my-config"), "1")
+
+		t.Assert(c2.SetPath("testdata/folder1"), nil)
+		t.Assert(c2.GetString("my-config"), "2")
+		t.Assert(c1.GetString("my-config"), "1")
+
+		c2.SetFileName("c2.json")
+		t.Assert(c1.GetFileName(), "c1.toml")
+	})
+}
+
+func TestCfg_EnvFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.New(".env")
+		t.Assert(c.SetPath("testdata/envfile"), nil)
+		t.Assert(c.Available(), true)
+		t.Assert(c.GetString("DB_HOST"), "127.0.0.1")
+		t.Assert(c.GetInt("DB_PORT"), 3306)
+		t.Assert(c.GetString("DB_USER"), "root")
+		t.Assert(c.GetString("DB_PASS"), "pass=123")
+		t.Assert(c.GetString("APP_NAME"), "gf app")
+		t.Assert(c.Get("Database"), nil)
+	})
+}
+
+func TestCfg_Json5File(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.New("config.json5")
+		t.Assert(c.SetPath("testdata/json5"), nil)
+		t.Assert(c.Available(), true)
+		t.Assert(c.GetString("server.address"), ":8199")
+		t.Assert(c.GetString("server.name"), "gf server")
+		t.Assert(c.GetString("database.host"), "127.0.0.1")
+		t.Assert(c.GetInt("database.port"), 3306)
+		t.Assert(c.GetStrings("database.tags"), []string{"master", "slave"})
+	})
+}
+
+func TestCfg_GetSearchPaths(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.New()
+		t.Assert(c.SetPath("testdata"), nil)
+		paths := c.GetSearchPaths()
+		t.Assert(len(paths) > 0, true)
+		t.Assert(paths[len(paths)-1], gfile.RealPath("testdata"))
+
+		t.Assert(c.AddPath("testdata/folder1"), nil)
+		paths = c.GetSearchPaths()
+		t.Assert(paths[len(paths)-1], gfile.RealPath("testdata/folder1"))
+	})
+}
+
+func TestCfg_RemovePath(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.New("c1.toml")
+		t.Assert(c.SetPath("testdata"), nil)
+		t.Assert(c.AddPath("testdata/folder1"), nil)
+		t.Assert(c.GetString("my-config"), "1")
+
+		t.Assert(c.RemovePath("testdata"), nil)
+		t.Assert(c.GetString("my-config"), "2")
+		t.AssertNE(c.RemovePath("testdata"), nil)
+
+		t.Assert(c.RemovePath(gfile.RealPath("testdata/folder1")), nil)
+		t.Assert(c.GetString("my-config"), "")
+		t.AssertNE(c.RemovePath("not-exist"), nil)
+	})
+}
+
+func TestCfg_Alias(t *testing.T) {
+	config := `
+[database]
+    host = "127.0.0.1"
+    port = 3306
+`
+	gtest.C(t, func(t *gtest.T) {
+		path := "alias.toml"
+		err := gfile.PutContents(path, config)
+		t.Assert(err, nil)
+		defer gfile.Remove(path)
+
+		c := gcfg.New(path)
+		c.AddAlias("db_host", "database.host")
+		c.AddAlias("db_port", "database.port")
+		t.Assert(c.GetString("db_host"), c.GetString("database.host"))
+		t.Assert(c.GetString("db_host"), "127.0.0.1")
+		t.Assert(c.GetInt("db_port"), 3306)
+		t.Assert(c.Contains("db_host"), true)
+		t.Assert(c.ListAliases(), g.MapStrStr{
+			"db_host": "database.host",
+			"db_port": "database.port",
+		})
+
+		// Value set via the canonical key is accessible via the alias.
+		t.Assert(c.Set("database.host", "192.168.0.1"), nil)
+		t.Assert(c.GetString("db_host"), "192.168.0.1")
+		// Value set via the alias is set to the canonical key.
+		t.Assert(c.Set("db_port", 3307), nil)
+		t.Assert(c.GetInt("database.port"), 3307)
+
+		// Clone keeps the aliases.
+		t.Assert(c.Clone().ListAliases(), c.ListAliases())
+
+		c.RemoveAlias("db_host")
+		t.Assert(c.GetString("db_host"), "")
+		t.Assert(c.Contains("db_host"), false)
+		t.Assert(c.ListAliases(), g.MapStrStr{"db_port": "database.port"})
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/container/garray"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_WatchAll(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path  = gfile.TempDir(gtime.TimestampNanoStr())
+			file1 = gfile.Join(path, "c1.json")
+			file2 = gfile.Join(path, "c2.json")
+		)
+		t.Assert(gfile.PutContents(file1, `{"name": "c1"}`), nil)
+		t.Assert(gfile.PutContents(file2, `{"name": "c2"}`), nil)
+		defer gfile.Remove(path)
+
+		c := New("c1.json")
+		t.Assert(c.SetPath(path), nil)
+		defer c.StopWatchAll()
+		t.Assert(c.GetString("name"), "c1")
+		t.Assert(c.SetFileName("c2.json").GetString("name"), "c2")
+		t.Assert(c.WatchAll(), nil)
+		t.Assert(c.watchers.Size(), 2)
+
+		// Only the cache of modified file is cleared.
+		t.Assert(gfile.PutContents(file1, `{"name": "c1-changed"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(c.jsonMap.Contains("c1.json"), false)
+		t.Assert(c.jsonMap.Contains("c2.json"), true)
+		t.Assert(c.SetFileName("c1.json").GetString("name"), "c1-changed")
+
+		t.Assert(gfile.PutContents(file2, `{"name": "c2-changed"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(c.jsonMap.Contains("c1.json"), true)
+		t.Assert(c.jsonMap.Contains("c2.json"), false)
+		t.Assert(c.SetFileName("c2.json").GetString("name"), "c2-changed")
+	})
+}
+
+func Test_StopWatch(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path  = gfile.TempDir(gtime.TimestampNanoStr())
+			file1 = gfile.Join(path, "c1.json")
+			file2 = gfile.Join(path, "c2.json")
+		)
+		t.Assert(gfile.PutContents(file1, `{"name": "c1"}`), nil)
+		t.Assert(gfile.PutContents(file2, `{"name": "c2"}`), nil)
+		defer gfile.Remove(path)
+
+		c := New("c1.json")
+		t.Assert(c.SetPath(path), nil)
+		defer c.StopWatchAll()
+		t.Assert(c.GetString("name"), "c1")
+		t.Assert(c.SetFileName("c2.json").GetString("name"), "c2")
+		t.Assert(c.watchers.Size(), 2)
+
+		// Stops watching c1.json, its changes do not clear the cache.
+		c.StopWatch("c1.json")
+		t.Assert(c.watchers.Size(), 1)
+		t.Assert(gfile.PutContents(file1, `{"name": "c1-changed"}`), nil)
+		t.Assert(gfile.PutContents(file2, `{"name": "c2-changed"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(c.jsonMap.Contains("c1.json"), true)
+		t.Assert(c.jsonMap.Contains("c2.json"), false)
+		t.Assert(c.SetFileName("c1.json").GetString("name"), "c1")
+
+		// Stops all.
+		c.StopWatchAll()
+		t.Assert(c.watchers.Size(), 0)
+		t.Assert(c.SetFileName("c2.json").GetString("name"), "c2-changed")
+		t.Assert(c.watchers.Size(), 1)
+		c.StopWatchAll()
+		t.Assert(gfile.PutContents(file2, `{"name": "c2"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(c.jsonMap.Contains("c2.json"), true)
+
+		// Re-enables watching.
+		t.Assert(c.WatchAll(), nil)
+		t.Assert(c.watchers.Size(), 2)
+		t.Assert(gfile.PutContents(file1, `{"name": "c1-changed-again"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(c.jsonMap.Contains("c1.json"), false)
+		t.Assert(c.jsonMap.Contains("c2.json"), true)
+	})
+}
+
+func Test_OnChange(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = gfile.Join(path, "c.json")
+		)
+		t.Assert(gfile.PutContents(file, `{"name": "c"}`), nil)
+		defer gfile.Remove(path)
+
+		c := New("c.json")
+		t.Assert(c.SetPath(path), nil)
+		defer c.StopWatchAll()
+
+		changes := garray.NewStrArray(true)
+		c.OnChange(func(file string) {
+			changes.Append(file)
+		})
+		t.Assert(c.GetString("name"), "c")
+
+		// Changes by Set.
+		t.Assert(c.Set("name", "c-set"), nil)
+		t.Assert(changes.Slice(), []string{"c.json"})
+
+		// Changes of file.
+		t.Assert(gfile.PutContents(file, `{"name": "c-changed"}`), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(changes.Len() > 1, true)
+		t.Assert(changes.Contains("c.json"), true)
+		t.Assert(c.GetString("name"), "c-changed")
+	})
+}
@@ -8,9 +8,12 @@
 package gcron
 
 import (
+	"context"
+	"io"
 	"math"
 	"time"
 
+	"github.com/ichunt2019/gf/os/gcfg"
 	"github.com/ichunt2019/gf/os/gtimer"
 )
 
@@ -20,6 +23,9 @@ const (
 	StatusStopped = gtimer.StatusStopped
 	StatusClosed  = gtimer.StatusClosed
 	defaultTimes  = math.MaxInt32
+
+	// dependsCheckInterval is the interval checking whether the depended entries finish running.
+	dependsCheckInterval = 10 * time.Millisecond
 )
 
 var (
@@ -76,6 +82,62 @@ func AddTimes(pattern string, times int, job func(), name ...string) (*Entry, er
 	return defaultCron.AddTimes(pattern, times, job, name...)
 }
 
+// AddWeighted adds a timed task with scheduling <weight> to default cron object.
+// The weighted timed tasks firing at the same tick are executed sequentially in descending weight order.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func AddWeighted(pattern string, weight int, job func(), name ...string) (*Entry, error) {
+	return defaultCron.AddWeighted(pattern, weight, job, name...)
+}
+
+// AddWithTimeout adds a timed task named <name> to default cron object,
+// whose <job> is called with a context cancelled after <timeout>.
+// It returns and error if the <name> is already used.
+func AddWithTimeout(pattern string, name string, timeout time.Duration, job func(ctx context.Context)) (*Entry, error) {
+	return defaultCron.AddWithTimeout(pattern, name, timeout, job)
+}
+
+// AddSingletonWithTimeout adds a singleton timed task to default cron object,
+// whose <job> is called with a context cancelled after <timeout>.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func AddSingletonWithTimeout(pattern string, timeout time.Duration, job func(ctx context.Context), name ...string) (*Entry, error) {
+	return defaultCron.AddSingletonWithTimeout(pattern, timeout, job, name...)
+}
+
+// AddWithOutput adds a timed task to default cron object, whose <job> is called with a writer
+// capturing its output, which is retained in the execution records of Entry.History.
+// It returns and error if the <name> is already used.
+func AddWithOutput(pattern string, job func(out io.Writer), name ...string) (*Entry, error) {
+	return defaultCron.AddWithOutput(pattern, job, name...)
+}
+
+// AddInterval adds a timed task running every <interval> to default cron object,
+// which supports sub-second intervals and the minimum <interval> is 1ms.
+func AddInterval(interval time.Duration, job func()) *Entry {
+	return defaultCron.AddInterval(interval, job)
+}
+
+// AddAfter adds a timed task depending on the timed tasks named <depends> to default cron object,
+// and returns the generated name of the added timed task.
+// It returns error if any of the depended timed tasks does not exist.
+func AddAfter(pattern string, job func(), depends ...string) (string, error) {
+	return defaultCron.AddAfter(pattern, job, depends...)
+}
+
+// AddWithWarmUp adds a timed task to default cron object, and executes its job once immediately.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func AddWithWarmUp(pattern string, job func(), name ...string) (*Entry, error) {
+	return defaultCron.AddWithWarmUp(pattern, job, name...)
+}
+
+// LoadConfig loads and adds timed tasks from configuration section <section> of <cfg>
+// to default cron object, see Cron.LoadConfig.
+func LoadConfig(cfg *gcfg.Config, section string) error {
+	return defaultCron.LoadConfig(cfg, section)
+}
+
 // DelayAdd adds a timed task to default cron object after <delay> time.
 func DelayAdd(delay time.Duration, pattern string, job func(), name ...string) {
 	defaultCron.DelayAdd(delay, pattern, job, name...)
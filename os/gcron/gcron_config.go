@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcron
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gcfg"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// configJob is the timed task definition in configuration.
+type configJob struct {
+	name    string        // Job name, which is the key in the configuration section.
+	spec    string        // Cron pattern of the job.
+	command string        // Shell command of the job.
+	timeout time.Duration // Timeout of the shell command, no timeout if it is not positive.
+}
+
+// LoadConfig loads and adds timed tasks from configuration section <section> of <cfg>, in which
+// each key is the job name and the value is a map with "spec", "command" and "timeout", eg:
+//
+//	[cron]
+//	    [cron.cleanup]
+//	        spec    = "0 0 3 * * *"
+//	        command = "rm -rf /tmp/cache/*"
+//	        timeout = "10m"
+//
+// The "command" is executed by the shell via os/exec, and its output is retained as Output in the
+// execution records of Entry.History, in which the failed command is recorded as unsuccessful.
+// The "timeout" is optional, and the command is killed if it does not finish in the timeout.
+//
+// The existing timed task of the same name is replaced, so that jobs can be changed without
+// redeploying by calling LoadConfig again, eg: in the callback of Config.OnChange.
+// The timed tasks loaded from <section> previously but no longer defined in it are removed.
+// No timed task is added if any of the job definitions is invalid.
+func (c *Cron) LoadConfig(cfg *gcfg.Config, section string) error {
+	data := cfg.GetMap(section)
+	if data == nil {
+		return errors.New(fmt.Sprintf(`cron config section "%s" not found`, section))
+	}
+	jobs := make([]configJob, 0, len(data))
+	for name, value := range data {
+		m := gconv.Map(value)
+		job := configJob{
+			name:    name,
+			spec:    gconv.String(m["spec"]),
+			command: gconv.String(m["command"]),
+			timeout: gconv.Duration(m["timeout"]),
+		}
+		if job.command == "" {
+			return errors.New(fmt.Sprintf(`command of cron job "%s" cannot be empty`, name))
+		}
+		if _, err := newSchedule(job.spec); err != nil {
+			return errors.New(fmt.Sprintf(`invalid spec of cron job "%s": %v`, name, err))
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].name < jobs[j].name
+	})
+	if v := c.sections.Get(section); v != nil {
+		for _, name := range v.([]string) {
+			if _, ok := data[name]; !ok {
+				c.Remove(name)
+			}
+		}
+	}
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		c.Remove(job.name)
+		if _, err := c.AddWithOutput(job.spec, commandJob(job), job.name); err != nil {
+			c.sections.Set(section, names)
+			return err
+		}
+		names = append(names, job.name)
+	}
+	c.sections.Set(section, names)
+	return nil
+}
+
+// commandJob returns the job function executing the shell command of <job>,
+// which writes the output of the command to <out>. It panics if the command fails,
+// so that the run is recorded as unsuccessful.
+//
+// Note that it does not wait for the output when the command times out, as the output pipes
+// might be still held by the sub processes of the killed shell.
+func commandJob(job configJob) func(out io.Writer) {
+	return func(out io.Writer) {
+		ctx := context.Background()
+		if job.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, job.timeout)
+			defer cancel()
+		}
+		var (
+			cmd    *exec.Cmd
+			output = &commandOutput{}
+			done   = make(chan error, 1)
+		)
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd.exe", "/c", job.command)
+		} else {
+			cmd = exec.CommandContext(ctx, "/bin/sh", "-c", job.command)
+		}
+		cmd.Stdout = output
+		cmd.Stderr = output
+		if err := cmd.Start(); err != nil {
+			panic(errors.New(fmt.Sprintf(`command "%s" failed: %v`, job.command, err)))
+		}
+		go func() {
+			done <- cmd.Wait()
+		}()
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		out.Write(output.Bytes())
+		if err != nil {
+			panic(errors.New(fmt.Sprintf(`command "%s" failed: %v`, job.command, err)))
+		}
+	}
+}
+
+// commandOutput is the concurrent-safe buffer of command output.
+type commandOutput struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write implements the interface of io.Writer.
+func (o *commandOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buffer.Write(p)
+}
+
+// Bytes returns a copy of the output.
+func (o *commandOutput) Bytes() []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]byte(nil), o.buffer.Bytes()...)
+}
@@ -7,8 +7,10 @@
 package gcron
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/ichunt2019/gf/container/garray"
@@ -19,21 +21,35 @@ import (
 )
 
 type Cron struct {
-	idGen    *gtype.Int64    // Used for unique name generation.
-	status   *gtype.Int      // Timed task status(0: Not Start; 1: Running; 2: Stopped; -1: Closed)
-	entries  *gmap.StrAnyMap // All timed task entries.
-	logPath  *gtype.String   // Logging path(folder).
-	logLevel *gtype.Int      // Logging level.
+	idGen     *gtype.Int64     // Used for unique name generation.
+	status    *gtype.Int       // Timed task status(0: Not Start; 1: Running; 2: Stopped; -1: Closed)
+	entries   *gmap.StrAnyMap  // All timed task entries.
+	logPath   *gtype.String    // Logging path(folder).
+	logLevel  *gtype.Int       // Logging level.
+	weighted  *gtype.Bool      // Whether the weighted dispatcher is started.
+	store     *gtype.Interface // Job store persisting running states of entries, see SetJobStore.
+	states    *gmap.StrAnyMap  // Loaded running states from job store, which are not recovered yet.
+	policy    *gtype.Int       // Missed run policy, see SetMissedRunPolicy.
+	leader    *gtype.Interface // Leader election, see SetLeaderElection.
+	retention *gtype.Int       // Count of execution records retained for each entry, see SetHistoryRetention.
+	sections  *gmap.StrAnyMap  // Names of the jobs loaded from each configuration section, see LoadConfig.
 }
 
 // New returns a new Cron object with default settings.
 func New() *Cron {
 	return &Cron{
-		idGen:    gtype.NewInt64(),
-		status:   gtype.NewInt(StatusRunning),
-		entries:  gmap.NewStrAnyMap(true),
-		logPath:  gtype.NewString(),
-		logLevel: gtype.NewInt(glog.LEVEL_PROD),
+		idGen:     gtype.NewInt64(),
+		status:    gtype.NewInt(StatusRunning),
+		entries:   gmap.NewStrAnyMap(true),
+		logPath:   gtype.NewString(),
+		logLevel:  gtype.NewInt(glog.LEVEL_PROD),
+		weighted:  gtype.NewBool(),
+		store:     gtype.NewInterface(),
+		states:    gmap.NewStrAnyMap(true),
+		policy:    gtype.NewInt(int(MissedRunSkip)),
+		leader:    gtype.NewInterface(),
+		retention: gtype.NewInt(defaultHistoryRetention),
+		sections:  gmap.NewStrAnyMap(true),
 	}
 }
 
@@ -106,6 +122,90 @@ func (c *Cron) AddTimes(pattern string, times int, job func(), name ...string) (
 	}
 }
 
+// AddWeighted adds a timed task with scheduling <weight>.
+// The weighted timed tasks firing at the same tick are executed sequentially in descending weight order,
+// which is useful for ensuring that critical jobs run before lower-priority jobs sharing a schedule.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func (c *Cron) AddWeighted(pattern string, weight int, job func(), name ...string) (*Entry, error) {
+	if entry, err := c.Add(pattern, job, name...); err != nil {
+		return nil, err
+	} else {
+		entry.SetWeight(weight)
+		return entry, nil
+	}
+}
+
+// AddWithWarmUp adds a timed task, and executes its job once immediately in a new goroutine,
+// which is useful for jobs that should run at startup rather than waiting for the first scheduled time.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func (c *Cron) AddWithWarmUp(pattern string, job func(), name ...string) (*Entry, error) {
+	if entry, err := c.Add(pattern, job, name...); err != nil {
+		return nil, err
+	} else {
+		return entry, entry.RunNow()
+	}
+}
+
+// AddWithTimeout adds a timed task named <name>, whose <job> is called with a context cancelled
+// after <timeout>. If <job> does not return by the deadline, the run is marked as timed-out in
+// Entry.Stats and recorded as failed in Entry.History, and the entry no longer waits for it, so that a slow job cannot block its future runs,
+// eg: in singleton mode. Note that <job> should return as soon as possible when the context is done,
+// as its goroutine cannot be stopped forcibly.
+// It returns and error if the <name> is already used.
+func (c *Cron) AddWithTimeout(pattern string, name string, timeout time.Duration, job func(ctx context.Context)) (*Entry, error) {
+	var names []string
+	if name != "" {
+		names = append(names, name)
+	}
+	return c.addTimeoutEntry(pattern, timeout, job, false, names...)
+}
+
+// AddSingletonWithTimeout adds a singleton timed task, whose <job> is called with a context
+// cancelled after <timeout>. It combines AddSingleton and AddWithTimeout: the run is skipped if
+// the previous run is still in flight, and the entry stops waiting for a run when it times out,
+// so that a hanging job cannot block the singleton timed task forever.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func (c *Cron) AddSingletonWithTimeout(pattern string, timeout time.Duration, job func(ctx context.Context), name ...string) (*Entry, error) {
+	return c.addTimeoutEntry(pattern, timeout, job, true, name...)
+}
+
+// AddInterval adds a timed task running every <interval>, which supports sub-second intervals,
+// eg: for high-frequency health probes and metric collection. It is driven by time.Ticker
+// instead of the cron schedule checked every second, and the minimum <interval> is 1ms.
+//
+// Note that the timed task added by AddInterval does not support weighted scheduling.
+func (c *Cron) AddInterval(interval time.Duration, job func()) *Entry {
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	return c.addIntervalEntry(interval, job)
+}
+
+// AddAfter adds a timed task depending on the timed tasks named <depends>, and returns the
+// generated name of the added timed task. When the timed task is triggered, it is deferred until
+// all the depended timed tasks finish their current running, which is useful for pipelines,
+// eg: the aggregation job runs after the data ingestion job.
+//
+// It returns error if any of the depended timed tasks does not exist.
+func (c *Cron) AddAfter(pattern string, job func(), depends ...string) (string, error) {
+	for _, name := range depends {
+		if c.Search(name) == nil {
+			return "", errors.New(fmt.Sprintf(`depended cron job "%s" does not exist`, name))
+		}
+	}
+	schedule, err := newSchedule(pattern)
+	if err != nil {
+		return "", err
+	}
+	entry := c.newEntry(schedule, job)
+	entry.depends = depends
+	c.startEntry(entry, false)
+	return entry.Name, nil
+}
+
 // DelayAdd adds a timed task after <delay> time.
 func (c *Cron) DelayAdd(delay time.Duration, pattern string, job func(), name ...string) {
 	gtimer.AddOnce(delay, func() {
@@ -163,6 +263,10 @@ func (c *Cron) Start(name ...string) {
 		}
 	} else {
 		c.status.Set(StatusReady)
+		// The "@reboot" timed tasks fire once after the cron starts.
+		for _, entry := range c.Entries() {
+			entry.reboot()
+		}
 	}
 }
 
@@ -201,7 +305,7 @@ func (c *Cron) Entries() []*Entry {
 	array := garray.NewSortedArraySize(c.entries.Size(), func(v1, v2 interface{}) int {
 		entry1 := v1.(*Entry)
 		entry2 := v2.(*Entry)
-		if entry1.Time.Nanosecond() > entry2.Time.Nanosecond() {
+		if entry1.Time.After(entry2.Time) {
 			return 1
 		}
 		return -1
@@ -219,3 +323,52 @@ func (c *Cron) Entries() []*Entry {
 	})
 	return entries
 }
+
+// startWeightedDispatcher starts the dispatcher for weighted entries, which checks the weighted
+// entries every second and executes the met ones in descending weight order.
+// It starts the dispatcher only once for each cron.
+func (c *Cron) startWeightedDispatcher() {
+	if c.weighted.Val() || !c.weighted.Cas(false, true) {
+		return
+	}
+	var dispatcher *gtimer.Entry
+	dispatcher = gtimer.Add(time.Second, func() {
+		if c.status.Val() == StatusClosed && c.entries.Size() == 0 {
+			dispatcher.Close()
+			return
+		}
+		c.dispatchWeighted(time.Now())
+	})
+}
+
+// dispatchWeighted executes the weighted entries which meet time <now> in descending weight order.
+func (c *Cron) dispatchWeighted(now time.Time) {
+	var entries []*Entry
+	c.entries.RLockFunc(func(m map[string]interface{}) {
+		for _, v := range m {
+			entry := v.(*Entry)
+			if !entry.weighted.Val() {
+				continue
+			}
+			switch entry.entry.Status() {
+			case StatusStopped, StatusClosed:
+				continue
+			}
+			if entry.IsSingleton() && entry.IsRunning() {
+				continue
+			}
+			if entry.schedule.meet(now) {
+				entries = append(entries, entry)
+			}
+		}
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		if wi, wj := entries[i].Weight(), entries[j].Weight(); wi != wj {
+			return wi > wj
+		}
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	for _, entry := range entries {
+		entry.run()
+	}
+}
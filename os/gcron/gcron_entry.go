@@ -7,6 +7,11 @@
 package gcron
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
 	"runtime"
 	"time"
@@ -15,6 +20,7 @@ import (
 	"github.com/ichunt2019/gf/os/glog"
 	"github.com/ichunt2019/gf/os/gtimer"
 	"github.com/ichunt2019/gf/util/gconv"
+	"github.com/ichunt2019/gf/util/grand"
 )
 
 // Timed task entry.
@@ -24,11 +30,26 @@ type Entry struct {
 	schedule *cronSchedule // Timed schedule object.
 	jobName  string        // Callback function name(address info).
 	times    *gtype.Int    // Running times limit.
+	runCount *gtype.Int64  // Executed times of the job.
+	running  *gtype.Int    // Count of the currently running job instances.
+	lastRun  *gtype.Int64  // Last running timestamp in nanoseconds.
+	weighted *gtype.Bool   // Whether the entry is scheduled by weight.
+	weight   *gtype.Int    // Scheduling weight, the higher weight entry runs first in the same tick.
+	depends  []string      // Names of depended entries, which should finish running before the entry runs.
+	interval time.Duration // Running interval of the entry driven by time.Ticker, see Cron.AddInterval.
+	timeouts *gtype.Int64  // Timed-out times of the job, see Cron.AddWithTimeout.
+	history  *jobHistory   // Execution records of the job.
+	output   outputFunc    // Job writing output, which replaces Job for execution, see Cron.AddWithOutput.
+	rebooted *gtype.Bool   // Whether the "@reboot" entry has fired at startup.
+	jitter   *gtype.Int64  // Maximum random delay in nanoseconds before each execution, see SetJitter.
 	Name     string        // Entry name.
 	Job      func()        `json:"-"` // Callback function.
 	Time     time.Time     // Registered time.
 }
 
+// outputFunc is the job function writing output to <out>.
+type outputFunc func(out io.Writer)
+
 // addEntry creates and returns a new Entry object.
 // Param <job> is the callback function for timed task execution.
 // Param <singleton> specifies whether timed task executing in singleton mode.
@@ -39,11 +60,62 @@ func (c *Cron) addEntry(pattern string, job func(), singleton bool, name ...stri
 		return nil, err
 	}
 	// No limit for <times>, for gtimer checking scheduling every second.
+	entry := c.newEntry(schedule, job, name...)
+	c.startEntry(entry, singleton)
+	return entry, nil
+}
+
+// startEntry adds the <entry> to the cron and starts it.
+func (c *Cron) startEntry(entry *Entry, singleton bool) {
+	// When you add a scheduled task, you cannot allow it to run.
+	// It cannot start running when added to gtimer.
+	// It should start running after the entry is added to the entries map,
+	// to avoid the task from running during adding where the entries
+	// does not have the entry information, which might cause panic.
+	entry.entry = gtimer.AddEntry(time.Second, entry.check, singleton, -1, gtimer.StatusStopped)
+	c.entries.Set(entry.Name, entry)
+	entry.entry.Start()
+	c.recoverEntry(entry)
+	switch c.status.Val() {
+	case StatusReady, StatusRunning:
+		entry.reboot()
+	}
+}
+
+// addIntervalEntry creates and returns a new Entry object running every <interval>,
+// which is driven by time.Ticker instead of the cron schedule.
+func (c *Cron) addIntervalEntry(interval time.Duration, job func(), name ...string) *Entry {
+	entry := c.newEntry(&cronSchedule{
+		create:  time.Now().Unix(),
+		pattern: "@every " + interval.String(),
+	}, job, name...)
+	entry.interval = interval
+	// The gtimer.Entry only holds the status and singleton mode of the entry,
+	// which does nothing when it runs.
+	entry.entry = gtimer.AddEntry(time.Hour, func() {}, false, -1, gtimer.StatusStopped)
+	c.entries.Set(entry.Name, entry)
+	entry.entry.Start()
+	go entry.tick()
+	return entry
+}
+
+// newEntry creates and returns a new Entry object with <schedule> and its <job>,
+// which is not added to the cron yet.
+func (c *Cron) newEntry(schedule *cronSchedule, job func(), name ...string) *Entry {
 	entry := &Entry{
 		cron:     c,
 		schedule: schedule,
-		jobName:  runtime.FuncForPC(reflect.ValueOf(job).Pointer()).Name(),
+		jobName:  funcName(job),
 		times:    gtype.NewInt(defaultTimes),
+		runCount: gtype.NewInt64(),
+		running:  gtype.NewInt(),
+		lastRun:  gtype.NewInt64(),
+		weighted: gtype.NewBool(),
+		weight:   gtype.NewInt(),
+		timeouts: gtype.NewInt64(),
+		history:  &jobHistory{},
+		jitter:   gtype.NewInt64(),
+		rebooted: gtype.NewBool(),
 		Job:      job,
 		Time:     time.Now(),
 	}
@@ -52,15 +124,7 @@ func (c *Cron) addEntry(pattern string, job func(), singleton bool, name ...stri
 	} else {
 		entry.Name = "gcron-" + gconv.String(c.idGen.Add(1))
 	}
-	// When you add a scheduled task, you cannot allow it to run.
-	// It cannot start running when added to gtimer.
-	// It should start running after the entry is added to the entries map,
-	// to avoid the task from running during adding where the entries
-	// does not have the entry information, which might cause panic.
-	entry.entry = gtimer.AddEntry(time.Second, entry.check, singleton, -1, gtimer.StatusStopped)
-	c.entries.Set(entry.Name, entry)
-	entry.entry.Start()
-	return entry, nil
+	return entry
 }
 
 // IsSingleton return whether this entry is a singleton timed task.
@@ -98,6 +162,100 @@ func (entry *Entry) Stop() {
 	entry.entry.Stop()
 }
 
+// Spec returns the cron pattern of the entry.
+func (entry *Entry) Spec() string {
+	return entry.schedule.pattern
+}
+
+// NextRun returns the next running time of the entry after now.
+// It returns zero time if there's no next running time for the entry.
+func (entry *Entry) NextRun() time.Time {
+	if entry.interval > 0 {
+		elapsed := time.Since(entry.Time)
+		return entry.Time.Add((elapsed/entry.interval + 1) * entry.interval)
+	}
+	return entry.schedule.next(time.Now())
+}
+
+// LastRun returns the last running time of the entry.
+// It returns zero time if the entry has never run.
+func (entry *Entry) LastRun() time.Time {
+	if nano := entry.lastRun.Val(); nano > 0 {
+		return time.Unix(0, nano)
+	}
+	return time.Time{}
+}
+
+// RunCount returns the count that the job of the entry has been executed.
+func (entry *Entry) RunCount() int64 {
+	return entry.runCount.Val()
+}
+
+// EntryStats is the statistics snapshot of Entry.
+type EntryStats struct {
+	RunCount int64     // Executed times of the job.
+	Running  int       // Count of the currently running job instances.
+	Timeouts int64     // Timed-out times of the job, see Cron.AddWithTimeout.
+	LastRun  time.Time // Last running time, which is zero time if the entry has never run.
+}
+
+// Stats returns the statistics snapshot of the entry.
+func (entry *Entry) Stats() EntryStats {
+	return EntryStats{
+		RunCount: entry.runCount.Val(),
+		Running:  entry.running.Val(),
+		Timeouts: entry.timeouts.Val(),
+		LastRun:  entry.LastRun(),
+	}
+}
+
+// IsRunning checks and returns whether the job of the entry is currently running.
+func (entry *Entry) IsRunning() bool {
+	return entry.running.Val() > 0
+}
+
+// SetJitter sets the maximum random delay <max> before each execution of the entry, which spreads
+// the executions of the entries firing at the same time to avoid thundering herd on downstream services.
+// The delay is uniform random in [0, <max>) in microsecond precision, and the jitter is disabled if
+// <max> is not positive.
+func (entry *Entry) SetJitter(max time.Duration) {
+	entry.jitter.Set(int64(max))
+}
+
+// SetWeight sets the scheduling weight of the entry, which can be adjusted at runtime.
+// The weighted entries firing at the same tick are executed sequentially in descending weight order,
+// which means the higher weight entry is executed first.
+//
+// Note that the entry becomes a weighted entry once its weight is set, which is scheduled by the cron
+// together with other weighted entries, instead of by itself.
+func (entry *Entry) SetWeight(weight int) {
+	entry.weight.Set(weight)
+	if !entry.weighted.Val() && entry.weighted.Cas(false, true) {
+		entry.cron.startWeightedDispatcher()
+	}
+}
+
+// Weight returns the scheduling weight of the entry.
+func (entry *Entry) Weight() int {
+	return entry.weight.Val()
+}
+
+// RunNow executes the job of the entry immediately in a new goroutine, outside its schedule.
+// The execution does not consume the running times limit of the entry.
+//
+// It returns error if the entry is closed, or if the entry is a singleton timed task
+// and its job is currently running.
+func (entry *Entry) RunNow() error {
+	if entry.entry.Status() == StatusClosed {
+		return errors.New(fmt.Sprintf(`cron job "%s" is closed`, entry.Name))
+	}
+	if entry.IsSingleton() && entry.IsRunning() {
+		return errors.New(fmt.Sprintf(`cron job "%s" is running in singleton mode`, entry.Name))
+	}
+	go entry.execute()
+	return nil
+}
+
 // Close stops and removes the entry from cron.
 func (entry *Entry) Close() {
 	entry.cron.entries.Remove(entry.Name)
@@ -108,43 +266,223 @@ func (entry *Entry) Close() {
 // The running times limits feature is implemented by gcron.Entry and cannot be implemented by gtimer.Entry.
 // gcron.Entry relies on gtimer to implement a scheduled task check for gcron.Entry per second.
 func (entry *Entry) check() {
+	// The weighted entry is checked by the weighted dispatcher of cron.
+	if entry.weighted.Val() {
+		return
+	}
 	if entry.schedule.meet(time.Now()) {
-		path := entry.cron.GetLogPath()
-		level := entry.cron.GetLogLevel()
-		switch entry.cron.status.Val() {
-		case StatusStopped:
-			return
+		entry.run()
+	}
+}
 
-		case StatusClosed:
-			glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s removed", entry.Name, entry.schedule.pattern, entry.jobName)
-			entry.Close()
+// addTimeoutEntry creates and returns a new timed task, whose <job> is called with a context
+// cancelled after <timeout>, see timeoutJob.
+func (c *Cron) addTimeoutEntry(pattern string, timeout time.Duration, job func(ctx context.Context), singleton bool, name ...string) (*Entry, error) {
+	if len(name) > 0 && name[0] != "" && c.Search(name[0]) != nil {
+		return nil, errors.New(fmt.Sprintf(`cron job "%s" already exists`, name[0]))
+	}
+	schedule, err := newSchedule(pattern)
+	if err != nil {
+		return nil, err
+	}
+	entry := c.newEntry(schedule, func() {}, name...)
+	entry.jobName = funcName(job)
+	entry.Job = entry.timeoutJob(job, timeout)
+	c.startEntry(entry, singleton)
+	return entry, nil
+}
 
-		case StatusReady:
-			fallthrough
-		case StatusRunning:
-			// Running times check.
-			times := entry.times.Add(-1)
-			if times <= 0 {
-				if entry.entry.SetStatus(StatusClosed) == StatusClosed || times < 0 {
-					return
-				}
-			}
-			if times < 2000000000 && times > 1000000000 {
-				entry.times.Set(defaultTimes)
-			}
-			glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s start", entry.Name, entry.schedule.pattern, entry.jobName)
+// timeoutJob returns the job function calling <job> with a context cancelled after <timeout>.
+// The returned function no longer waits for <job> if it does not return by the deadline,
+// and the timed-out times of the entry is increased.
+//
+// The returned function panics with the panic of <job> or the timeout error, so that the failed
+// execution is recorded by execute. The panic of <job> after timeout is only logged.
+func (entry *Entry) timeoutJob(job func(ctx context.Context), timeout time.Duration) func() {
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		var (
+			done      = make(chan struct{})
+			settled   = gtype.NewBool() // Whether the job returns or times out firstly.
+			recovered interface{}
+		)
+		go func() {
 			defer func() {
-				if err := recover(); err != nil {
-					glog.Path(path).Level(level).Errorf("[gcron] %s(%s) %s end with error: %v", entry.Name, entry.schedule.pattern, entry.jobName, err)
-				} else {
-					glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s end", entry.Name, entry.schedule.pattern, entry.jobName)
+				err := recover()
+				if settled.Cas(false, true) {
+					recovered = err
+					close(done)
+					return
 				}
-				if entry.entry.Status() == StatusClosed {
-					entry.Close()
+				if err != nil {
+					glog.Path(entry.cron.GetLogPath()).Level(entry.cron.GetLogLevel()).Errorf(
+						"[gcron] %s(%s) %s end with error after timeout: %v", entry.Name, entry.schedule.pattern, entry.jobName, err,
+					)
 				}
 			}()
-			entry.Job()
+			job(ctx)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if !settled.Cas(false, true) {
+				// The job is returning at the deadline.
+				<-done
+			}
+		}
+		if recovered != nil {
+			panic(recovered)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			entry.timeouts.Add(1)
+			panic(errors.New(fmt.Sprintf(`timeout after %s`, timeout)))
+		}
+	}
+}
 
+// reboot fires the job of the "@reboot" entry once in a new goroutine, which does nothing
+// if the entry is not "@reboot" or it has fired.
+func (entry *Entry) reboot() {
+	if entry.schedule.reboot && entry.rebooted.Cas(false, true) {
+		go entry.run()
+	}
+}
+
+// tick runs the job of the entry every interval using time.Ticker until the entry is closed.
+func (entry *Entry) tick() {
+	ticker := time.NewTicker(entry.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		switch entry.entry.Status() {
+		case StatusClosed:
+			return
+		case StatusStopped:
+			continue
+		}
+		if entry.IsSingleton() && entry.IsRunning() {
+			continue
 		}
+		go entry.run()
+	}
+}
+
+// run executes the job of the entry, which also handles the status of cron and running times limit.
+func (entry *Entry) run() {
+	path := entry.cron.GetLogPath()
+	level := entry.cron.GetLogLevel()
+	switch entry.cron.status.Val() {
+	case StatusStopped:
+		return
+
+	case StatusClosed:
+		glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s removed", entry.Name, entry.schedule.pattern, entry.jobName)
+		entry.Close()
+
+	case StatusReady:
+		fallthrough
+	case StatusRunning:
+		// Only the leader executes the job if there's leader election.
+		if !entry.cron.isLeader() {
+			glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s skipped for not leader", entry.Name, entry.schedule.pattern, entry.jobName)
+			return
+		}
+		// Running times check.
+		times := entry.times.Add(-1)
+		if times <= 0 {
+			if entry.entry.SetStatus(StatusClosed) == StatusClosed || times < 0 {
+				return
+			}
+		}
+		if times < 2000000000 && times > 1000000000 {
+			entry.times.Set(defaultTimes)
+		}
+		entry.execute()
+	}
+}
+
+// execute calls the job of the entry, which also maintains the running statistics of the entry.
+func (entry *Entry) execute() {
+	path := entry.cron.GetLogPath()
+	level := entry.cron.GetLogLevel()
+	if max := entry.jitter.Val() / int64(time.Microsecond); max > 0 {
+		delay := time.Duration(grand.Intn(int(max))) * time.Microsecond
+		glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s delayed %s for jitter", entry.Name, entry.schedule.pattern, entry.jobName, delay)
+		time.Sleep(delay)
+	}
+	entry.waitDepends()
+	glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s start", entry.Name, entry.schedule.pattern, entry.jobName)
+	var (
+		run    = JobRun{StartTime: time.Now()}
+		output *bytes.Buffer
+	)
+	entry.runCount.Add(1)
+	entry.running.Add(1)
+	entry.lastRun.Set(run.StartTime.UnixNano())
+	defer func() {
+		entry.running.Add(-1)
+		if err := recover(); err != nil {
+			run.Error = fmt.Sprint(err)
+			glog.Path(path).Level(level).Errorf("[gcron] %s(%s) %s end with error: %v", entry.Name, entry.schedule.pattern, entry.jobName, err)
+		} else {
+			run.Success = true
+			glog.Path(path).Level(level).Debugf("[gcron] %s(%s) %s end", entry.Name, entry.schedule.pattern, entry.jobName)
+		}
+		run.EndTime = time.Now()
+		if output != nil {
+			run.Output = output.String()
+		}
+		entry.history.add(run, entry.cron.retention.Val())
+		entry.cron.saveEntry(entry)
+		if entry.entry.Status() == StatusClosed {
+			entry.Close()
+		}
+	}()
+	if entry.output != nil {
+		output = bytes.NewBuffer(nil)
+		entry.output(output)
+		return
+	}
+	entry.Job()
+}
+
+// funcName returns the function name of <f>, which is used as the job name for logging.
+func funcName(f interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// waitDepends blocks until all the depended entries of the entry are not running.
+// The depended entry that is removed from cron is ignored.
+func (entry *Entry) waitDepends() {
+	tick := time.Now().Truncate(time.Second)
+	for _, name := range entry.depends {
+		for deferred := false; ; deferred = true {
+			depended := entry.cron.Search(name)
+			if depended == nil || !depended.isPending(tick) {
+				break
+			}
+			if !deferred {
+				glog.Path(entry.cron.GetLogPath()).Level(entry.cron.GetLogLevel()).Debugf(
+					"[gcron] %s(%s) %s deferred for running cron job %s", entry.Name, entry.schedule.pattern, entry.jobName, name,
+				)
+			}
+			time.Sleep(dependsCheckInterval)
+		}
+	}
+}
+
+// isPending checks and returns whether the entry is running, or it is going to run
+// at the same second <tick> but does not start running yet.
+func (entry *Entry) isPending(tick time.Time) bool {
+	if entry.IsRunning() {
+		return true
+	}
+	if entry.interval > 0 || time.Since(tick) >= time.Second {
+		return false
+	}
+	switch entry.entry.Status() {
+	case StatusStopped, StatusClosed:
+		return false
 	}
+	return entry.schedule.meet(tick) && entry.lastRun.Val() < tick.UnixNano()
 }
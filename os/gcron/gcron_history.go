@@ -0,0 +1,125 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcron
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// JobRun is the execution record of the job of a timed task.
+type JobRun struct {
+	StartTime time.Time // Starting time of the execution.
+	EndTime   time.Time // Ending time of the execution.
+	Success   bool      // Whether the job returns without panic or timeout.
+	Error     string    // Error of the failed execution, eg: the panic or timeout error.
+	Output    string    // Output written by the job, see Cron.AddWithOutput.
+}
+
+// jobHistory is a concurrent-safe circular buffer of job execution records.
+type jobHistory struct {
+	mu   sync.RWMutex
+	runs []JobRun // Circular buffer of the records.
+	head int      // Position of the oldest record.
+	size int      // Count of the records.
+}
+
+const (
+	// defaultHistoryRetention is the default count of execution records retained for each entry.
+	defaultHistoryRetention = 100
+)
+
+// SetHistoryRetention sets the count of execution records retained for each entry, the oldest
+// record is discarded when exceeded. The history is disabled if <retention> <= 0.
+func (c *Cron) SetHistoryRetention(retention int) {
+	c.retention.Set(retention)
+}
+
+// AllHistory returns the latest at most <limit> execution records of all entries, keyed by entry name,
+// see Entry.History.
+func (c *Cron) AllHistory(limit int) map[string][]JobRun {
+	history := make(map[string][]JobRun)
+	for _, entry := range c.Entries() {
+		history[entry.Name] = entry.History(limit)
+	}
+	return history
+}
+
+// AddWithOutput adds a timed task, whose <job> is called with a writer capturing its output,
+// which is retained as Output in the execution records of Entry.History.
+// A unique <name> can be bound with the timed task.
+// It returns and error if the <name> is already used.
+func (c *Cron) AddWithOutput(pattern string, job func(out io.Writer), name ...string) (*Entry, error) {
+	if len(name) > 0 {
+		if c.Search(name[0]) != nil {
+			return nil, errors.New(fmt.Sprintf(`cron job "%s" already exists`, name[0]))
+		}
+	}
+	schedule, err := newSchedule(pattern)
+	if err != nil {
+		return nil, err
+	}
+	entry := c.newEntry(schedule, func() {
+		job(ioutil.Discard)
+	}, name...)
+	entry.jobName = funcName(job)
+	entry.output = job
+	c.startEntry(entry, false)
+	return entry, nil
+}
+
+// History returns the latest at most <limit> execution records of the entry in chronological order,
+// or all the retained records if <limit> <= 0.
+func (entry *Entry) History(limit int) []JobRun {
+	return entry.history.list(limit)
+}
+
+// add adds execution record <run> to the history retaining at most <retention> records.
+func (h *jobHistory) add(run JobRun, retention int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if retention <= 0 {
+		h.runs, h.head, h.size = nil, 0, 0
+		return
+	}
+	if len(h.runs) != retention {
+		// Resizes the buffer keeping the latest records.
+		runs := h.latest(retention)
+		h.runs = make([]JobRun, retention)
+		h.head, h.size = 0, copy(h.runs, runs)
+	}
+	if h.size < retention {
+		h.runs[(h.head+h.size)%retention] = run
+		h.size++
+	} else {
+		h.runs[h.head] = run
+		h.head = (h.head + 1) % retention
+	}
+}
+
+// list returns the latest at most <limit> records in chronological order.
+func (h *jobHistory) list(limit int) []JobRun {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.latest(limit)
+}
+
+// latest returns the latest at most <limit> records in chronological order without locking.
+func (h *jobHistory) latest(limit int) []JobRun {
+	if limit <= 0 || limit > h.size {
+		limit = h.size
+	}
+	runs := make([]JobRun, limit)
+	for i := 0; i < limit; i++ {
+		runs[i] = h.runs[(h.head+h.size-limit+i)%len(h.runs)]
+	}
+	return runs
+}
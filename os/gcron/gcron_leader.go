@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcron
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gtype"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/os/gtimer"
+	"github.com/ichunt2019/gf/util/gconv"
+	"github.com/ichunt2019/gf/util/guid"
+)
+
+// LeaderElection is the interface for leader election among multiple replicas,
+// with which only the leader replica executes the scheduled jobs, see Cron.SetLeaderElection.
+type LeaderElection interface {
+	// IsLeader checks and returns whether current replica is the leader.
+	IsLeader() bool
+}
+
+// RedisClient is the minimal redis client interface for RedisLeaderElection,
+// which sends a command to redis server and returns the reply.
+// It is satisfied by *gredis.Redis and redigo connection directly.
+type RedisClient interface {
+	Do(command string, args ...interface{}) (reply interface{}, err error)
+}
+
+// RedisLeaderElection implements LeaderElection with a renewable redis lock,
+// which is acquired using "SET NX EX" and renewed by the leader periodically.
+type RedisLeaderElection struct {
+	client RedisClient   // Redis client.
+	key    string        // Redis key of the lock.
+	id     string        // Unique id of current replica, which is the value of the lock.
+	ttl    time.Duration // TTL of the lock.
+	leader *gtype.Bool   // Whether current replica is the leader.
+	timer  *gtimer.Entry // Timer renewing or acquiring the lock.
+	mu     sync.Mutex    // Mutex serializing campaign and Close.
+	closed bool          // Whether the election is closed, which stops campaigning.
+}
+
+const (
+	// redisRenewScript renews the lock only if it's still held by current replica.
+	redisRenewScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("expire", KEYS[1], ARGV[2]) else return 0 end`
+	// redisReleaseScript deletes the lock only if it's still held by current replica.
+	redisReleaseScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+// SetLeaderElection sets the leader election <le> of the cron, with which only the leader replica
+// executes the scheduled jobs and other replicas skip their turns, which prevents duplicate runs
+// in multi-replica deployment. The manual runs by Entry.RunNow are not affected.
+func (c *Cron) SetLeaderElection(le LeaderElection) {
+	c.leader.Set(le)
+}
+
+// isLeader checks and returns whether current replica is the leader,
+// which is always true if there's no leader election.
+func (c *Cron) isLeader() bool {
+	if v := c.leader.Val(); v != nil {
+		return v.(LeaderElection).IsLeader()
+	}
+	return true
+}
+
+// NewRedisLeaderElection creates and returns a redis leader election using lock <key> with <ttl>.
+// It tries acquiring the lock immediately, and then the lock is renewed by the leader or acquired
+// by other replicas every third of <ttl>, the minimum <ttl> is one second.
+func NewRedisLeaderElection(client RedisClient, key string, ttl time.Duration) *RedisLeaderElection {
+	if client == nil {
+		panic("redis client for leader election cannot be empty")
+	}
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	le := &RedisLeaderElection{
+		client: client,
+		key:    key,
+		id:     guid.S(),
+		ttl:    ttl,
+		leader: gtype.NewBool(),
+	}
+	le.campaign()
+	le.timer = gtimer.Add(ttl/3, le.campaign)
+	return le
+}
+
+// IsLeader checks and returns whether current replica holds the lock.
+func (le *RedisLeaderElection) IsLeader() bool {
+	return le.leader.Val()
+}
+
+// Close stops the lock renewing and releases the lock if current replica is the leader.
+// The campaign in flight is waited for, so the lock it acquires is released as well.
+func (le *RedisLeaderElection) Close() error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	le.closed = true
+	le.timer.Close()
+	if !le.leader.Val() {
+		return nil
+	}
+	le.leader.Set(false)
+	_, err := le.client.Do("EVAL", redisReleaseScript, 1, le.key, le.id)
+	return err
+}
+
+// campaign renews the lock if current replica is the leader, or else tries acquiring the lock.
+func (le *RedisLeaderElection) campaign() {
+	var (
+		r       interface{}
+		err     error
+		seconds = int64(le.ttl / time.Second)
+	)
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	if le.closed {
+		return
+	}
+	if le.leader.Val() {
+		r, err = le.client.Do("EVAL", redisRenewScript, 1, le.key, le.id, seconds)
+		le.leader.Set(err == nil && gconv.Int(r) == 1)
+	} else {
+		r, err = le.client.Do("SET", le.key, le.id, "NX", "EX", seconds)
+		le.leader.Set(err == nil && r != nil && gconv.String(r) == "OK")
+	}
+	if err != nil {
+		intlog.Errorf(`leader election for key "%s" failed: %v`, le.key, err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 type cronSchedule struct {
 	create  int64            // Created timestamp.
 	every   int64            // Running interval in seconds.
+	reboot  bool             // Whether the job runs only once at startup, which is the "@reboot" pattern.
 	pattern string           // The raw cron pattern string.
 	second  map[int]struct{} // Job can run in these second numbers.
 	minute  map[int]struct{} // Job can run in these minute numbers.
@@ -36,7 +37,7 @@ const (
 )
 
 var (
-	// Predefined pattern map.
+	// Predefined pattern map, note that "@reboot" and "@every" are handled separately.
 	predefinedPatternMap = map[string]string{
 		"@yearly":   "0 0 0 1 1 *",
 		"@annually": "0 0 0 1 1 *",
@@ -80,6 +81,12 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 		key := strings.ToLower(match[1])
 		if v, ok := predefinedPatternMap[key]; ok {
 			pattern = v
+		} else if key == "@reboot" {
+			return &cronSchedule{
+				create:  time.Now().Unix(),
+				pattern: pattern,
+				reboot:  true,
+			}, nil
 		} else if strings.Compare(key, "@every") == 0 {
 			if d, err := gtime.ParseDuration(match[2]); err != nil {
 				return nil, err
@@ -226,6 +233,10 @@ func parseItemValue(value string, fieldType byte) (int, error) {
 
 // meet checks if the given time <t> meets the runnable point for the job.
 func (s *cronSchedule) meet(t time.Time) bool {
+	if s.reboot {
+		// It runs only at startup.
+		return false
+	}
 	if s.every != 0 {
 		// It checks using interval.
 		diff := t.Unix() - s.create
@@ -256,3 +267,49 @@ func (s *cronSchedule) meet(t time.Time) bool {
 		return true
 	}
 }
+
+// next returns the next time after <t> that meets the runnable point for the job.
+// It returns zero time if no time meets the schedule in the following five years.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	if s.reboot {
+		return time.Time{}
+	}
+	t = t.Truncate(time.Second).Add(time.Second)
+	if s.every != 0 {
+		diff := t.Unix() - s.create
+		if diff <= 0 {
+			return time.Unix(s.create+s.every, 0)
+		}
+		if remainder := diff % s.every; remainder != 0 {
+			t = t.Add(time.Duration(s.every-remainder) * time.Second)
+		}
+		return t
+	}
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if _, ok := s.month[int(t.Month())]; !ok {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		_, dayOk := s.day[t.Day()]
+		_, weekOk := s.week[int(t.Weekday())]
+		if !dayOk || !weekOk {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if _, ok := s.hour[t.Hour()]; !ok {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if _, ok := s.minute[t.Minute()]; !ok {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if _, ok := s.second[t.Second()]; !ok {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,133 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gcron
+
+import (
+	"time"
+
+	"github.com/ichunt2019/gf/os/glog"
+)
+
+// JobState is the persistent running state of a timed task.
+type JobState struct {
+	Name     string    // Entry name.
+	Spec     string    // Cron pattern of the entry.
+	LastRun  time.Time // Last running time.
+	NextRun  time.Time // Next running time after the last running.
+	RunCount int64     // Executed times of the job.
+}
+
+// JobStore is the interface for persisting the running states of timed tasks,
+// so that the missed runs during the process is down can be recovered, see Cron.SetJobStore.
+type JobStore interface {
+	// Save saves the running state of a timed task, which is called after each running of the task.
+	Save(state JobState) error
+
+	// Load loads all the saved running states of timed tasks.
+	Load() ([]JobState, error)
+}
+
+// MissedRunPolicy is the policy for runs missed while the process was down.
+type MissedRunPolicy int
+
+const (
+	MissedRunSkip MissedRunPolicy = iota // Skips all the missed runs, which is the default policy.
+	MissedRunOnce                        // Runs the job once immediately if there're missed runs.
+	MissedRunAll                         // Runs the job immediately for each missed run, at most maxMissedRuns times.
+)
+
+const (
+	// maxMissedRuns is the max count of missed runs recovered by MissedRunAll.
+	maxMissedRuns = 1000
+)
+
+// SetJobStore sets the job store <store> of the cron, and loads the saved running states from store.
+// The running state of each timed task is saved to the store after each its running.
+//
+// The timed task with the same name as a loaded state, either added before or after SetJobStore,
+// recovers the runs missed while the process was down according to the missed run policy,
+// see SetMissedRunPolicy. Note that only the timed task with a given name can be recovered,
+// as the generated name might be different after restarting.
+func (c *Cron) SetJobStore(store JobStore) error {
+	states, err := store.Load()
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		c.states.Set(state.Name, state)
+	}
+	c.store.Set(store)
+	for _, entry := range c.Entries() {
+		c.recoverEntry(entry)
+	}
+	return nil
+}
+
+// SetMissedRunPolicy sets the policy for runs missed while the process was down,
+// which should be called before SetJobStore.
+func (c *Cron) SetMissedRunPolicy(policy MissedRunPolicy) {
+	c.policy.Set(int(policy))
+}
+
+// recoverEntry runs the missed runs of <entry> according to the missed run policy
+// if there's loaded running state of the entry.
+func (c *Cron) recoverEntry(entry *Entry) {
+	v := c.states.Remove(entry.Name)
+	if v == nil || entry.interval > 0 {
+		return
+	}
+	var (
+		state  = v.(JobState)
+		now    = time.Now()
+		missed = 0
+	)
+	for t := state.NextRun; !t.IsZero() && t.Before(now) && missed < maxMissedRuns; t = entry.schedule.next(t) {
+		missed++
+	}
+	if missed == 0 {
+		return
+	}
+	// Only the leader recovers the missed runs if there's leader election.
+	if !c.isLeader() {
+		return
+	}
+	switch MissedRunPolicy(c.policy.Val()) {
+	case MissedRunOnce:
+		missed = 1
+	case MissedRunAll:
+	default:
+		return
+	}
+	glog.Path(c.GetLogPath()).Level(c.GetLogLevel()).Debugf(
+		"[gcron] %s(%s) %s recovers %d missed runs", entry.Name, entry.schedule.pattern, entry.jobName, missed,
+	)
+	go func() {
+		for i := 0; i < missed; i++ {
+			entry.execute()
+		}
+	}()
+}
+
+// saveEntry saves the running state of <entry> to the job store if it is set.
+func (c *Cron) saveEntry(entry *Entry) {
+	v := c.store.Val()
+	if v == nil {
+		return
+	}
+	err := v.(JobStore).Save(JobState{
+		Name:     entry.Name,
+		Spec:     entry.Spec(),
+		LastRun:  entry.LastRun(),
+		NextRun:  entry.NextRun(),
+		RunCount: entry.RunCount(),
+	})
+	if err != nil {
+		glog.Path(c.GetLogPath()).Level(c.GetLogLevel()).Errorf(
+			"[gcron] %s(%s) %s saving state failed: %v", entry.Name, entry.schedule.pattern, entry.jobName, err,
+		)
+	}
+}
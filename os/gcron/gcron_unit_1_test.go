@@ -7,6 +7,7 @@
 package gcron_test
 
 import (
+	"context"
 	"github.com/ichunt2019/gf/frame/g"
 	"testing"
 	"time"
@@ -223,3 +224,306 @@ func TestCron_DelayAddTimes(t *testing.T) {
 		t.Assert(cron.Size(), 0)
 	})
 }
+
+func TestCron_Entries_Inspection(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		_, err := cron.Add("* * * * * *", func() {}, "entry1")
+		t.Assert(err, nil)
+		_, err = cron.Add("0 0 1 * * *", func() {}, "entry2")
+		t.Assert(err, nil)
+		_, err = cron.Add("@every 3s", func() {
+			time.Sleep(time.Second)
+		}, "entry3")
+		t.Assert(err, nil)
+
+		entries := cron.Entries()
+		t.Assert(len(entries), 3)
+		t.Assert(entries[0].Name, "entry1")
+		t.Assert(entries[1].Name, "entry2")
+		t.Assert(entries[2].Name, "entry3")
+		t.Assert(entries[0].Spec(), "* * * * * *")
+		t.Assert(entries[2].Spec(), "@every 3s")
+
+		now := time.Now()
+		next := entries[1].NextRun()
+		t.Assert(next.After(now), true)
+		t.Assert(next.Hour(), 1)
+		t.Assert(next.Minute(), 0)
+		t.Assert(next.Second(), 0)
+		t.Assert(next.Sub(now) <= 24*time.Hour, true)
+		t.Assert(entries[0].NextRun().Sub(now) <= time.Second, true)
+
+		t.Assert(entries[0].RunCount(), 0)
+		t.Assert(entries[0].LastRun().IsZero(), true)
+		time.Sleep(3500 * time.Millisecond)
+		t.AssertGE(entries[0].RunCount(), 2)
+		t.Assert(entries[0].LastRun().After(now), true)
+		t.Assert(entries[1].RunCount(), 0)
+		t.Assert(entries[2].RunCount(), 1)
+		t.Assert(entries[2].IsRunning(), true)
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(entries[2].IsRunning(), false)
+	})
+}
+
+func TestCron_AddWeighted(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		array := garray.New(true)
+		for _, weight := range []int{3, 1, 2} {
+			w := weight
+			entry, err := cron.AddWeighted("* * * * * *", w, func() {
+				array.Append(w)
+			})
+			t.Assert(err, nil)
+			t.Assert(entry.Weight(), w)
+		}
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(array.Len() >= 3, true)
+		t.Assert(array.Slice()[:3], []int{3, 2, 1})
+		cron.Close()
+	})
+	// Runtime adjustment.
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		array := garray.New(true)
+		entries := make([]*gcron.Entry, 0)
+		for _, weight := range []int{3, 1, 2} {
+			w := weight
+			entry, err := cron.AddWeighted("* * * * * *", w, func() {
+				array.Append(w)
+			})
+			t.Assert(err, nil)
+			entries = append(entries, entry)
+		}
+		entries[1].SetWeight(10)
+		entries[0].Stop()
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(array.Len() >= 2, true)
+		t.Assert(array.Slice()[:2], []int{1, 2})
+		t.Assert(array.Contains(3), false)
+		cron.Close()
+	})
+}
+
+func TestCron_AddWithWarmUp(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		array := garray.New(true)
+		entry, err := cron.AddWithWarmUp("0 0 0 1 1 *", func() {
+			array.Append(1)
+		})
+		t.Assert(err, nil)
+		t.Assert(cron.Size(), 1)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		t.Assert(entry.RunCount(), 1)
+		t.AssertNE(entry.LastRun().IsZero(), true)
+		cron.Close()
+	})
+}
+
+func TestCron_Entry_RunNow(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		array := garray.New(true)
+		entry, err := cron.AddTimes("0 0 0 1 1 *", 1, func() {
+			array.Append(1)
+		})
+		t.Assert(err, nil)
+		t.Assert(entry.RunNow(), nil)
+		t.Assert(entry.RunNow(), nil)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(array.Len(), 2)
+		t.Assert(entry.RunCount(), 2)
+
+		entry.Close()
+		t.AssertNE(entry.RunNow(), nil)
+		cron.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		array := garray.New(true)
+		entry, err := cron.AddSingleton("0 0 0 1 1 *", func() {
+			array.Append(1)
+			time.Sleep(500 * time.Millisecond)
+		})
+		t.Assert(err, nil)
+		t.Assert(entry.RunNow(), nil)
+		time.Sleep(100 * time.Millisecond)
+		t.AssertNE(entry.RunNow(), nil)
+		time.Sleep(600 * time.Millisecond)
+		t.Assert(entry.RunNow(), nil)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Len(), 2)
+		cron.Close()
+	})
+}
+
+func TestCron_AddAfter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		_, err := cron.AddAfter("* * * * * *", func() {}, "none")
+		t.AssertNE(err, nil)
+
+		_, err = cron.Add("* * * * * *", func() {
+			array.Append("ingest start")
+			time.Sleep(500 * time.Millisecond)
+			array.Append("ingest end")
+		}, "ingest")
+		t.Assert(err, nil)
+		name, err := cron.AddAfter("* * * * * *", func() {
+			array.Append("aggregate")
+		}, "ingest")
+		t.Assert(err, nil)
+		t.AssertNE(cron.Search(name), nil)
+
+		time.Sleep(2200 * time.Millisecond)
+		cron.Stop()
+		time.Sleep(600 * time.Millisecond)
+		t.AssertGE(array.Len(), 3)
+		if array.Len() >= 3 {
+			t.Assert(array.Slice()[:3], g.Slice{"ingest start", "ingest end", "aggregate"})
+		}
+	})
+}
+
+func TestCron_AddInterval(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		entry := cron.AddInterval(10*time.Millisecond, func() {
+			array.Append(1)
+		})
+		t.Assert(entry.Spec(), "@every 10ms")
+		t.Assert(cron.Size(), 1)
+		t.Assert(entry.NextRun().After(time.Now()), true)
+		t.Assert(entry.NextRun().Before(time.Now().Add(20*time.Millisecond)), true)
+
+		time.Sleep(500 * time.Millisecond)
+		t.AssertGT(array.Len(), 10)
+
+		entry.Stop()
+		time.Sleep(50 * time.Millisecond)
+		length := array.Len()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Len(), length)
+
+		entry.Start()
+		time.Sleep(100 * time.Millisecond)
+		t.AssertGT(array.Len(), length)
+
+		entry.Close()
+		time.Sleep(50 * time.Millisecond)
+		length = array.Len()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Len(), length)
+		t.Assert(cron.Size(), 0)
+	})
+	// Running times limit.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		entry := cron.AddInterval(0, func() {
+			array.Append(1)
+		})
+		entry.SetTimes(3)
+		t.Assert(entry.Spec(), "@every 1ms")
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Len(), 3)
+	})
+}
+
+func TestCron_AddWithTimeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		entry, err := cron.AddWithTimeout("* * * * * *", "slow", 100*time.Millisecond, func(ctx context.Context) {
+			<-ctx.Done()
+			array.Append(ctx.Err())
+		})
+		t.Assert(err, nil)
+		t.Assert(entry.Name, "slow")
+		entry.SetSingleton(true)
+
+		_, err = cron.AddWithTimeout("* * * * * *", "slow", time.Second, func(ctx context.Context) {})
+		t.AssertNE(err, nil)
+
+		time.Sleep(1500 * time.Millisecond)
+		stats := entry.Stats()
+		t.Assert(stats.RunCount, 1)
+		t.Assert(stats.Timeouts, 1)
+		t.Assert(stats.Running, 0)
+		t.Assert(array.Len(), 1)
+		t.Assert(array.Slice()[0], context.DeadlineExceeded)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		entry, err := cron.AddWithTimeout("* * * * * *", "", time.Second, func(ctx context.Context) {})
+		t.Assert(err, nil)
+		t.AssertNE(entry.Name, "")
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(entry.Stats().RunCount, 1)
+		t.Assert(entry.Stats().Timeouts, 0)
+	})
+}
+
+func TestCron_AddSingletonWithTimeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		// The job hangs until its context is done, which should not block the future runs.
+		entry, err := cron.AddSingletonWithTimeout("* * * * * *", 500*time.Millisecond, func(ctx context.Context) {
+			array.Append(1)
+			<-ctx.Done()
+			time.Sleep(time.Hour)
+		}, "hang")
+		t.Assert(err, nil)
+		t.Assert(entry.Name, "hang")
+		t.Assert(entry.IsSingleton(), true)
+
+		_, err = cron.AddSingletonWithTimeout("* * * * * *", time.Second, func(ctx context.Context) {}, "hang")
+		t.AssertNE(err, nil)
+
+		time.Sleep(2500 * time.Millisecond)
+		stats := entry.Stats()
+		t.Assert(stats.RunCount, 2)
+		t.Assert(stats.Timeouts, 2)
+		t.Assert(array.Len(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		// The singleton job running longer than a second skips the run in flight.
+		entry, err := cron.AddSingletonWithTimeout("* * * * * *", 10*time.Second, func(ctx context.Context) {
+			array.Append(1)
+			time.Sleep(1500 * time.Millisecond)
+		})
+		t.Assert(err, nil)
+		time.Sleep(2500 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		t.Assert(entry.Stats().Timeouts, 0)
+	})
+}
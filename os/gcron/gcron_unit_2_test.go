@@ -7,13 +7,21 @@
 package gcron_test
 
 import (
+	"context"
+	"fmt"
 	"github.com/ichunt2019/gf/frame/g"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ichunt2019/gf/container/garray"
+	"github.com/ichunt2019/gf/container/gtype"
+	"github.com/ichunt2019/gf/os/gcfg"
 	"github.com/ichunt2019/gf/os/gcron"
 	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
 )
 
 func TestCron_Entry_Operations(t *testing.T) {
@@ -64,3 +72,463 @@ func TestCron_Entry_Operations(t *testing.T) {
 		t.Assert(cron.Size(), 0)
 	})
 }
+
+// memoryJobStore is a job store saving states in memory.
+type memoryJobStore struct {
+	mu     sync.Mutex
+	states map[string]gcron.JobState
+}
+
+func (s *memoryJobStore) Save(state gcron.JobState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.Name] = state
+	return nil
+}
+
+func (s *memoryJobStore) Load() ([]gcron.JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]gcron.JobState, 0, len(s.states))
+	for _, state := range s.states {
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (s *memoryJobStore) Get(name string) gcron.JobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[name]
+}
+
+func TestCron_SetJobStore(t *testing.T) {
+	var (
+		now      = time.Now()
+		midnight = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		// Missed the runs of two days ago, yesterday and today.
+		newStore = func() *memoryJobStore {
+			return &memoryJobStore{states: map[string]gcron.JobState{
+				"daily": {
+					Name:    "daily",
+					Spec:    "0 0 0 * * *",
+					LastRun: midnight.Add(-72 * time.Hour),
+					NextRun: midnight.Add(-48 * time.Hour),
+				},
+			}}
+		}
+	)
+	for policy, expect := range map[gcron.MissedRunPolicy]int{
+		gcron.MissedRunSkip: 0,
+		gcron.MissedRunOnce: 1,
+		gcron.MissedRunAll:  3,
+	} {
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				cron  = gcron.New()
+				array = garray.NewArray(true)
+				store = newStore()
+			)
+			defer cron.Close()
+			cron.SetMissedRunPolicy(policy)
+			t.Assert(cron.SetJobStore(store), nil)
+			_, err := cron.Add("0 0 0 * * *", func() {
+				array.Append(1)
+			}, "daily")
+			t.Assert(err, nil)
+			time.Sleep(200 * time.Millisecond)
+			t.Assert(array.Len(), expect)
+			if expect > 0 {
+				state := store.Get("daily")
+				t.Assert(state.RunCount, expect)
+				t.Assert(state.Spec, "0 0 0 * * *")
+				t.Assert(state.NextRun, midnight.Add(24*time.Hour))
+				t.Assert(state.LastRun.After(now), true)
+			}
+		})
+	}
+	// The states are recovered for the entries added before SetJobStore, and saved after running.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+			store = newStore()
+		)
+		defer cron.Close()
+		cron.SetMissedRunPolicy(gcron.MissedRunOnce)
+		cron.Add("0 0 0 * * *", func() {
+			array.Append(1)
+		}, "daily")
+		cron.Add("* * * * * *", func() {}, "secondly")
+		t.Assert(cron.SetJobStore(store), nil)
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		t.AssertGE(store.Get("secondly").RunCount, 1)
+	})
+}
+
+// fakeRedisClient is an in-memory redis client supporting the commands of RedisLeaderElection.
+type fakeRedisClient struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (c *fakeRedisClient) Do(command string, args ...interface{}) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch command {
+	case "SET":
+		key := gconv.String(args[0])
+		if _, ok := c.values[key]; ok {
+			return nil, nil
+		}
+		c.values[key] = gconv.String(args[1])
+		return "OK", nil
+	case "EVAL":
+		script, key, id := gconv.String(args[0]), gconv.String(args[2]), gconv.String(args[3])
+		if c.values[key] != id {
+			return int64(0), nil
+		}
+		if strings.Contains(script, `"del"`) {
+			delete(c.values, key)
+		}
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unsupported command: %s", command)
+}
+
+func TestCron_SetLeaderElection(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = &fakeRedisClient{values: make(map[string]string)}
+			le1    = gcron.NewRedisLeaderElection(client, "cron:leader", time.Second)
+			le2    = gcron.NewRedisLeaderElection(client, "cron:leader", time.Second)
+			cron1  = gcron.New()
+			cron2  = gcron.New()
+			array1 = garray.NewArray(true)
+			array2 = garray.NewArray(true)
+		)
+		defer cron1.Close()
+		defer cron2.Close()
+		defer le2.Close()
+		t.Assert(le1.IsLeader(), true)
+		t.Assert(le2.IsLeader(), false)
+
+		cron1.SetLeaderElection(le1)
+		cron2.SetLeaderElection(le2)
+		cron1.Add("* * * * * *", func() { array1.Append(1) })
+		cron2.Add("* * * * * *", func() { array2.Append(1) })
+		time.Sleep(1500 * time.Millisecond)
+		t.AssertGE(array1.Len(), 1)
+		t.Assert(array2.Len(), 0)
+
+		// The lock is renewed by the leader.
+		time.Sleep(time.Second)
+		t.Assert(le1.IsLeader(), true)
+		t.Assert(le2.IsLeader(), false)
+
+		// Another replica becomes the leader after the leader releases the lock.
+		t.Assert(le1.Close(), nil)
+		t.Assert(le1.IsLeader(), false)
+		time.Sleep(time.Second)
+		t.Assert(le2.IsLeader(), true)
+		length := array1.Len()
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(array1.Len(), length)
+		t.AssertGE(array2.Len(), 1)
+	})
+}
+
+// blockingRedisClient blocks the SET command until <release> is closed if <blocking> is set.
+type blockingRedisClient struct {
+	*fakeRedisClient
+	blocking *gtype.Bool
+	blocked  chan struct{}
+	release  chan struct{}
+}
+
+func (c *blockingRedisClient) Do(command string, args ...interface{}) (interface{}, error) {
+	if command == "SET" && c.blocking.Cas(true, false) {
+		close(c.blocked)
+		<-c.release
+	}
+	return c.fakeRedisClient.Do(command, args...)
+}
+
+func TestCron_RedisLeaderElection_CloseInCampaign(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = &blockingRedisClient{
+				fakeRedisClient: &fakeRedisClient{values: map[string]string{"cron:leader": "other"}},
+				blocking:        gtype.NewBool(),
+				blocked:         make(chan struct{}),
+				release:         make(chan struct{}),
+			}
+			le = gcron.NewRedisLeaderElection(client, "cron:leader", time.Second)
+		)
+		t.Assert(le.IsLeader(), false)
+
+		// The lock is released by other replica, and the next campaign is blocked in acquiring it.
+		client.mu.Lock()
+		delete(client.values, "cron:leader")
+		client.mu.Unlock()
+		client.blocking.Set(true)
+		<-client.blocked
+
+		closed := make(chan error)
+		go func() {
+			closed <- le.Close()
+		}()
+		time.Sleep(100 * time.Millisecond)
+		close(client.release)
+		t.Assert(<-closed, nil)
+		t.Assert(le.IsLeader(), false)
+
+		// The lock acquired by the campaign in flight is released by Close and never renewed.
+		time.Sleep(time.Second)
+		t.Assert(le.IsLeader(), false)
+		client.mu.Lock()
+		t.Assert(len(client.values), 0)
+		client.mu.Unlock()
+	})
+}
+
+func TestCron_History(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		cron.SetHistoryRetention(3)
+		entry, err := cron.AddWithOutput("* * * * * *", func(out io.Writer) {
+			fmt.Fprint(out, "done")
+		}, "output")
+		t.Assert(err, nil)
+		for i := 0; i < 5; i++ {
+			t.Assert(entry.RunNow(), nil)
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Assert(len(entry.History(0)), 3)
+		t.Assert(len(entry.History(2)), 2)
+		t.Assert(len(entry.History(10)), 3)
+		history := entry.History(0)
+		for i, run := range history {
+			t.Assert(run.Success, true)
+			t.Assert(run.Output, "done")
+			t.Assert(run.EndTime.Before(run.StartTime), false)
+			if i > 0 {
+				t.Assert(run.StartTime.After(history[i-1].StartTime), true)
+			}
+		}
+		t.Assert(entry.History(1)[0], history[2])
+
+		failing, _ := cron.Add("0 0 0 1 1 *", func() {
+			panic("error")
+		}, "failing")
+		t.Assert(failing.RunNow(), nil)
+		time.Sleep(20 * time.Millisecond)
+		all := cron.AllHistory(1)
+		t.Assert(len(all), 2)
+		t.Assert(all["output"], g.Slice{history[2]})
+		t.Assert(len(all["failing"]), 1)
+		t.Assert(all["failing"][0].Success, false)
+		t.Assert(all["failing"][0].Error, "error")
+		t.Assert(all["failing"][0].Output, "")
+
+		cron.SetHistoryRetention(0)
+		t.Assert(failing.RunNow(), nil)
+		time.Sleep(20 * time.Millisecond)
+		t.Assert(len(failing.History(0)), 0)
+	})
+}
+
+func TestCron_History_Timeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		timeout, err := cron.AddWithTimeout("0 0 0 1 1 *", "timeout", 50*time.Millisecond, func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(100 * time.Millisecond)
+		})
+		t.Assert(err, nil)
+		panicking, err := cron.AddWithTimeout("0 0 0 1 1 *", "panicking", time.Second, func(ctx context.Context) {
+			panic("error")
+		})
+		t.Assert(err, nil)
+		succeeding, err := cron.AddWithTimeout("0 0 0 1 1 *", "succeeding", time.Second, func(ctx context.Context) {})
+		t.Assert(err, nil)
+
+		t.Assert(timeout.RunNow(), nil)
+		t.Assert(panicking.RunNow(), nil)
+		t.Assert(succeeding.RunNow(), nil)
+		time.Sleep(200 * time.Millisecond)
+
+		history := timeout.History(0)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].Success, false)
+		t.Assert(history[0].Error, "timeout after 50ms")
+		t.Assert(timeout.Stats().Timeouts, 1)
+
+		history = panicking.History(0)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].Success, false)
+		t.Assert(history[0].Error, "error")
+		t.Assert(panicking.Stats().Timeouts, 0)
+
+		history = succeeding.History(0)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].Success, true)
+		t.Assert(history[0].Error, "")
+	})
+}
+
+func TestCron_PatternAlias(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron     = gcron.New()
+			now      = time.Now()
+			midnight = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		)
+		defer cron.Close()
+		for pattern, next := range map[string]time.Time{
+			"@yearly":   time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, now.Location()),
+			"@annually": time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, now.Location()),
+			"@monthly":  time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location()),
+			"@weekly":   midnight.AddDate(0, 0, 7-int(now.Weekday())),
+			"@daily":    midnight.AddDate(0, 0, 1),
+			"@midnight": midnight.AddDate(0, 0, 1),
+			"@hourly":   now.Truncate(time.Hour).Add(time.Hour),
+		} {
+			entry, err := cron.Add(pattern, func() {})
+			t.Assert(err, nil)
+			t.Assert(entry.NextRun(), next)
+		}
+		_, err := cron.Add("@unknown", func() {})
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestCron_Reboot(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		// The cron is running in default, so that the job fires once immediately.
+		entry, err := cron.Add("@reboot", func() {
+			array.Append(1)
+		})
+		t.Assert(err, nil)
+		t.Assert(entry.NextRun().IsZero(), true)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+
+		// The job fires after the stopped cron starts.
+		cron.Stop()
+		cron.Add("@reboot", func() {
+			array.Append(2)
+		})
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Slice(), g.Slice{1})
+		cron.Start()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Slice(), g.Slice{1, 2})
+
+		// It fires only once.
+		cron.Stop()
+		cron.Start()
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(array.Slice(), g.Slice{1, 2})
+	})
+}
+
+func TestCron_LoadConfig(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			file    = "gcron_load_config.toml"
+			content = `
+[cron]
+    [cron.echo]
+        spec    = "* * * * * *"
+        command = "echo hello"
+    [cron.sleep]
+        spec    = "* * * * * *"
+        command = "sleep 10"
+        timeout = "100ms"
+[invalid]
+    [invalid.echo]
+        spec    = "* * *"
+        command = "echo hello"
+`
+			cron = gcron.New()
+		)
+		gcfg.SetContent(content, file)
+		defer gcfg.RemoveContent(file)
+		defer cron.Close()
+		cfg := gcfg.New(file)
+
+		t.AssertNE(cron.LoadConfig(cfg, "none"), nil)
+		t.AssertNE(cron.LoadConfig(cfg, "invalid"), nil)
+		t.Assert(cron.Size(), 0)
+
+		t.Assert(cron.LoadConfig(cfg, "cron"), nil)
+		t.Assert(cron.Size(), 2)
+		// Loading again replaces the existing jobs.
+		t.Assert(cron.LoadConfig(cfg, "cron"), nil)
+		t.Assert(cron.Size(), 2)
+
+		time.Sleep(1500 * time.Millisecond)
+		history := cron.Search("echo").History(10)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].Success, true)
+		t.Assert(history[0].Output, "hello\n")
+		history = cron.Search("sleep").History(10)
+		t.Assert(len(history), 1)
+		t.Assert(history[0].Success, false)
+		t.Assert(history[0].EndTime.Sub(history[0].StartTime) < time.Second, true)
+
+		// Loading again removes the jobs deleted from the section.
+		gcfg.SetContent(`
+[cron]
+    [cron.echo]
+        spec    = "* * * * * *"
+        command = "echo hello"
+`, file)
+		cfg = gcfg.New(file)
+		t.Assert(cron.LoadConfig(cfg, "cron"), nil)
+		t.Assert(cron.Size(), 1)
+		t.AssertNE(cron.Search("echo"), nil)
+		t.Assert(cron.Search("sleep"), nil)
+	})
+}
+
+func TestCron_Entry_SetJitter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.NewArray(true)
+		)
+		defer cron.Close()
+		for i := 0; i < 10; i++ {
+			entry, err := cron.Add("* * * * * *", func() {
+				array.Append(time.Now())
+			})
+			t.Assert(err, nil)
+			entry.SetJitter(500 * time.Millisecond)
+			entry.SetTimes(1)
+		}
+		time.Sleep(1800 * time.Millisecond)
+		t.Assert(array.Len(), 10)
+		var min, max time.Time
+		for _, v := range array.Slice() {
+			if tm := v.(time.Time); min.IsZero() || tm.Before(min) {
+				min = tm
+			}
+			if tm := v.(time.Time); tm.After(max) {
+				max = tm
+			}
+		}
+		// The executions are spread, rather than all at the same time.
+		t.Assert(max.Sub(min) > 50*time.Millisecond, true)
+		t.Assert(max.Sub(min) < 600*time.Millisecond, true)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	// EqualHashThreshold is the file size threshold in bytes for Equal,
+	// above which the files are compared using streaming SHA-256 hashing
+	// instead of direct byte comparison. It's 1MB in default.
+	EqualHashThreshold int64 = 1024 * 1024
+)
+
+// Equal checks and returns whether the contents of file <pathA> and <pathB> are byte-for-byte equal.
+// The files are not loaded into memory simultaneously if their size is larger than EqualHashThreshold,
+// in which case they are compared using streaming SHA-256 hashing.
+func Equal(pathA, pathB string) (bool, error) {
+	fileA, err := os.Open(pathA)
+	if err != nil {
+		return false, err
+	}
+	defer fileA.Close()
+	fileB, err := os.Open(pathB)
+	if err != nil {
+		return false, err
+	}
+	defer fileB.Close()
+
+	infoA, err := fileA.Stat()
+	if err != nil {
+		return false, err
+	}
+	infoB, err := fileB.Stat()
+	if err != nil {
+		return false, err
+	}
+	if infoA.Size() != infoB.Size() {
+		return false, nil
+	}
+	if infoA.Size() <= EqualHashThreshold {
+		contentA, err := ioutil.ReadAll(fileA)
+		if err != nil {
+			return false, err
+		}
+		contentB, err := ioutil.ReadAll(fileB)
+		if err != nil {
+			return false, err
+		}
+		return SameContent(contentA, contentB), nil
+	}
+	hashA, err := hashReader(fileA)
+	if err != nil {
+		return false, err
+	}
+	hashB, err := hashReader(fileB)
+	if err != nil {
+		return false, err
+	}
+	return SameContent(hashA, hashB), nil
+}
+
+// SameContent checks and returns whether the in-memory contents <a> and <b> are byte-for-byte equal.
+func SameContent(a, b []byte) bool {
+	return bytes.Equal(a, b)
+}
+
+// hashReader calculates and returns the SHA-256 sum of <reader> in streaming.
+func hashReader(reader io.Reader) ([]byte, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,270 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rotateBackupTimeFormat is the time format of the rotated backup file name, which is to microseconds.
+	rotateBackupTimeFormat = "20060102150405.000000"
+	// rotateBackupCompressExt is the extension of the compressed backup file.
+	rotateBackupCompressExt = ".gz"
+)
+
+// RotateOptions is the options for RotatingFile.
+type RotateOptions struct {
+	MaxSize    int64         // Rotate the file before writing if the writing makes its size exceed MaxSize in bytes. It's 0 in default, means no rotation by size.
+	MaxBackups int           // Max count of the backup files, the older ones are removed. It's 0 in default, means no limit.
+	MaxAge     time.Duration // Max age of the backup files, the expired ones are removed. It's 0 in default, means no expiration.
+	Compress   bool          // Compress the backup files using gzip algorithm(false in default).
+}
+
+// RotatingFile is the file writer with size-based rotation and backup management,
+// which implements the io.WriteCloser interface and is concurrent-safe.
+//
+// The rotated backup file is renamed by adding the rotation time to microseconds, like:
+// audit.bin -> audit.20200326101301.899002.bin
+type RotatingFile struct {
+	mu      sync.Mutex     // Mutex for concurrent safety.
+	path    string         // Absolute path of the file.
+	options RotateOptions  // Rotation options.
+	file    *os.File       // Current opened file.
+	size    int64          // Current size of the file.
+	backup  *regexp.Regexp // Pattern for backup file names.
+}
+
+// NewRotatingFile opens or creates file <path> for appending, and returns a RotatingFile for it,
+// which rotates the file and manages its backups according to <options>.
+// The parent directory of <path> is created if it does not exist.
+func NewRotatingFile(path string, options RotateOptions) (*RotatingFile, error) {
+	if path == "" {
+		return nil, errors.New("file path cannot be empty")
+	}
+	path = Abs(path)
+	if err := Mkdir(Dir(path)); err != nil {
+		return nil, err
+	}
+	var (
+		ext  = Ext(path)
+		name = strings.TrimSuffix(Basename(path), ext)
+	)
+	f := &RotatingFile{
+		path:    path,
+		options: options,
+		backup: regexp.MustCompile(fmt.Sprintf(
+			`^%s\.(\d{14}\.\d{6})(?:\.(\d+))?%s(?:%s)?$`,
+			regexp.QuoteMeta(name), regexp.QuoteMeta(ext), regexp.QuoteMeta(rotateBackupCompressExt),
+		)),
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write implements the io.Writer interface.
+// It rotates the file before writing if the writing makes its size exceed MaxSize,
+// but the <p> larger than MaxSize is still written to the new file.
+func (f *RotatingFile) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, errors.New("rotating file is closed")
+	}
+	if f.options.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.options.MaxSize {
+		if err = f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file immediately, even if its size does not exceed MaxSize.
+// It does nothing if the file is empty.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return errors.New("rotating file is closed")
+	}
+	if f.size == 0 {
+		return nil
+	}
+	return f.rotate()
+}
+
+// Close implements the io.Closer interface, which closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Path returns the absolute path of the file.
+func (f *RotatingFile) Path() string {
+	return f.path
+}
+
+// Backups returns the absolute paths of all backup files, which are sorted from the newest to the oldest.
+func (f *RotatingFile) Backups() ([]string, error) {
+	names, err := DirNames(Dir(f.path))
+	if err != nil {
+		return nil, err
+	}
+	type backupItem struct {
+		path   string // Absolute path of the backup file.
+		time   string // Rotation time in the name, which is in fixed width and can be compared as string.
+		suffix int    // Sequence suffix for the same rotation time, 0 if there's no suffix.
+	}
+	items := make([]backupItem, 0)
+	for _, name := range names {
+		if match := f.backup.FindStringSubmatch(name); match != nil {
+			item := backupItem{
+				path: Join(Dir(f.path), name),
+				time: match[1],
+			}
+			if match[2] != "" {
+				item.suffix, _ = strconv.Atoi(match[2])
+			}
+			items = append(items, item)
+		}
+	}
+	// The backups of the same rotation time are renamed with increasing suffix,
+	// so they are sorted by the rotation time and then the suffix, but not the name.
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].time != items[j].time {
+			return items[i].time > items[j].time
+		}
+		return items[i].suffix > items[j].suffix
+	})
+	backups := make([]string, len(items))
+	for i, item := range items {
+		backups[i] = item.path
+	}
+	return backups, nil
+}
+
+// open opens the file for appending and retrieves its current size.
+func (f *RotatingFile) open() error {
+	file, err := OpenWithFlagPerm(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, DefaultPermOpen)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames current file to a backup file, opens a new file and manages the backups.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	backupPath := f.backupPath(time.Now())
+	if err := Rename(f.path, backupPath); err != nil {
+		// Reopens the original file for further writing.
+		if e := f.open(); e != nil {
+			return e
+		}
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	if f.options.Compress {
+		if err := compressBackup(backupPath); err != nil {
+			return err
+		}
+	}
+	return f.removeBackups()
+}
+
+// backupPath returns a backup file path for time <t>, which does not exist.
+func (f *RotatingFile) backupPath(t time.Time) string {
+	var (
+		ext  = Ext(f.path)
+		base = strings.TrimSuffix(f.path, ext) + "." + t.Format(rotateBackupTimeFormat)
+		path = base + ext
+	)
+	for i := 1; Exists(path) || Exists(path+rotateBackupCompressExt); i++ {
+		path = fmt.Sprintf(`%s.%d%s`, base, i, ext)
+	}
+	return path
+}
+
+// removeBackups removes the backup files exceeding MaxBackups or MaxAge.
+func (f *RotatingFile) removeBackups() error {
+	if f.options.MaxBackups <= 0 && f.options.MaxAge <= 0 {
+		return nil
+	}
+	backups, err := f.Backups()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i, backup := range backups {
+		if (f.options.MaxBackups > 0 && i >= f.options.MaxBackups) ||
+			(f.options.MaxAge > 0 && now.Sub(MTime(backup)) > f.options.MaxAge) {
+			if err = Remove(backup); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compressBackup compresses the backup file <path> to <path>.gz using gzip algorithm,
+// and removes the original backup file.
+func compressBackup(path string) error {
+	src, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := Create(path + rotateBackupCompressExt)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(dst)
+	if _, err = io.Copy(writer, src); err == nil {
+		err = writer.Close()
+	}
+	if e := dst.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		Remove(path + rotateBackupCompressExt)
+		return err
+	}
+	src.Close()
+	return Remove(path)
+}
@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfsnotify"
+)
+
+const (
+	// Buffer size for the events and errors channel of Watcher.
+	watcherChannelSize = 1024
+)
+
+// Watcher aggregates the file change events of multiple paths into a single channel.
+// It is a higher-level wrapper over gfsnotify.
+type Watcher struct {
+	mu        sync.Mutex                     // Mutex for concurrent safety.
+	watcher   *gfsnotify.Watcher             // Underlying gfsnotify watcher.
+	callbacks map[string]*gfsnotify.Callback // Watched absolute path to its callback mapping.
+	events    chan WatchEvent                // Aggregated file change events.
+	errors    chan error                     // Errors occurring in watching.
+	closed    bool                           // Whether the watcher is closed.
+}
+
+// WatchEvent is the file change event produced by Watcher.
+type WatchEvent struct {
+	Path string       // Absolute file path.
+	Op   gfsnotify.Op // File operation.
+	Time time.Time    // Time the event is received.
+}
+
+// NewWatcher creates and returns a watcher monitoring given <paths>.
+// It returns error if any of the <paths> cannot be watched, and the created watcher is closed.
+func NewWatcher(paths ...string) (*Watcher, error) {
+	watcher, err := gfsnotify.New()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		watcher:   watcher,
+		callbacks: make(map[string]*gfsnotify.Callback),
+		events:    make(chan WatchEvent, watcherChannelSize),
+		errors:    make(chan error, watcherChannelSize),
+	}
+	for _, path := range paths {
+		if err = w.Add(path); err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// Events returns the channel receiving the file change events of all watched paths.
+// The channel is closed after the watcher is closed.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Errors returns the channel receiving the errors occurring in watching.
+// The channel is closed after the watcher is closed.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Add adds <path> to the watcher, which can be a file or directory path.
+// Adding the same path multiple times takes effect only once.
+func (w *Watcher) Add(path string) error {
+	realPath := RealPath(path)
+	if realPath == "" {
+		return errors.New(fmt.Sprintf(`path "%s" does not exist`, path))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return errors.New("watcher is closed")
+	}
+	if _, ok := w.callbacks[realPath]; ok {
+		return nil
+	}
+	callback, err := w.watcher.Add(realPath, w.handleEvent)
+	if err != nil {
+		return err
+	}
+	w.callbacks[realPath] = callback
+	return nil
+}
+
+// Remove removes <path> from the watcher.
+// It returns error if <path> is not watched by the watcher.
+func (w *Watcher) Remove(path string) error {
+	realPath := RealPath(path)
+	if realPath == "" {
+		realPath = Abs(path)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	callback, ok := w.callbacks[realPath]
+	if !ok {
+		return errors.New(fmt.Sprintf(`path "%s" is not watched`, path))
+	}
+	w.watcher.RemoveCallback(callback.Id)
+	delete(w.callbacks, realPath)
+	return nil
+}
+
+// Close stops watching all paths and closes the events and errors channels.
+// It is safe to call Close multiple times.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	for _, callback := range w.callbacks {
+		w.watcher.RemoveCallback(callback.Id)
+	}
+	w.callbacks = nil
+	w.watcher.Close()
+	close(w.events)
+	close(w.errors)
+	return nil
+}
+
+// handleEvent is the callback function for underlying gfsnotify watcher,
+// which sends the event to events channel.
+func (w *Watcher) handleEvent(event *gfsnotify.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	watchEvent := WatchEvent{
+		Path: event.Path,
+		Op:   event.Op,
+		Time: time.Now(),
+	}
+	select {
+	case w.events <- watchEvent:
+	default:
+		// The events channel is full, it reports the dropped event to errors channel.
+		select {
+		case w.errors <- errors.New(fmt.Sprintf(`events channel is full, event dropped: %s`, event.String())):
+		default:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_Equal(t *testing.T) {
+	var (
+		paths1 = "/testfile_equal1.txt"
+		paths2 = "/testfile_equal2.txt"
+		paths3 = "/testfile_equal3.txt"
+		paths4 = "/testfile_equal4.txt"
+	)
+	createTestFile(paths1, "abcdefghijklmn")
+	createTestFile(paths2, "abcdefghijklmn")
+	createTestFile(paths3, "abcdefghijklmo")
+	createTestFile(paths4, "abcdefghijklmn=")
+	defer delTestFiles(paths1)
+	defer delTestFiles(paths2)
+	defer delTestFiles(paths3)
+	defer delTestFiles(paths4)
+
+	gtest.C(t, func(t *gtest.T) {
+		// Identical files.
+		equal, err := gfile.Equal(testpath()+paths1, testpath()+paths2)
+		t.Assert(err, nil)
+		t.Assert(equal, true)
+		// Differ only in the last byte.
+		equal, err = gfile.Equal(testpath()+paths1, testpath()+paths3)
+		t.Assert(err, nil)
+		t.Assert(equal, false)
+		// Different lengths.
+		equal, err = gfile.Equal(testpath()+paths1, testpath()+paths4)
+		t.Assert(err, nil)
+		t.Assert(equal, false)
+		// Not exist.
+		_, err = gfile.Equal(testpath()+paths1, testpath()+"/testfile_equal_none.txt")
+		t.AssertNE(err, nil)
+	})
+	// Streaming hashing for large files.
+	gtest.C(t, func(t *gtest.T) {
+		threshold := gfile.EqualHashThreshold
+		gfile.EqualHashThreshold = 4
+		defer func() {
+			gfile.EqualHashThreshold = threshold
+		}()
+		equal, err := gfile.Equal(testpath()+paths1, testpath()+paths2)
+		t.Assert(err, nil)
+		t.Assert(equal, true)
+		equal, err = gfile.Equal(testpath()+paths1, testpath()+paths3)
+		t.Assert(err, nil)
+		t.Assert(equal, false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			large1  = "/testfile_equal_large1.txt"
+			large2  = "/testfile_equal_large2.txt"
+			large3  = "/testfile_equal_large3.txt"
+			content = strings.Repeat("0123456789", 200*1024)
+		)
+		createTestFile(large1, content)
+		createTestFile(large2, content)
+		createTestFile(large3, content[:len(content)-1]+"x")
+		defer delTestFiles(large1)
+		defer delTestFiles(large2)
+		defer delTestFiles(large3)
+		equal, err := gfile.Equal(testpath()+large1, testpath()+large2)
+		t.Assert(err, nil)
+		t.Assert(equal, true)
+		equal, err = gfile.Equal(testpath()+large1, testpath()+large3)
+		t.Assert(err, nil)
+		t.Assert(equal, false)
+	})
+}
+
+func Test_SameContent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gfile.SameContent([]byte("abc"), []byte("abc")), true)
+		t.Assert(gfile.SameContent([]byte("abc"), []byte("abd")), false)
+		t.Assert(gfile.SameContent([]byte("abc"), []byte("abcd")), false)
+		t.Assert(gfile.SameContent(nil, []byte{}), true)
+	})
+}
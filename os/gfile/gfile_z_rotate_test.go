@@ -0,0 +1,228 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_RotatingFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{
+			MaxSize: 10,
+		})
+		t.Assert(err, nil)
+		defer f.Close()
+		t.Assert(f.Path(), path)
+
+		n, err := f.Write([]byte("123456"))
+		t.Assert(err, nil)
+		t.Assert(n, 6)
+		// Rotates before writing that exceeds MaxSize.
+		_, err = f.Write([]byte("7890ab"))
+		t.Assert(err, nil)
+		t.Assert(gfile.GetContents(path), "7890ab")
+
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 1)
+		t.Assert(gfile.GetContents(backups[0]), "123456")
+		t.Assert(strings.HasPrefix(gfile.Basename(backups[0]), "audit."), true)
+		t.Assert(gfile.Ext(backups[0]), ".bin")
+
+		// Content larger than MaxSize is written to the new file.
+		_, err = f.Write([]byte("0123456789abcdef"))
+		t.Assert(err, nil)
+		t.Assert(gfile.GetContents(path), "0123456789abcdef")
+		backups, err = f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 2)
+		t.Assert(gfile.GetContents(backups[0]), "7890ab")
+		t.Assert(gfile.GetContents(backups[1]), "123456")
+
+		// Closed.
+		t.Assert(f.Close(), nil)
+		_, err = f.Write([]byte("closed"))
+		t.AssertNE(err, nil)
+	})
+	// Opens existing file for appending.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		t.Assert(gfile.PutContents(path, "12345678"), nil)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{MaxSize: 10})
+		t.Assert(err, nil)
+		defer f.Close()
+		_, err = f.Write([]byte("90"))
+		t.Assert(err, nil)
+		t.Assert(gfile.GetContents(path), "1234567890")
+		_, err = f.Write([]byte("a"))
+		t.Assert(err, nil)
+		t.Assert(gfile.GetContents(path), "a")
+	})
+}
+
+func Test_RotatingFile_Backups(t *testing.T) {
+	// MaxBackups.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{MaxBackups: 2})
+		t.Assert(err, nil)
+		defer f.Close()
+		for _, content := range []string{"1", "2", "3", "4"} {
+			_, err = f.Write([]byte(content))
+			t.Assert(err, nil)
+			t.Assert(f.Rotate(), nil)
+		}
+		// Empty file is not rotated.
+		t.Assert(f.Rotate(), nil)
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 2)
+		t.Assert(gfile.GetContents(backups[0]), "4")
+		t.Assert(gfile.GetContents(backups[1]), "3")
+	})
+	// MaxAge.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{MaxAge: time.Hour})
+		t.Assert(err, nil)
+		defer f.Close()
+		_, err = f.Write([]byte("expired"))
+		t.Assert(err, nil)
+		t.Assert(f.Rotate(), nil)
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 1)
+		mtime := time.Now().Add(-2 * time.Hour)
+		t.Assert(os.Chtimes(backups[0], mtime, mtime), nil)
+
+		_, err = f.Write([]byte("fresh"))
+		t.Assert(err, nil)
+		t.Assert(f.Rotate(), nil)
+		backups, err = f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 1)
+		t.Assert(gfile.GetContents(backups[0]), "fresh")
+	})
+	// Compress.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{Compress: true})
+		t.Assert(err, nil)
+		defer f.Close()
+		_, err = f.Write([]byte("compressed"))
+		t.Assert(err, nil)
+		t.Assert(f.Rotate(), nil)
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		t.Assert(len(backups), 1)
+		t.Assert(gfile.Ext(backups[0]), ".gz")
+
+		reader, err := gzip.NewReader(bytes.NewReader(gfile.GetBytes(backups[0])))
+		t.Assert(err, nil)
+		content, err := ioutil.ReadAll(reader)
+		t.Assert(err, nil)
+		t.Assert(content, "compressed")
+	})
+	// Sorted by rotation time and suffix.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		for _, name := range []string{
+			"audit.20200326101301.899002.bin",
+			"audit.20200326101301.899002.1.bin",
+			"audit.20200326101301.899002.2.bin.gz",
+			"audit.20200326101301.899002.10.bin",
+			"audit.20200326101301.899001.3.bin",
+			"audit.20200326101302.000000.bin",
+		} {
+			t.Assert(gfile.PutContents(gfile.Join(dir, name), name), nil)
+		}
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{})
+		t.Assert(err, nil)
+		defer f.Close()
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		names := make([]string, len(backups))
+		for i, backup := range backups {
+			names[i] = gfile.Basename(backup)
+		}
+		t.Assert(names, []string{
+			"audit.20200326101302.000000.bin",
+			"audit.20200326101301.899002.10.bin",
+			"audit.20200326101301.899002.2.bin.gz",
+			"audit.20200326101301.899002.1.bin",
+			"audit.20200326101301.899002.bin",
+			"audit.20200326101301.899001.3.bin",
+		})
+	})
+}
+
+func Test_RotatingFile_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg   = sync.WaitGroup{}
+			dir  = gfile.TempDir(gtime.TimestampNanoStr())
+			path = gfile.Join(dir, "audit.bin")
+		)
+		defer gfile.Remove(dir)
+		f, err := gfile.NewRotatingFile(path, gfile.RotateOptions{MaxSize: 100})
+		t.Assert(err, nil)
+		defer f.Close()
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f.Write([]byte("0123456789"))
+			}()
+		}
+		wg.Wait()
+		backups, err := f.Backups()
+		t.Assert(err, nil)
+		total := gfile.Size(path)
+		for _, backup := range backups {
+			t.Assert(gfile.Size(backup), 100)
+			total += gfile.Size(backup)
+		}
+		t.Assert(total, 1000)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gfile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+func Test_Watcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path1 = gfile.TempDir(gconv.String(gtime.TimestampNano()) + "_watcher1")
+			path2 = gfile.TempDir(gconv.String(gtime.TimestampNano()) + "_watcher2")
+		)
+		t.Assert(gfile.PutContents(path1, "init"), nil)
+		t.Assert(gfile.PutContents(path2, "init"), nil)
+		defer gfile.Remove(path1)
+		defer gfile.Remove(path2)
+
+		w, err := gfile.NewWatcher(path1)
+		t.Assert(err, nil)
+		defer w.Close()
+		t.Assert(w.Add(path2), nil)
+		time.Sleep(100 * time.Millisecond)
+
+		t.Assert(gfile.PutContents(path1, "1"), nil)
+		t.Assert(gfile.PutContents(path2, "2"), nil)
+
+		paths := make(map[string]struct{})
+		timeout := time.After(time.Second)
+		for len(paths) < 2 {
+			select {
+			case event := <-w.Events():
+				t.AssertNE(event.Op, 0)
+				t.Assert(event.Time.IsZero(), false)
+				paths[event.Path] = struct{}{}
+			case <-timeout:
+				t.Error("waiting for events timeout")
+				return
+			}
+		}
+		_, ok1 := paths[gfile.RealPath(path1)]
+		_, ok2 := paths[gfile.RealPath(path2)]
+		t.Assert(ok1, true)
+		t.Assert(ok2, true)
+
+		// Removed path produces no more events.
+		t.Assert(w.Remove(path1), nil)
+		t.AssertNE(w.Remove(path1), nil)
+		time.Sleep(100 * time.Millisecond)
+	drain:
+		for {
+			select {
+			case <-w.Events():
+			default:
+				break drain
+			}
+		}
+		t.Assert(gfile.PutContents(path1, "3"), nil)
+		time.Sleep(200 * time.Millisecond)
+		select {
+		case event := <-w.Events():
+			t.Error("unexpected event:", event.Path)
+		default:
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gfile.NewWatcher("/none-exist-path-for-watcher")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		w, err := gfile.NewWatcher()
+		t.Assert(err, nil)
+		t.Assert(w.Close(), nil)
+		t.Assert(w.Close(), nil)
+		_, ok := <-w.Events()
+		t.Assert(ok, false)
+		t.AssertNE(w.Add(gfile.TempDir()), nil)
+	})
+}
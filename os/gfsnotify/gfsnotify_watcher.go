@@ -105,11 +105,13 @@ func (w *Watcher) addWithCallbackFunc(name, path string, callbackFunc func(event
 
 // Close closes the watcher.
 func (w *Watcher) Close() {
-	w.events.Close()
+	// It stops the watch loop before closing the events queue,
+	// to avoid pushing events to the closed queue.
+	close(w.closeChan)
 	if err := w.watcher.Close(); err != nil {
 		intlog.Error(err)
 	}
-	close(w.closeChan)
+	w.events.Close()
 }
 
 // Remove removes monitor and all callbacks associated with the <path> recursively.
@@ -21,7 +21,11 @@ func (w *Watcher) startWatchLoop() {
 				return
 
 			// Event listening.
-			case ev := <-w.watcher.Events:
+			case ev, ok := <-w.watcher.Events:
+				// The underlying watcher is closed.
+				if !ok {
+					return
+				}
 				// Filter the repeated event in custom duration.
 				w.cache.SetIfNotExist(ev.String(), func() (interface{}, error) {
 					w.events.Push(&Event{
@@ -33,7 +37,10 @@ func (w *Watcher) startWatchLoop() {
 					return struct{}{}, nil
 				}, repeatEventFilterDuration)
 
-			case err := <-w.watcher.Errors:
+			case err, ok := <-w.watcher.Errors:
+				if !ok {
+					return
+				}
 				intlog.Error(err)
 			}
 		}
@@ -104,3 +104,21 @@ func Line(long ...bool) *Logger {
 func Async(enabled ...bool) *Logger {
 	return logger.Async(enabled...)
 }
+
+// WithFields returns a new logger from default logger bound to custom <fields>,
+// which are attached to every logging entry.
+func WithFields(fields map[string]interface{}) *Logger {
+	return logger.WithFields(fields)
+}
+
+// WithContext returns a new logger from default logger bound to <ctx>,
+// which prints the values of registered context keys in each logging content.
+func WithContext(ctx context.Context) *Logger {
+	return logger.WithContext(ctx)
+}
+
+// WithRequestId returns a new logger from default logger bound to request id <id>,
+// which prefixes every logging content with "[id]".
+func WithRequestId(id string) *Logger {
+	return logger.WithRequestId(id)
+}
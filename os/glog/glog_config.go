@@ -8,6 +8,7 @@ package glog
 
 import (
 	"io"
+	"time"
 )
 
 // SetConfig set configurations for the logger.
@@ -73,6 +74,17 @@ func SetAsync(enabled bool) {
 	logger.SetAsync(enabled)
 }
 
+// SetAsyncBuffer switches default logger to write the logging content through an internal
+// channel based writer with buffer of <bufferSize> entries.
+func SetAsyncBuffer(bufferSize int) {
+	logger.SetAsyncBuffer(bufferSize)
+}
+
+// Flush blocks until the buffered logging content of default logger is written.
+func Flush() error {
+	return logger.Flush()
+}
+
 // SetStdoutPrint sets whether ouptput the logging contents to stdout, which is true in default.
 func SetStdoutPrint(enabled bool) {
 	logger.SetStdoutPrint(enabled)
@@ -83,6 +95,16 @@ func SetHeaderPrint(enabled bool) {
 	logger.SetHeaderPrint(enabled)
 }
 
+// SetInlineCompress sets whether compress each logging entry individually before writing to the logging file for default logger.
+func SetInlineCompress(enabled bool) {
+	logger.SetInlineCompress(enabled)
+}
+
+// SetLineNumbers sets whether prefix each logging entry with a monotonically increasing line number for default logger.
+func SetLineNumbers(enabled bool) {
+	logger.SetLineNumbers(enabled)
+}
+
 // SetPrefix sets prefix string for every logging content.
 // Prefix is part of header, which means if header output is shut, no prefix will be output.
 func SetPrefix(prefix string) {
@@ -148,3 +170,71 @@ func SetLevelPrefixes(prefixes map[int]string) {
 func GetLevelPrefix(level int) string {
 	return logger.GetLevelPrefix(level)
 }
+
+// SetLevelWriter sets the customized <writer> for specified logging <level>.
+func SetLevelWriter(level int, writer io.Writer) {
+	logger.SetLevelWriter(level, writer)
+}
+
+// GetLevelWriter returns the customized writer for specified logging <level>.
+func GetLevelWriter(level int) io.Writer {
+	return logger.GetLevelWriter(level)
+}
+
+// SetFormat sets the output format for logging content of default logger, eg: FormatText, FormatJSON.
+func SetFormat(format LogFormat) {
+	logger.SetFormat(format)
+}
+
+// GetFormat returns the output format for logging content of default logger.
+func GetFormat() LogFormat {
+	return logger.GetFormat()
+}
+
+// SetSamplingRate sets the sampling <rate> between 0.0 and 1.0 for all logging levels of default logger.
+func SetSamplingRate(rate float64) {
+	logger.SetSamplingRate(rate)
+}
+
+// SetLevelSamplingRate sets the sampling <rate> between 0.0 and 1.0 for specified logging <level> of default logger.
+func SetLevelSamplingRate(level int, rate float64) {
+	logger.SetLevelSamplingRate(level, rate)
+}
+
+// SetDeduplication enables deduplication of the identical logging messages with time <window> for default logger.
+func SetDeduplication(window time.Duration) {
+	logger.SetDeduplication(window)
+}
+
+// SetPackageLevelFilter sets the logging <level> for the logging content originating from files
+// under <pkgPrefix> for default logger.
+func SetPackageLevelFilter(pkgPrefix string, level int) {
+	logger.SetPackageLevelFilter(pkgPrefix, level)
+}
+
+// RemovePackageLevelFilter removes the logging level filter for <pkgPrefix> for default logger.
+func RemovePackageLevelFilter(pkgPrefix string) {
+	logger.RemovePackageLevelFilter(pkgPrefix)
+}
+
+// AddWriter adds <writer> to default logger, which writes logging content to <writer>
+// in addition to its default output.
+func AddWriter(writer io.Writer) {
+	logger.AddWriter(writer)
+}
+
+// RemoveWriter removes <writer> previously added by AddWriter from default logger.
+func RemoveWriter(writer io.Writer) {
+	logger.RemoveWriter(writer)
+}
+
+// SetOutput sets <writer> as the output of default logger, which receives the formatted
+// logging content directly instead of stdout.
+func SetOutput(writer io.Writer) {
+	logger.SetOutput(writer)
+}
+
+// Rotate rotates the current logging file of default logger immediately.
+func Rotate() error {
+	return logger.Rotate()
+}
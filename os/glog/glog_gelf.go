@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	gelfVersion        = "1.1"
+	gelfChunkSize      = 1420 // Max payload size of each UDP datagram, which is the recommended size for WAN.
+	gelfChunkHeaderLen = 12   // Chunk header: 2 bytes magic, 8 bytes message id, 1 byte sequence number, 1 byte sequence count.
+	gelfChunkMaxCount  = 128  // Max chunk count of a message, which is defined by GELF specification.
+	gelfDefaultLevel   = 6    // Syslog level informational.
+)
+
+var (
+	// gelfChunkMagic is the magic bytes of chunked GELF message.
+	gelfChunkMagic = []byte{0x1e, 0x0f}
+
+	// gelfLevels defines the logging level prefix to its syslog level mapping.
+	gelfLevels = map[string]int{
+		defaultLevelPrefixes[LEVEL_DEBU]: 7,
+		defaultLevelPrefixes[LEVEL_INFO]: 6,
+		defaultLevelPrefixes[LEVEL_NOTI]: 5,
+		defaultLevelPrefixes[LEVEL_WARN]: 4,
+		defaultLevelPrefixes[LEVEL_ERRO]: 3,
+		defaultLevelPrefixes[LEVEL_CRIT]: 2,
+		defaultLevelPrefixes[LEVEL_PANI]: 1,
+		defaultLevelPrefixes[LEVEL_FATA]: 0,
+	}
+
+	// gelfReservedFields are the fields of JSON logging content that are converted to GELF standard fields.
+	gelfReservedFields = map[string]struct{}{
+		"time":    {},
+		"level":   {},
+		"message": {},
+	}
+)
+
+// gelfWriter is the writer sending logging content in GELF to Graylog server over UDP.
+type gelfWriter struct {
+	conn net.Conn // UDP connection to Graylog server.
+	host string   // Host name of current machine, which is the "host" field of GELF.
+}
+
+// NewGELFWriter creates and returns a writer, which sends each logging entry as GELF(Graylog Extended Log Format)
+// JSON payload over UDP to the Graylog server <addr>, eg: "127.0.0.1:12201".
+// The payload larger than UDP datagram size is sent in chunked GELF automatically.
+//
+// It's recommended to use the writer with JSON format, in which the structured fields attached via WithFields
+// are passed through as GELF additional fields. For text format, the level is parsed from the level prefix.
+func NewGELFWriter(addr string) (io.WriteCloser, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	return &gelfWriter{
+		conn: conn,
+		host: host,
+	}, nil
+}
+
+// Write implements the io.Writer interface, which sends <p> as one GELF message.
+func (w *gelfWriter) Write(p []byte) (n int, err error) {
+	payload, err := json.Marshal(w.message(p))
+	if err != nil {
+		return 0, err
+	}
+	if len(payload) <= gelfChunkSize {
+		_, err = w.conn.Write(payload)
+	} else {
+		err = w.writeChunks(payload)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close implements the io.Closer interface, which closes the UDP connection.
+func (w *gelfWriter) Close() error {
+	return w.conn.Close()
+}
+
+// message converts logging content <p> to GELF message.
+func (w *gelfWriter) message(p []byte) map[string]interface{} {
+	var (
+		content = bytes.TrimSpace(p)
+		message = map[string]interface{}{
+			"version": gelfVersion,
+			"host":    w.host,
+		}
+		fields    map[string]interface{}
+		text      string
+		level     = gelfDefaultLevel
+		timestamp = time.Now()
+	)
+	if len(content) > 0 && content[0] == '{' && json.Unmarshal(content, &fields) == nil {
+		// JSON format.
+		text = fmt.Sprint(fields["message"])
+		if v, ok := fields["level"].(string); ok {
+			if l, ok := gelfLevels[v]; ok {
+				level = l
+			}
+		}
+		if v, ok := fields["time"].(string); ok {
+			if t, err := time.Parse(jsonTimeFormat, v); err == nil {
+				timestamp = t
+			}
+		}
+		for k, v := range fields {
+			if _, ok := gelfReservedFields[k]; ok || k == "id" {
+				continue
+			}
+			message["_"+k] = v
+		}
+	} else {
+		// Text format.
+		text = string(content)
+		first := -1
+		for prefix, l := range gelfLevels {
+			if pos := strings.Index(text, "["+prefix+"]"); pos != -1 && (first == -1 || pos < first) {
+				first = pos
+				level = l
+			}
+		}
+	}
+	if pos := strings.IndexByte(text, '\n'); pos != -1 {
+		message["short_message"] = text[:pos]
+		message["full_message"] = text
+	} else {
+		message["short_message"] = text
+	}
+	message["level"] = level
+	message["timestamp"] = float64(timestamp.UnixNano()/int64(time.Millisecond)) / 1000
+	return message
+}
+
+// writeChunks sends <payload> in chunked GELF.
+func (w *gelfWriter) writeChunks(payload []byte) error {
+	var (
+		dataSize = gelfChunkSize - gelfChunkHeaderLen
+		count    = (len(payload) + dataSize - 1) / dataSize
+	)
+	if count > gelfChunkMaxCount {
+		return fmt.Errorf(`GELF message too large: %d bytes exceeds %d chunks`, len(payload), gelfChunkMaxCount)
+	}
+	messageId := make([]byte, 8)
+	if _, err := rand.Read(messageId); err != nil {
+		return err
+	}
+	chunk := make([]byte, 0, gelfChunkSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * dataSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk = append(chunk[:0], gelfChunkMagic...)
+		chunk = append(chunk, messageId...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*dataSize:end]...)
+		if _, err := w.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -78,10 +78,28 @@ func (l *Logger) Clone() *Logger {
 	logger := New()
 	logger.ctx = l.ctx
 	logger.config = l.config
+	logger.config.writers = l.config.writers.clone()
 	logger.parent = l
 	return logger
 }
 
+// derive returns a new standalone logger with the same configuration and context of current logger.
+// Different from Clone, the returned logger is not used for chaining operations,
+// and it shares the initialization status with current logger,
+// so the rotation feature is not initialized twice.
+func (l *Logger) derive() *Logger {
+	logger := New()
+	logger.ctx = l.ctx
+	logger.config = l.config
+	logger.config.writers = l.config.writers.clone()
+	if l.parent != nil {
+		logger.init = l.parent.init
+	} else {
+		logger.init = l.init
+	}
+	return logger
+}
+
 // getFilePath returns the logging file path.
 // The logging file name must have extension name of "log".
 func (l *Logger) getFilePath(now time.Time) string {
@@ -94,7 +112,11 @@ func (l *Logger) getFilePath(now time.Time) string {
 }
 
 // print prints <s> to defined writer, logging file or passed <std>.
-func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
+// The parameter <level> is the logging level of the content, which is levelNone for Print/Printf.
+//
+// It returns the aggregated error of all writers, which is always nil in async mode,
+// as the writing is asynchronous and the errors are only logged internally or returned by Flush.
+func (l *Logger) print(std io.Writer, level int, values ...interface{}) error {
 	// Lazy initialize for rotation feature.
 	// It uses atomic reading operation to enhance the performance checking.
 	// It here uses CAP for performance and concurrent safety.
@@ -108,10 +130,61 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 			gtimer.AddOnce(p.config.RotateCheckInterval, p.rotateChecksTimely)
 			intlog.Printf("logger rotation initialized: every %s", p.config.RotateCheckInterval.String())
 		}
+		if p.config.RotateOnCalendar > 0 {
+			p.rotateOnCalendarTimely()
+		}
+	}
+
+	// Sampling checks.
+	if !l.sampled(level) {
+		return nil
+	}
+	// Deduplication checks.
+	if l.deduplicated(std, level, values...) {
+		return nil
 	}
+	return l.output(std, level, values...)
+}
 
+// output formats the logging content of <values> and outputs it to the writers.
+func (l *Logger) output(std io.Writer, level int, values ...interface{}) error {
 	var (
 		now    = time.Now()
+		buffer *bytes.Buffer
+	)
+	if l.config.Format == FormatJSON {
+		buffer = l.jsonContent(now, level, values...)
+	} else {
+		buffer = l.textContent(now, level, values...)
+	}
+	// It writes synchronously if the shared buffered writer is closed by its owner logger.
+	if w := l.config.asyncWriter; w != nil && w.send(&asyncEntry{
+		logger: l,
+		now:    now,
+		level:  level,
+		std:    std,
+		buffer: buffer,
+	}) {
+		return nil
+	}
+	if l.config.Flags&F_ASYNC > 0 {
+		err := asyncPool.Add(func() {
+			if err := l.printToWriter(now, level, std, buffer); err != nil {
+				intlog.Error(err)
+			}
+		})
+		if err != nil {
+			intlog.Error(err)
+		}
+		return nil
+	}
+	return l.printToWriter(now, level, std, buffer)
+}
+
+// textContent formats the logging content of <values> in text format.
+func (l *Logger) textContent(now time.Time, level int, values ...interface{}) *bytes.Buffer {
+	var (
+		lead   = l.getLevelPrefixWithBrackets(level)
 		buffer = bytes.NewBuffer(nil)
 	)
 	if l.config.HeaderPrint {
@@ -157,12 +230,6 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 			buffer.WriteString(l.config.Prefix + " ")
 		}
 	}
-	// Convert value to string.
-	var (
-		tempStr  = ""
-		valueStr = ""
-	)
-
 	if l.ctx != nil {
 		// Tracing values.
 		spanCtx := trace.SpanContextFromContext(l.ctx)
@@ -186,6 +253,40 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 		}
 	}
 
+	// Request id.
+	if len(l.config.requestId) > 0 {
+		buffer.WriteString("[" + l.config.requestId + "] ")
+	}
+	// Registered context fields and custom fields.
+	var (
+		fieldsStr                     = ""
+		ctxFieldNames, ctxFieldValues = l.contextFields()
+	)
+	for i, name := range ctxFieldNames {
+		if fieldsStr != "" {
+			fieldsStr += ", "
+		}
+		fieldsStr += fmt.Sprintf("%s: %+v", name, ctxFieldValues[i])
+	}
+	for _, key := range l.sortedFieldKeys() {
+		if fieldsStr != "" {
+			fieldsStr += ", "
+		}
+		fieldsStr += fmt.Sprintf("%s: %+v", key, l.config.Fields[key])
+	}
+	if fieldsStr != "" {
+		buffer.WriteString(fmt.Sprintf("{%s} ", fieldsStr))
+	}
+	buffer.WriteString(valuesToString(values...) + "\n")
+	return buffer
+}
+
+// valuesToString converts and joins <values> to logging message string.
+func valuesToString(values ...interface{}) string {
+	var (
+		tempStr  = ""
+		valueStr = ""
+	)
 	for _, v := range values {
 		tempStr = gconv.String(v)
 		if len(valueStr) > 0 {
@@ -203,38 +304,69 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 			valueStr = tempStr
 		}
 	}
-	buffer.WriteString(valueStr + "\n")
-	if l.config.Flags&F_ASYNC > 0 {
-		err := asyncPool.Add(func() {
-			l.printToWriter(now, std, buffer)
-		})
-		if err != nil {
-			intlog.Error(err)
-		}
+	return valueStr
+}
+
+// printToWriter writes buffer to writer.
+// The level writer has priority over the default writer if it is set for <level>,
+// the writer set by SetOutput replaces stdout and coexists with the file logging,
+// and the content is also written to all writers added by AddWriter.
+func (l *Logger) printToWriter(now time.Time, level int, std io.Writer, buffer *bytes.Buffer) error {
+	var err error
+	if l.config.LineNumbers {
+		// The line number is increased and written under the same lock,
+		// so the line numbers are in order of writing.
+		l.config.lineNumber.mu.Lock()
+		err = l.writeToWriters(now, level, std, l.lineNumbered(now, buffer))
+		l.config.lineNumber.mu.Unlock()
 	} else {
-		l.printToWriter(now, std, buffer)
+		err = l.writeToWriters(now, level, std, buffer)
 	}
+	if err != nil && l.config.writerErrorHandler != nil {
+		l.config.writerErrorHandler(err)
+	}
+	return err
 }
 
-// printToWriter writes buffer to writer.
-func (l *Logger) printToWriter(now time.Time, std io.Writer, buffer *bytes.Buffer) {
-	if l.config.Writer == nil {
+// writeToWriters writes buffer to all writers of printToWriter,
+// and returns the aggregated error of all writers.
+func (l *Logger) writeToWriters(now time.Time, level int, std io.Writer, buffer *bytes.Buffer) error {
+	var errs []error
+	if writer := l.getLevelWriter(level); writer != nil {
+		if _, err := writer.Write(buffer.Bytes()); err != nil {
+			intlog.Error(err)
+			errs = append(errs, err)
+		}
+	} else if l.config.Writer == nil {
 		// Output content to disk file.
 		if l.config.Path != "" {
 			l.printToFile(now, buffer)
 		}
-		// Allow output to stdout?
-		if l.config.StdoutPrint {
+		if l.config.output != nil {
+			// Output content to the writer set by SetOutput instead of stdout.
+			if _, err := l.config.output.Write(buffer.Bytes()); err != nil {
+				intlog.Error(err)
+				errs = append(errs, err)
+			}
+		} else if l.config.StdoutPrint {
+			// Allow output to stdout.
 			if _, err := std.Write(buffer.Bytes()); err != nil {
 				intlog.Error(err)
+				errs = append(errs, err)
 			}
 		}
 	} else {
 		if _, err := l.config.Writer.Write(buffer.Bytes()); err != nil {
 			// panic(err)
 			intlog.Error(err)
+			errs = append(errs, err)
 		}
 	}
+	// Fan-out writers.
+	if _, err := l.config.writers.Write(buffer.Bytes()); err != nil {
+		errs = append(errs, err)
+	}
+	return aggregateErrors(errs)
 }
 
 // printToFile outputs logging content to disk file.
@@ -256,7 +388,17 @@ func (l *Logger) printToFile(now time.Time, buffer *bytes.Buffer) {
 	if file := l.getFilePointer(logFilePath); file == nil {
 		intlog.Errorf(`got nil file pointer for: %s`, logFilePath)
 	} else {
-		if _, err := file.Write(buffer.Bytes()); err != nil {
+		var (
+			err     error
+			content = buffer.Bytes()
+		)
+		if l.config.InlineCompress {
+			content, err = inlineCompress(content)
+		}
+		if err == nil {
+			_, err = file.Write(content)
+		}
+		if err != nil {
 			intlog.Error(err)
 		}
 		if err := file.Close(); err != nil {
@@ -281,19 +423,19 @@ func (l *Logger) getFilePointer(path string) *gfpool.File {
 }
 
 // printStd prints content <s> without stack.
-func (l *Logger) printStd(lead string, value ...interface{}) {
-	l.print(os.Stdout, lead, value...)
+func (l *Logger) printStd(level int, value ...interface{}) error {
+	return l.print(os.Stdout, level, value...)
 }
 
 // printStd prints content <s> with stack check.
-func (l *Logger) printErr(lead string, value ...interface{}) {
+func (l *Logger) printErr(level int, value ...interface{}) error {
 	if l.config.StStatus == 1 {
 		if s := l.GetStack(); s != "" {
 			value = append(value, "\nStack:\n"+s)
 		}
 	}
 	// In matter of sequence, do not use stderr here, but use the same stdout.
-	l.print(os.Stdout, lead, value...)
+	return l.print(os.Stdout, level, value...)
 }
 
 // format formats <values> using fmt.Sprintf.
@@ -14,13 +14,13 @@ import (
 // Print prints <v> with newline using fmt.Sprintln.
 // The parameter <v> can be multiple variables.
 func (l *Logger) Print(v ...interface{}) {
-	l.printStd("", v...)
+	l.printStd(levelNone, v...)
 }
 
 // Printf prints <v> with format <format> using fmt.Sprintf.
 // The parameter <v> can be multiple variables.
 func (l *Logger) Printf(format string, v ...interface{}) {
-	l.printStd("", l.format(format, v...))
+	l.printStd(levelNone, l.format(format, v...))
 }
 
 // Println is alias of Print.
@@ -31,53 +31,57 @@ func (l *Logger) Println(v ...interface{}) {
 
 // Fatal prints the logging content with [FATA] header and newline, then exit the current process.
 func (l *Logger) Fatal(v ...interface{}) {
-	l.printErr(l.getLevelPrefixWithBrackets(LEVEL_FATA), v...)
+	l.printErr(LEVEL_FATA, v...)
+	_ = l.Flush()
 	os.Exit(1)
 }
 
 // Fatalf prints the logging content with [FATA] header, custom format and newline, then exit the current process.
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.printErr(l.getLevelPrefixWithBrackets(LEVEL_FATA), l.format(format, v...))
+	l.printErr(LEVEL_FATA, l.format(format, v...))
+	_ = l.Flush()
 	os.Exit(1)
 }
 
 // Panic prints the logging content with [PANI] header and newline, then panics.
 func (l *Logger) Panic(v ...interface{}) {
-	l.printErr(l.getLevelPrefixWithBrackets(LEVEL_PANI), v...)
+	l.printErr(LEVEL_PANI, v...)
+	_ = l.Flush()
 	panic(fmt.Sprint(v...))
 }
 
 // Panicf prints the logging content with [PANI] header, custom format and newline, then panics.
 func (l *Logger) Panicf(format string, v ...interface{}) {
-	l.printErr(l.getLevelPrefixWithBrackets(LEVEL_PANI), l.format(format, v...))
+	l.printErr(LEVEL_PANI, l.format(format, v...))
+	_ = l.Flush()
 	panic(l.format(format, v...))
 }
 
 // Info prints the logging content with [INFO] header and newline.
 func (l *Logger) Info(v ...interface{}) {
 	if l.checkLevel(LEVEL_INFO) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_INFO), v...)
+		l.printStd(LEVEL_INFO, v...)
 	}
 }
 
 // Infof prints the logging content with [INFO] header, custom format and newline.
 func (l *Logger) Infof(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_INFO) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_INFO), l.format(format, v...))
+		l.printStd(LEVEL_INFO, l.format(format, v...))
 	}
 }
 
 // Debug prints the logging content with [DEBU] header and newline.
 func (l *Logger) Debug(v ...interface{}) {
 	if l.checkLevel(LEVEL_DEBU) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_DEBU), v...)
+		l.printStd(LEVEL_DEBU, v...)
 	}
 }
 
 // Debugf prints the logging content with [DEBU] header, custom format and newline.
 func (l *Logger) Debugf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_DEBU) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_DEBU), l.format(format, v...))
+		l.printStd(LEVEL_DEBU, l.format(format, v...))
 	}
 }
 
@@ -85,7 +89,7 @@ func (l *Logger) Debugf(format string, v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Notice(v ...interface{}) {
 	if l.checkLevel(LEVEL_NOTI) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_NOTI), v...)
+		l.printStd(LEVEL_NOTI, v...)
 	}
 }
 
@@ -93,7 +97,7 @@ func (l *Logger) Notice(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Noticef(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_NOTI) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_NOTI), l.format(format, v...))
+		l.printStd(LEVEL_NOTI, l.format(format, v...))
 	}
 }
 
@@ -101,7 +105,7 @@ func (l *Logger) Noticef(format string, v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Warning(v ...interface{}) {
 	if l.checkLevel(LEVEL_WARN) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_WARN), v...)
+		l.printStd(LEVEL_WARN, v...)
 	}
 }
 
@@ -109,7 +113,7 @@ func (l *Logger) Warning(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Warningf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_WARN) {
-		l.printStd(l.getLevelPrefixWithBrackets(LEVEL_WARN), l.format(format, v...))
+		l.printStd(LEVEL_WARN, l.format(format, v...))
 	}
 }
 
@@ -117,7 +121,7 @@ func (l *Logger) Warningf(format string, v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Error(v ...interface{}) {
 	if l.checkLevel(LEVEL_ERRO) {
-		l.printErr(l.getLevelPrefixWithBrackets(LEVEL_ERRO), v...)
+		l.printErr(LEVEL_ERRO, v...)
 	}
 }
 
@@ -125,7 +129,7 @@ func (l *Logger) Error(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Errorf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_ERRO) {
-		l.printErr(l.getLevelPrefixWithBrackets(LEVEL_ERRO), l.format(format, v...))
+		l.printErr(LEVEL_ERRO, l.format(format, v...))
 	}
 }
 
@@ -133,7 +137,7 @@ func (l *Logger) Errorf(format string, v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Critical(v ...interface{}) {
 	if l.checkLevel(LEVEL_CRIT) {
-		l.printErr(l.getLevelPrefixWithBrackets(LEVEL_CRIT), v...)
+		l.printErr(LEVEL_CRIT, v...)
 	}
 }
 
@@ -141,11 +145,11 @@ func (l *Logger) Critical(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Criticalf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_CRIT) {
-		l.printErr(l.getLevelPrefixWithBrackets(LEVEL_CRIT), l.format(format, v...))
+		l.printErr(LEVEL_CRIT, l.format(format, v...))
 	}
 }
 
 // checkLevel checks whether the given <level> could be output.
 func (l *Logger) checkLevel(level int) bool {
-	return l.config.Level&level > 0
+	return l.getCallerLevel()&level > 0
 }
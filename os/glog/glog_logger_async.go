@@ -0,0 +1,138 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// asyncWriter is the channel based writer, which writes the logging entries in background goroutine.
+type asyncWriter struct {
+	owner   *Logger          // Logger creating the writer, which is the only one closing it.
+	entries chan *asyncEntry // Buffered logging entries.
+	mu      sync.Mutex       // Mutex for errs.
+	errs    []error          // Writing errors since last flushing.
+	closeMu sync.RWMutex     // Mutex for concurrent safety between the sending and closing of entries.
+	closed  bool             // Whether the entries channel is closed.
+}
+
+// asyncEntry is the logging entry for asyncWriter, in which the <done> is not nil for flushing marker.
+type asyncEntry struct {
+	logger *Logger
+	now    time.Time
+	level  int
+	std    io.Writer
+	buffer *bytes.Buffer
+	done   chan struct{}
+}
+
+// newAsyncWriter creates and returns an asyncWriter of <owner> with buffer of <size> entries,
+// and starts its background goroutine.
+func newAsyncWriter(owner *Logger, size int) *asyncWriter {
+	w := &asyncWriter{
+		owner:   owner,
+		entries: make(chan *asyncEntry, size),
+	}
+	go w.loop()
+	return w
+}
+
+// loop drains the channel and writes the logging entries to the underlying writers.
+// It exits when the channel is closed.
+func (w *asyncWriter) loop() {
+	for entry := range w.entries {
+		if entry.done != nil {
+			close(entry.done)
+			continue
+		}
+		if err := entry.logger.printToWriter(entry.now, entry.level, entry.std, entry.buffer); err != nil {
+			w.mu.Lock()
+			w.errs = append(w.errs, err)
+			w.mu.Unlock()
+		}
+	}
+}
+
+// send queues <entry> to the writer, and returns false if the writer is closed,
+// in which case the caller should write the entry synchronously.
+func (w *asyncWriter) send(entry *asyncEntry) bool {
+	w.closeMu.RLock()
+	defer w.closeMu.RUnlock()
+	if w.closed {
+		return false
+	}
+	w.entries <- entry
+	return true
+}
+
+// close closes the writer, which stops its background goroutine after all queued entries are written.
+// The loggers still sharing the writer, eg: from Clone or WithRequestId, write synchronously after closing.
+func (w *asyncWriter) close() {
+	w.closeMu.Lock()
+	defer w.closeMu.Unlock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+}
+
+// flush blocks until all entries queued before calling are written,
+// and returns the aggregated writing errors since last flushing.
+func (w *asyncWriter) flush() error {
+	done := make(chan struct{})
+	if w.send(&asyncEntry{done: done}) {
+		<-done
+	}
+	w.mu.Lock()
+	errs := w.errs
+	w.errs = nil
+	w.mu.Unlock()
+	return aggregateErrors(errs)
+}
+
+// SetAsyncBuffer switches the logger to write the logging content through an internal
+// channel based writer with buffer of <bufferSize> entries. A background goroutine drains the
+// channel and writes the content to the underlying writers, so the logging calls do not block
+// on writing unless the buffer is full. It takes precedence over the F_ASYNC flag.
+//
+// The Fatal* and Panic* functions attempt flushing the buffered content before exiting or panicking.
+// The <bufferSize> not greater than 0 flushes the buffered content and disables the feature.
+//
+// The loggers from Clone share the buffered writer of current logger, and calling SetAsyncBuffer
+// on them creates or disables their own buffered writer, which never closes the shared one.
+// After current logger disables the buffered writer, the loggers still sharing it write synchronously.
+// The buffered writer created by a cloned logger should be disabled by SetAsyncBuffer(0) after use,
+// or else its background goroutine is never stopped.
+//
+// Note that it should be called before logging, as it's not concurrent safe with logging calls.
+// The name SetAsync is already used for enabling/disabling the F_ASYNC flag.
+func (l *Logger) SetAsyncBuffer(bufferSize int) {
+	if w := l.config.asyncWriter; w != nil {
+		_ = w.flush()
+		// Only the owner closes the writer, as it might be shared with other loggers from Clone.
+		if w.owner == l {
+			w.close()
+		}
+		l.config.asyncWriter = nil
+	}
+	if bufferSize > 0 {
+		l.config.asyncWriter = newAsyncWriter(l, bufferSize)
+	}
+}
+
+// Flush blocks until the buffered logging content from SetAsyncBuffer is written,
+// and returns the aggregated writing error since last flushing.
+// It does nothing and returns nil if the buffered writer is not enabled.
+func (l *Logger) Flush() error {
+	if w := l.config.asyncWriter; w != nil {
+		return w.flush()
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// inlineCompressDictionary is the preset dictionary for inline compression,
+// which contains the commonly repeated content of logging entries.
+// Note that it must never be changed, or else the existing compressed logging files cannot be read.
+var inlineCompressDictionary = []byte(
+	`{"time":"","level":"","file":"","line":,"func":"","prefix":"","traceId":"","message":""}` +
+		`[DEBU] [INFO] [NOTI] [WARN] [ERRO] [CRIT] [PANI] [FATA] ` +
+		`Stack:` + "\n" + `1. github.com/ichunt2019/gf/ .go: main.main ` +
+		`error failed success request response user id name status http://` +
+		` 2006-01-02 15:04:05.000 `,
+)
+
+const (
+	// inlineCompressMaxRecordSize is the max size of a compressed record in bytes,
+	// the record of larger size is considered as file corruption.
+	inlineCompressMaxRecordSize = 64 * 1024 * 1024
+)
+
+// inlineCompressWriterPool is the pool for flate writers of inline compression.
+// It uses the best compression level, as the lower levels store the small logging entry
+// without compression in most cases.
+var inlineCompressWriterPool = sync.Pool{
+	New: func() interface{} {
+		writer, _ := flate.NewWriterDict(nil, flate.BestCompression, inlineCompressDictionary)
+		return writer
+	},
+}
+
+// inlineCompress compresses single logging entry <data> using flate algorithm with preset dictionary.
+//
+// Note that it uses flate of the standard library instead of zstd, as there's no zstd implementation
+// in the standard library, and the zstd packages need the third-party dependency or cgo, which this
+// module avoids for its basic packages like glog. The flate with preset dictionary works similarly
+// to the zstd dictionary compression for the short and repetitive logging entries.
+// The returned content is a record in format: uvarint(length of compressed data) + compressed data.
+func inlineCompress(data []byte) ([]byte, error) {
+	var (
+		buffer = bytes.NewBuffer(make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data)/2))
+		writer = inlineCompressWriterPool.Get().(*flate.Writer)
+	)
+	defer inlineCompressWriterPool.Put(writer)
+	writer.Reset(buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	var (
+		record = buffer.Bytes()
+		header = make([]byte, binary.MaxVarintLen64)
+		n      = binary.PutUvarint(header, uint64(len(record)-binary.MaxVarintLen64))
+		start  = binary.MaxVarintLen64 - n
+	)
+	copy(record[start:], header[:n])
+	return record[start:], nil
+}
+
+// inlineCompressReader decompresses the inline compressed logging file on the fly.
+type inlineCompressReader struct {
+	file    *os.File      // Compressed logging file.
+	reader  *bufio.Reader // Buffered reader of the file.
+	current *bytes.Reader // Decompressed content of current record.
+	remain  int64         // Remaining size of the file in bytes for validating the record length.
+	err     error         // Error of the reading, which is returned by all following Read calls.
+}
+
+// ReadCompressed returns a reader which decompresses the logging file <path> on the fly,
+// the logging file should be written with InlineCompress enabled.
+//
+// The file is closed automatically when the reading reaches the end or any error occurs,
+// and the error of opening or decompressing is returned by the Read of returned reader.
+func ReadCompressed(path string) io.Reader {
+	file, err := os.Open(path)
+	if err != nil {
+		return &inlineCompressReader{err: err}
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return &inlineCompressReader{err: err}
+	}
+	return &inlineCompressReader{
+		file:    file,
+		reader:  bufio.NewReader(file),
+		current: bytes.NewReader(nil),
+		remain:  info.Size(),
+	}
+}
+
+// Read implements the io.Reader interface.
+func (r *inlineCompressReader) Read(p []byte) (n int, err error) {
+	for r.err == nil {
+		if n, _ = r.current.Read(p); n > 0 {
+			return n, nil
+		}
+		r.err = r.next()
+	}
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+	return 0, r.err
+}
+
+// next reads and decompresses the next record from the file.
+// It returns error if the record length exceeds the max record size or the remaining file size,
+// which means the file is corrupted.
+func (r *inlineCompressReader) next() error {
+	length, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return err
+	}
+	r.remain -= int64(uvarintSize(length))
+	if length > inlineCompressMaxRecordSize || int64(length) > r.remain {
+		return errors.New(fmt.Sprintf(
+			`invalid record length %d, which exceeds the max record size %d or the remaining file size %d`,
+			length, inlineCompressMaxRecordSize, r.remain,
+		))
+	}
+	r.remain -= int64(length)
+	record := make([]byte, length)
+	if _, err = io.ReadFull(r.reader, record); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	reader := flate.NewReaderDict(bytes.NewReader(record), inlineCompressDictionary)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	r.current.Reset(content)
+	return nil
+}
+
+// uvarintSize returns the encoded size of <x> in uvarint.
+func uvarintSize(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
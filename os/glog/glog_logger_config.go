@@ -21,25 +21,48 @@ import (
 
 // Config is the configuration object for logger.
 type Config struct {
-	Writer               io.Writer      `json:"-"`                    // Customized io.Writer.
-	Flags                int            `json:"flags"`                // Extra flags for logging output features.
-	Path                 string         `json:"path"`                 // Logging directory path.
-	File                 string         `json:"file"`                 // Format for logging file.
-	Level                int            `json:"level"`                // Output level.
-	Prefix               string         `json:"prefix"`               // Prefix string for every logging content.
-	StSkip               int            `json:"stSkip"`               // Skip count for stack.
-	StStatus             int            `json:"stStatus"`             // Stack status(1: enabled - default; 0: disabled)
-	StFilter             string         `json:"stFilter"`             // Stack string filter.
-	CtxKeys              []interface{}  `json:"ctxKeys"`              // Context keys for logging, which is used for value retrieving from context.
-	HeaderPrint          bool           `json:"header"`               // Print header or not(true in default).
-	StdoutPrint          bool           `json:"stdout"`               // Output to stdout or not(true in default).
-	LevelPrefixes        map[int]string `json:"levelPrefixes"`        // Logging level to its prefix string mapping.
-	RotateSize           int64          `json:"rotateSize"`           // Rotate the logging file if its size > 0 in bytes.
-	RotateExpire         time.Duration  `json:"rotateExpire"`         // Rotate the logging file if its mtime exceeds this duration.
-	RotateBackupLimit    int            `json:"rotateBackupLimit"`    // Max backup for rotated files, default is 0, means no backups.
-	RotateBackupExpire   time.Duration  `json:"rotateBackupExpire"`   // Max expire for rotated files, which is 0 in default, means no expiration.
-	RotateBackupCompress int            `json:"rotateBackupCompress"` // Compress level for rotated files using gzip algorithm. It's 0 in default, means no compression.
-	RotateCheckInterval  time.Duration  `json:"rotateCheckInterval"`  // Asynchronizely checks the backups and expiration at intervals. It's 1 hour in default.
+	Writer                   io.Writer              `json:"-"`                        // Customized io.Writer.
+	Flags                    int                    `json:"flags"`                    // Extra flags for logging output features.
+	Path                     string                 `json:"path"`                     // Logging directory path.
+	File                     string                 `json:"file"`                     // Format for logging file.
+	Level                    int                    `json:"level"`                    // Output level.
+	Prefix                   string                 `json:"prefix"`                   // Prefix string for every logging content.
+	StSkip                   int                    `json:"stSkip"`                   // Skip count for stack.
+	StStatus                 int                    `json:"stStatus"`                 // Stack status(1: enabled - default; 0: disabled)
+	StFilter                 string                 `json:"stFilter"`                 // Stack string filter.
+	CtxKeys                  []interface{}          `json:"ctxKeys"`                  // Context keys for logging, which is used for value retrieving from context.
+	HeaderPrint              bool                   `json:"header"`                   // Print header or not(true in default).
+	StdoutPrint              bool                   `json:"stdout"`                   // Output to stdout or not(true in default).
+	LevelPrefixes            map[int]string         `json:"levelPrefixes"`            // Logging level to its prefix string mapping.
+	LevelWriters             map[int]io.Writer      `json:"-"`                        // Logging level to its customized io.Writer mapping.
+	Format                   LogFormat              `json:"format"`                   // Output format for logging content, eg: text, json.
+	Fields                   map[string]interface{} `json:"-"`                        // Custom fields attached to every logging entry.
+	InlineCompress           bool                   `json:"inlineCompress"`           // Compress each logging entry individually before writing to the logging file(false in default).
+	RotateSize               int64                  `json:"rotateSize"`               // Rotate the logging file if its size > 0 in bytes.
+	RotateExpire             time.Duration          `json:"rotateExpire"`             // Rotate the logging file if its mtime exceeds this duration.
+	RotateBackupLimit        int                    `json:"rotateBackupLimit"`        // Max backup for rotated files, default is 0, means no backups.
+	RotateBackupExpire       time.Duration          `json:"rotateBackupExpire"`       // Max expire for rotated files, which is 0 in default, means no expiration.
+	RotateBackupCompress     int                    `json:"rotateBackupCompress"`     // Compress level for rotated files using gzip algorithm. It's 0 in default, means no compression.
+	RotateCheckInterval      time.Duration          `json:"rotateCheckInterval"`      // Asynchronizely checks the backups and expiration at intervals. It's 1 hour in default.
+	RotateBackupNameTemplate string                 `json:"rotateBackupNameTemplate"` // Template for rotated backup file name in time.Format layout, supporting "{pid}" and "{seq}" tokens, eg: "access-2006-01-02T15-04-05.{seq}.log".
+	RotateOnCalendar         RotateCalendar         `json:"rotateOnCalendar"`         // Rotate the logging file at midnight on calendar boundary, eg: RotateDaily, RotateWeekly, RotateMonthly. It's 0 in default, means disabled.
+	LineNumbers              bool                   `json:"lineNumbers"`              // Prefix each logging entry with a monotonically increasing line number starting from 1(false in default).
+	ResetLineNumbers         bool                   `json:"resetLineNumbers"`         // Reset the line number to 1 after the logging file rotation(false in default).
+
+	writers       *fanoutWriter       // Fan-out writers added by AddWriter, which is not shared among loggers.
+	output        io.Writer           // Output writer set by SetOutput, which replaces stdout.
+	sampler       *logSampler         // Sampler for all logging levels.
+	levelSamplers map[int]*logSampler // Logging level to its sampler mapping.
+	deduplicator  *logDeduplicator    // Deduplicator for identical logging messages.
+	asyncWriter   *asyncWriter        // Channel based writer for buffered asynchronous writing.
+	requestId     string              // Request id prefixed to every logging content.
+	lineNumber    *lineNumber         // Current line number of LineNumbers, which is shared by chaining loggers.
+
+	// Handler for the aggregated error of all writers, see SetWriterErrorHandler.
+	writerErrorHandler func(err error)
+
+	// Caller file path prefix to its logging level mapping, sorted by prefix in ascending order.
+	packageLevelFilters []packageLevelFilter
 }
 
 // DefaultConfig returns the default configuration for logger.
@@ -51,6 +74,9 @@ func DefaultConfig() Config {
 		StStatus:            1,
 		HeaderPrint:         true,
 		StdoutPrint:         true,
+		Format:              FormatText,
+		writers:             &fanoutWriter{},
+		lineNumber:          new(lineNumber),
 		LevelPrefixes:       make(map[int]string, len(defaultLevelPrefixes)),
 		RotateCheckInterval: time.Hour,
 	}
@@ -66,6 +92,10 @@ func DefaultConfig() Config {
 // SetConfig set configurations for the logger.
 func (l *Logger) SetConfig(config Config) error {
 	l.config = config
+	l.config.writers = config.writers.clone()
+	if l.config.lineNumber == nil {
+		l.config.lineNumber = new(lineNumber)
+	}
 	// Necessary validation.
 	if config.Path != "" {
 		if err := l.SetPath(config.Path); err != nil {
@@ -217,6 +247,14 @@ func (l *Logger) SetStdoutPrint(enabled bool) {
 	l.config.StdoutPrint = enabled
 }
 
+// SetInlineCompress sets whether compress each logging entry individually before writing to the logging file.
+// The compressed logging file is not valid text, use ReadCompressed to read its content.
+// Each entry is compressed using flate algorithm with a preset dictionary of the common logging content,
+// which is from the standard library without introducing third-party zstd dependency.
+func (l *Logger) SetInlineCompress(enabled bool) {
+	l.config.InlineCompress = enabled
+}
+
 // SetHeaderPrint sets whether output header of the logging contents, which is true in default.
 func (l *Logger) SetHeaderPrint(enabled bool) {
 	l.config.HeaderPrint = enabled
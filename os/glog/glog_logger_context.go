@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the registered context key and its field name in logging content.
+type contextKey struct {
+	key       interface{} // Key for value retrieving from context.
+	fieldName string      // Field name in logging content.
+}
+
+var (
+	// contextKeys is the registered context keys for logging in registering sequence.
+	contextKeys []contextKey
+
+	// contextKeysMu is the mutex for concurrent safety of contextKeys.
+	contextKeysMu sync.RWMutex
+)
+
+// RegisterContextKey registers context <key> for all loggers, the value of <key> is retrieved from
+// the context of logger and is printed to logging content as field named <fieldName>.
+// It's commonly used for distributed tracing correlation, eg: request id, trace id.
+//
+// Registering the same <key> again updates its <fieldName>.
+func RegisterContextKey(key interface{}, fieldName string) {
+	contextKeysMu.Lock()
+	defer contextKeysMu.Unlock()
+	for i, v := range contextKeys {
+		if v.key == key {
+			contextKeys[i].fieldName = fieldName
+			return
+		}
+	}
+	contextKeys = append(contextKeys, contextKey{
+		key:       key,
+		fieldName: fieldName,
+	})
+}
+
+// WithContext returns a new logger bound to <ctx>, which retrieves the values of registered context keys
+// from <ctx> and prints them as fields in front of each logging content.
+//
+// Different from chaining function Ctx, the returned logger can be stored and reused,
+// which does not change the current logger.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	logger := l.derive()
+	logger.ctx = ctx
+	return logger
+}
+
+// contextFields retrieves and returns the field names and values of registered context keys
+// from the context of logger. The returned slices are in registering sequence.
+func (l *Logger) contextFields() (names []string, values []interface{}) {
+	if l.ctx == nil {
+		return nil, nil
+	}
+	contextKeysMu.RLock()
+	defer contextKeysMu.RUnlock()
+	for _, v := range contextKeys {
+		if value := l.ctx.Value(v.key); value != nil {
+			names = append(names, v.fieldName)
+			values = append(values, value)
+		}
+	}
+	return
+}
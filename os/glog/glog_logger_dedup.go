@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/os/gtimer"
+)
+
+// logDeduplicator suppresses the identical logging messages in a time window.
+type logDeduplicator struct {
+	mu         sync.Mutex
+	window     time.Duration // Time window in which identical messages are suppressed.
+	message    string        // Last output message, which is the deduplication key along with <level>.
+	start      time.Time     // Output time of the last message, which is the beginning of the window.
+	suppressed int           // Suppressed count of the last message.
+	logger     *Logger       // Logger of the last suppressed message, for flushing the counter.
+	std        io.Writer     // Std writer of the last suppressed message, for flushing the counter.
+	level      int           // Logging level of the last output message, which is also for flushing the counter.
+	timer      *gtimer.Entry // Timer flushing the counter when the window expires.
+}
+
+// dedupSummary describes the suppressed count of a message.
+type dedupSummary struct {
+	logger *Logger
+	std    io.Writer
+	level  int
+	count  int
+}
+
+// SetDeduplication enables deduplication of the identical logging messages with time <window>.
+// If the same formatted message was logged with the same level within <window>, subsequent identical messages are
+// suppressed, and a counter of suppressed messages is printed when a new distinct message arrives
+// or the window expires.
+//
+// The <window> not greater than 0 disables the deduplication feature.
+func (l *Logger) SetDeduplication(window time.Duration) {
+	if window <= 0 {
+		l.config.deduplicator = nil
+		return
+	}
+	l.config.deduplicator = &logDeduplicator{window: window}
+}
+
+// deduplicated checks and returns whether the logging <values> of <level> is suppressed
+// as a duplicated message. It also prints the counter of previous suppressed message if necessary.
+func (l *Logger) deduplicated(std io.Writer, level int, values ...interface{}) bool {
+	d := l.config.deduplicator
+	if d == nil {
+		return false
+	}
+	var (
+		now        = time.Now()
+		message    = valuesToString(values...)
+		summary    *dedupSummary
+		suppressed = false
+	)
+	d.mu.Lock()
+	if message == d.message && level == d.level && now.Sub(d.start) < d.window {
+		d.suppressed++
+		d.logger, d.std = l, std
+		if d.timer == nil {
+			d.timer = gtimer.AddOnce(d.window-now.Sub(d.start), d.flushTimely)
+		}
+		suppressed = true
+	} else {
+		summary = d.reset()
+		d.message = message
+		d.level = level
+		d.start = now
+	}
+	d.mu.Unlock()
+	summary.print()
+	return suppressed
+}
+
+// flushTimely prints the counter of suppressed message when the window expires.
+func (d *logDeduplicator) flushTimely() {
+	d.mu.Lock()
+	d.timer = nil
+	summary := d.reset()
+	d.mu.Unlock()
+	summary.print()
+}
+
+// reset clears the suppressed counter and returns its summary.
+// It returns nil if there's no message suppressed.
+// Note that it should be called within the lock.
+func (d *logDeduplicator) reset() *dedupSummary {
+	if d.timer != nil {
+		d.timer.Close()
+		d.timer = nil
+	}
+	if d.suppressed == 0 {
+		return nil
+	}
+	summary := &dedupSummary{
+		logger: d.logger,
+		std:    d.std,
+		level:  d.level,
+		count:  d.suppressed,
+	}
+	d.suppressed = 0
+	d.logger, d.std = nil, nil
+	return summary
+}
+
+// print outputs the summary message, it does nothing if <s> is nil.
+func (s *dedupSummary) print() {
+	if s == nil {
+		return
+	}
+	message := fmt.Sprintf("last message repeated %d times", s.count)
+	if err := s.logger.output(s.std, s.level, message); err != nil {
+		intlog.Error(err)
+	}
+}
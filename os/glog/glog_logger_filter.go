@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ichunt2019/gf/debug/gdebug"
+)
+
+// packageLevelFilter is the logging level for callers under specified file path prefix.
+type packageLevelFilter struct {
+	prefix string // Caller file path prefix.
+	level  int    // Logging level for the callers.
+}
+
+// SetPackageLevelFilter sets the logging <level> for the logging content originating from files
+// under <pkgPrefix>, which is matched against the caller file paths, eg: github.com/foo/bar.
+// The longest matched prefix takes effect, and the callers matching no prefix use the logger level.
+//
+// It is commonly used to suppress the noisy logging of third-party packages, for example:
+// SetPackageLevelFilter("github.com/foo/bar", LEVEL_PROD).
+func (l *Logger) SetPackageLevelFilter(pkgPrefix string, level int) {
+	// It creates a new slice for copy-on-write,
+	// as the filters might be shared with other loggers from Clone.
+	filters := make([]packageLevelFilter, 0, len(l.config.packageLevelFilters)+1)
+	for _, v := range l.config.packageLevelFilters {
+		if v.prefix != pkgPrefix {
+			filters = append(filters, v)
+		}
+	}
+	filters = append(filters, packageLevelFilter{
+		prefix: pkgPrefix,
+		level:  level,
+	})
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].prefix < filters[j].prefix
+	})
+	l.config.packageLevelFilters = filters
+}
+
+// RemovePackageLevelFilter removes the logging level filter for <pkgPrefix>.
+func (l *Logger) RemovePackageLevelFilter(pkgPrefix string) {
+	filters := make([]packageLevelFilter, 0, len(l.config.packageLevelFilters))
+	for _, v := range l.config.packageLevelFilters {
+		if v.prefix != pkgPrefix {
+			filters = append(filters, v)
+		}
+	}
+	l.config.packageLevelFilters = filters
+}
+
+// getCallerLevel returns the logging level for the caller of current logging.
+// The package level filters are matched against both the caller file path and the caller package path.
+func (l *Logger) getCallerLevel() int {
+	if len(l.config.packageLevelFilters) == 0 {
+		return l.config.Level
+	}
+	callerFnName, path, _ := gdebug.CallerWithFilter(pathFilterKey, l.config.StSkip)
+	if level, ok := searchPackageLevel(l.config.packageLevelFilters, path); ok {
+		return level
+	}
+	// Eg: github.com/foo/bar.(*Bar).Print
+	if level, ok := searchPackageLevel(l.config.packageLevelFilters, callerFnName); ok {
+		return level
+	}
+	return l.config.Level
+}
+
+// searchPackageLevel searches the logging level of the longest prefix matching <path>
+// using binary search in the sorted <filters>.
+func searchPackageLevel(filters []packageLevelFilter, path string) (level int, ok bool) {
+	for len(filters) > 0 {
+		// The greatest prefix that is not greater than <path>.
+		index := sort.Search(len(filters), func(i int) bool {
+			return filters[i].prefix > path
+		})
+		if index == 0 {
+			break
+		}
+		filter := filters[index-1]
+		if strings.HasPrefix(path, filter.prefix) {
+			return filter.level, true
+		}
+		// Any other prefix of <path> which is less than <filter.prefix>,
+		// should also be prefix of their common part.
+		path = path[:commonPrefixLength(path, filter.prefix)]
+		filters = filters[:index-1]
+	}
+	return 0, false
+}
+
+// commonPrefixLength returns the length of the common prefix of <s1> and <s2>.
+func commonPrefixLength(s1, s2 string) int {
+	n := 0
+	for n < len(s1) && n < len(s2) && s1[n] == s2[n] {
+		n++
+	}
+	return n
+}
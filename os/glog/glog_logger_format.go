@@ -0,0 +1,139 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ichunt2019/gf/debug/gdebug"
+	"github.com/ichunt2019/gf/internal/json"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogFormat is the output format of logging content.
+type LogFormat string
+
+const (
+	FormatText LogFormat = "text" // Free-form text output, which is the default format.
+	FormatJSON LogFormat = "json" // Single-line JSON object output for each logging entry.
+)
+
+const (
+	jsonTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// SetFormat sets the output format for logging content, eg: FormatText, FormatJSON.
+func (l *Logger) SetFormat(format LogFormat) {
+	l.config.Format = format
+}
+
+// GetFormat returns the output format for logging content.
+func (l *Logger) GetFormat() LogFormat {
+	if l.config.Format == "" {
+		return FormatText
+	}
+	return l.config.Format
+}
+
+// WithFields returns a new logger bound to custom <fields>, which are attached to every logging entry.
+// The <fields> are merged with the fields of current logger, and the latter ones take priority.
+//
+// Different from chaining functions, the returned logger can be stored and reused,
+// which does not change the current logger.
+func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	logger := l.derive()
+	logger.config.Fields = make(map[string]interface{}, len(l.config.Fields)+len(fields))
+	for k, v := range l.config.Fields {
+		logger.config.Fields[k] = v
+	}
+	for k, v := range fields {
+		logger.config.Fields[k] = v
+	}
+	return logger
+}
+
+// WithRequestId returns a new logger bound to request id <id>, which prefixes every logging
+// content with "[id]". It works without context.Context, which is useful in goroutines that
+// do not carry one. The returned logger inherits all settings from current logger.
+func (l *Logger) WithRequestId(id string) *Logger {
+	logger := l.derive()
+	logger.config.requestId = id
+	return logger
+}
+
+// sortedFieldKeys returns the keys of custom fields in ascending order.
+func (l *Logger) sortedFieldKeys() []string {
+	keys := make([]string, 0, len(l.config.Fields))
+	for k := range l.config.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonContent formats the logging content of <values> as a single-line JSON object.
+// The built-in fields are in order of: time, level, file, line, func, prefix, traceId,
+// registered context fields, message, and then the context values and custom fields in ascending order.
+func (l *Logger) jsonContent(now time.Time, level int, values ...interface{}) *bytes.Buffer {
+	var (
+		buffer                   = bytes.NewBuffer(nil)
+		callerFnName, path, line = gdebug.CallerWithFilter(pathFilterKey, l.config.StSkip)
+	)
+	buffer.WriteByte('{')
+	writeJsonField(buffer, "time", now.Format(jsonTimeFormat))
+	writeJsonField(buffer, "level", l.config.LevelPrefixes[level])
+	writeJsonField(buffer, "file", path)
+	writeJsonField(buffer, "line", line)
+	writeJsonField(buffer, "func", callerFnName)
+	if len(l.config.Prefix) > 0 {
+		writeJsonField(buffer, "prefix", l.config.Prefix)
+	}
+	if l.ctx != nil {
+		if traceId := trace.SpanContextFromContext(l.ctx).TraceID; traceId.IsValid() {
+			writeJsonField(buffer, "traceId", traceId.String())
+		}
+	}
+	if len(l.config.requestId) > 0 {
+		writeJsonField(buffer, "requestId", l.config.requestId)
+	}
+	ctxFieldNames, ctxFieldValues := l.contextFields()
+	for i, name := range ctxFieldNames {
+		writeJsonField(buffer, name, ctxFieldValues[i])
+	}
+	writeJsonField(buffer, "message", valuesToString(values...))
+	if l.ctx != nil {
+		for _, key := range l.config.CtxKeys {
+			if v := l.ctx.Value(key); v != nil {
+				writeJsonField(buffer, fmt.Sprintf("%v", key), v)
+			}
+		}
+	}
+	for _, key := range l.sortedFieldKeys() {
+		writeJsonField(buffer, key, l.config.Fields[key])
+	}
+	buffer.WriteString("}\n")
+	return buffer
+}
+
+// writeJsonField writes the <key> and <value> pair to JSON object <buffer>.
+// The <value> is written as string if it cannot be encoded as JSON.
+func writeJsonField(buffer *bytes.Buffer, key string, value interface{}) {
+	if buffer.Len() > 1 {
+		buffer.WriteByte(',')
+	}
+	keyBytes, _ := json.Marshal(key)
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		valueBytes, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+	buffer.Write(keyBytes)
+	buffer.WriteByte(':')
+	buffer.Write(valueBytes)
+}
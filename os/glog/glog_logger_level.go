@@ -9,6 +9,7 @@ package glog
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -28,6 +29,9 @@ const (
 	LEVEL_FATA             // 1024
 )
 
+// levelNone is the level for logging content without level, eg: Print/Printf.
+const levelNone = 0
+
 // defaultLevelPrefixes defines the default level and its mapping prefix string.
 var defaultLevelPrefixes = map[int]string{
 	LEVEL_DEBU: "DEBU",
@@ -104,3 +108,54 @@ func (l *Logger) getLevelPrefixWithBrackets(level int) string {
 	}
 	return ""
 }
+
+// SetLevelWriter sets the customized <writer> for specified logging <level>,
+// which redirects the logging content of <level> to <writer> instead of the default writer.
+// The parameter <level> can be combination of levels, eg: LEVEL_ERRO | LEVEL_CRIT.
+//
+// Multiple writers set for the same level are all written like io.MultiWriter.
+// Levels without specified writer still use the default writer.
+func (l *Logger) SetLevelWriter(level int, writer io.Writer) {
+	if writer == nil {
+		return
+	}
+	// It creates a new map for copy-on-write,
+	// as the writers might be shared with other loggers from Clone.
+	writers := make(map[int]io.Writer, len(l.config.LevelWriters)+1)
+	for k, v := range l.config.LevelWriters {
+		writers[k] = v
+	}
+	for _, v := range levelsOf(level) {
+		if w, ok := writers[v]; ok {
+			writers[v] = io.MultiWriter(w, writer)
+		} else {
+			writers[v] = writer
+		}
+	}
+	l.config.LevelWriters = writers
+}
+
+// GetLevelWriter returns the customized writer for specified logging <level>.
+// It returns nil if no writer set for <level>.
+func (l *Logger) GetLevelWriter(level int) io.Writer {
+	return l.getLevelWriter(level)
+}
+
+// getLevelWriter returns the customized writer for specified logging <level>.
+func (l *Logger) getLevelWriter(level int) io.Writer {
+	if level == levelNone || len(l.config.LevelWriters) == 0 {
+		return nil
+	}
+	return l.config.LevelWriters[level]
+}
+
+// levelsOf splits combination <level> into single levels.
+func levelsOf(level int) []int {
+	levels := make([]int, 0)
+	for v := range defaultLevelPrefixes {
+		if level&v > 0 {
+			levels = append(levels, v)
+		}
+	}
+	return levels
+}
@@ -7,8 +7,10 @@
 package glog
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ichunt2019/gf/container/garray"
+	"github.com/ichunt2019/gf/container/gtype"
 	"github.com/ichunt2019/gf/encoding/gcompress"
 	"github.com/ichunt2019/gf/internal/intlog"
 	"github.com/ichunt2019/gf/os/gfile"
@@ -16,9 +18,18 @@ import (
 	"github.com/ichunt2019/gf/os/gtime"
 	"github.com/ichunt2019/gf/os/gtimer"
 	"github.com/ichunt2019/gf/text/gregex"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
+var (
+	// rotateBackupSeq is the monotonic sequence number for "{seq}" token of RotateBackupNameTemplate.
+	rotateBackupSeq = gtype.NewUint64()
+)
+
 // rotateFileBySize rotates the current logging file according to the
 // configured rotation size.
 func (l *Logger) rotateFileBySize(now time.Time) {
@@ -32,12 +43,18 @@ func (l *Logger) rotateFileBySize(now time.Time) {
 }
 
 // doRotateFile rotates the given logging file.
-func (l *Logger) doRotateFile(filePath string) error {
+// It resets the line number after successful rotation if ResetLineNumbers is enabled.
+func (l *Logger) doRotateFile(filePath string) (err error) {
 	memoryLockKey := "glog.doRotateFile:" + filePath
 	if !gmlock.TryLock(memoryLockKey) {
 		return nil
 	}
 	defer gmlock.Unlock(memoryLockKey)
+	defer func() {
+		if err == nil {
+			l.resetLineNumber()
+		}
+	}()
 
 	// No backups, it then just removes the current logging file.
 	if l.config.RotateBackupLimit == 0 {
@@ -54,6 +71,17 @@ func (l *Logger) doRotateFile(filePath string) error {
 		fileExtName = gfile.ExtName(filePath)
 		newFilePath = ""
 	)
+	// Custom backup file name from template.
+	if l.config.RotateBackupNameTemplate != "" {
+		newFilePath = gfile.Join(dirPath, formatBackupName(l.config.RotateBackupNameTemplate, time.Now()))
+		if gfile.Exists(newFilePath) {
+			return fmt.Errorf(
+				`rotation backup file already exists: %s, "{seq}" can be used in RotateBackupNameTemplate to avoid conflicts`,
+				newFilePath,
+			)
+		}
+		return gfile.Rename(filePath, newFilePath)
+	}
 	// Rename the logging file by adding extra datetime information to microseconds, like:
 	// access.log          -> access.20200326101301899002.log
 	// access.20200326.log -> access.20200326.20200326101301899002.log
@@ -88,6 +116,83 @@ func (l *Logger) doRotateFile(filePath string) error {
 	return nil
 }
 
+// Rotate rotates the current logging file immediately, and then synchronously runs the same
+// checks as the timely rotation, including the expiration, compression and backups checks.
+// It is commonly used after log shipping, which needs the rotation without waiting for RotateCheckInterval.
+//
+// It is safe to be called concurrently with in-flight writes.
+func (l *Logger) Rotate() error {
+	if l.config.Path == "" {
+		return errors.New("logging path is empty")
+	}
+	var (
+		logFilePath   = l.getFilePath(time.Now())
+		memoryLockKey = "glog.printToFile:" + logFilePath
+	)
+	// It uses the same memory lock with file writing.
+	gmlock.Lock(memoryLockKey)
+	if gfile.Exists(logFilePath) && gfile.Size(logFilePath) > 0 {
+		if err := l.doRotateFile(logFilePath); err != nil {
+			gmlock.Unlock(memoryLockKey)
+			return err
+		}
+	}
+	gmlock.Unlock(memoryLockKey)
+	l.rotateChecks()
+	return nil
+}
+
+// RotateCalendar is the calendar boundary for logging file rotation.
+type RotateCalendar int
+
+const (
+	RotateDaily   RotateCalendar = iota + 1 // Rotate at midnight every day.
+	RotateWeekly                            // Rotate at midnight on every Monday.
+	RotateMonthly                           // Rotate at midnight on the first day of every month.
+)
+
+// nextCalendarBoundary returns the next calendar boundary of <calendar> after time <t>,
+// which is the midnight in the location of <t>.
+func nextCalendarBoundary(t time.Time, calendar RotateCalendar) time.Time {
+	var (
+		year, month, day = t.Date()
+		midnight         = time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	)
+	switch calendar {
+	case RotateDaily:
+		return midnight.AddDate(0, 0, 1)
+	case RotateWeekly:
+		// Days to next Monday, which is in 1 to 7 days.
+		days := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return midnight.AddDate(0, 0, days)
+	case RotateMonthly:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+// rotateOnCalendarTimely rotates the logging file at the next calendar boundary,
+// and then resets the timer to the boundary after that. It respects the timezone of time.Local.
+func (l *Logger) rotateOnCalendarTimely() {
+	var (
+		now  = time.Now().In(time.Local)
+		next = nextCalendarBoundary(now, l.config.RotateOnCalendar)
+	)
+	if next.IsZero() {
+		return
+	}
+	intlog.Printf("logger calendar rotation at: %s", next.String())
+	time.AfterFunc(next.Sub(now), func() {
+		if err := l.Rotate(); err != nil {
+			intlog.Error(err)
+		}
+		l.rotateOnCalendarTimely()
+	})
+}
+
 // rotateChecksTimely timely checks the backups expiration and the compression.
 func (l *Logger) rotateChecksTimely() {
 	defer gtimer.AddOnce(l.config.RotateCheckInterval, l.rotateChecksTimely)
@@ -99,7 +204,12 @@ func (l *Logger) rotateChecksTimely() {
 		)
 		return
 	}
+	l.rotateChecks()
+}
 
+// rotateChecks checks the rotation of expired files, the compression of rotated files,
+// and the backups count limitation and expiration.
+func (l *Logger) rotateChecks() {
 	// It here uses memory lock to guarantee the concurrent safety.
 	memoryLockKey := "glog.rotateChecksTimely:" + l.config.Path
 	if !gmlock.TryLock(memoryLockKey) {
@@ -157,7 +267,7 @@ func (l *Logger) rotateChecksTimely() {
 			}
 			// Eg:
 			// access.20200326101301899002.log
-			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) {
+			if l.isBackupFile(file) {
 				needCompressFileArray.Append(file)
 			}
 		}
@@ -188,7 +298,12 @@ func (l *Logger) rotateChecksTimely() {
 	)
 	if l.config.RotateBackupLimit > 0 || l.config.RotateBackupExpire > 0 {
 		for _, file := range files {
-			originalLoggingFilePath, _ = gregex.ReplaceString(`\.\d{20}`, "", file)
+			if l.config.RotateBackupNameTemplate != "" {
+				// All backup files from template are in the same group.
+				originalLoggingFilePath = gfile.Join(gfile.Dir(file), l.config.RotateBackupNameTemplate)
+			} else {
+				originalLoggingFilePath, _ = gregex.ReplaceString(`\.\d{20}`, "", file)
+			}
 			if backupFilesMap[originalLoggingFilePath] == nil {
 				backupFilesMap[originalLoggingFilePath] = garray.NewSortedArray(func(a, b interface{}) int {
 					// Sorted by rotated/backup file mtime.
@@ -203,7 +318,7 @@ func (l *Logger) rotateChecksTimely() {
 				})
 			}
 			// Check if this file a rotated/backup file.
-			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) {
+			if l.isBackupFile(file) {
 				backupFilesMap[originalLoggingFilePath].Add(file)
 			}
 		}
@@ -246,3 +361,32 @@ func (l *Logger) rotateChecksTimely() {
 		}
 	}
 }
+
+// isBackupFile checks and returns whether <file> is a rotated backup file,
+// which is not compressed yet.
+func (l *Logger) isBackupFile(file string) bool {
+	if l.config.RotateBackupNameTemplate != "" {
+		return gregex.IsMatchString(backupNamePattern(l.config.RotateBackupNameTemplate), gfile.Basename(file))
+	}
+	return gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file))
+}
+
+// formatBackupName formats and returns the backup file name from <template> with time <t>.
+// The <template> is in time.Format layout, and supports "{pid}" and "{seq}" tokens.
+func formatBackupName(template string, t time.Time) string {
+	name := t.Format(template)
+	name = strings.Replace(name, "{pid}", strconv.Itoa(os.Getpid()), -1)
+	name = strings.Replace(name, "{seq}", strconv.FormatUint(rotateBackupSeq.Add(1), 10), -1)
+	return name
+}
+
+// backupNamePattern converts backup name <template> to regular expression pattern,
+// which matches the backup file names formatted from <template>.
+// Note that only the numeric layout elements of time.Format are supported for matching.
+func backupNamePattern(template string) string {
+	pattern := regexp.QuoteMeta(template)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{pid}"), `\d+`, -1)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{seq}"), `\d+`, -1)
+	pattern, _ = gregex.ReplaceString(`[0-9]+`, `\d+`, pattern)
+	return "^" + pattern + "$"
+}
@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"github.com/ichunt2019/gf/container/gtype"
+)
+
+// logSampler samples the logging calls in specified rate.
+type logSampler struct {
+	rate    float64       // Sampling rate between 0.0 and 1.0.
+	counter *gtype.Uint64 // Counter of logging calls.
+}
+
+// newLogSampler creates and returns a sampler with <rate>.
+func newLogSampler(rate float64) *logSampler {
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	return &logSampler{
+		rate:    rate,
+		counter: gtype.NewUint64(),
+	}
+}
+
+// allow checks and returns whether current logging call passes the sampling.
+// It uses atomic counter instead of random number and lock, which passes the N-th calling
+// if the integer part of N*rate increases, so exactly <rate> fraction of calls pass.
+func (s *logSampler) allow() bool {
+	if s.rate >= 1 {
+		return true
+	}
+	if s.rate <= 0 {
+		return false
+	}
+	n := s.counter.Add(1)
+	return uint64(float64(n)*s.rate) != uint64(float64(n-1)*s.rate)
+}
+
+// SetSamplingRate sets the sampling <rate> between 0.0 and 1.0 for all logging levels.
+// Only approximately <rate> fraction of logging calls are output, and the rest are dropped silently.
+// The rate 1.0 disables the sampling feature.
+func (l *Logger) SetSamplingRate(rate float64) {
+	l.config.sampler = newLogSampler(rate)
+}
+
+// SetLevelSamplingRate sets the sampling <rate> between 0.0 and 1.0 for specified logging <level>,
+// which overrides the rate set by SetSamplingRate for <level>.
+// The parameter <level> can be combination of levels, eg: LEVEL_DEBU | LEVEL_INFO.
+func (l *Logger) SetLevelSamplingRate(level int, rate float64) {
+	// It creates a new map for copy-on-write,
+	// as the samplers might be shared with other loggers from Clone.
+	samplers := make(map[int]*logSampler, len(l.config.levelSamplers)+1)
+	for k, v := range l.config.levelSamplers {
+		samplers[k] = v
+	}
+	for _, v := range levelsOf(level) {
+		samplers[v] = newLogSampler(rate)
+	}
+	l.config.levelSamplers = samplers
+}
+
+// sampled checks and returns whether the logging content of <level> passes the sampling.
+func (l *Logger) sampled(level int) bool {
+	if sampler, ok := l.config.levelSamplers[level]; ok {
+		return sampler.allow()
+	}
+	if l.config.sampler != nil {
+		return l.config.sampler.allow()
+	}
+	return true
+}
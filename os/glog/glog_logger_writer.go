@@ -6,11 +6,141 @@
 
 package glog
 
-import "bytes"
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/ichunt2019/gf/internal/intlog"
+)
+
+// fanoutWriter writes the content to multiple writers, which is concurrent-safe.
+type fanoutWriter struct {
+	mu      sync.RWMutex
+	writers []io.Writer
+}
 
 // Write implements the io.Writer interface.
-// It just prints the content using Print.
+// It just prints the content using Print, and returns the aggregated error of all writers.
 func (l *Logger) Write(p []byte) (n int, err error) {
-	l.Header(false).Print(string(bytes.TrimRight(p, "\r\n")))
-	return len(p), nil
+	err = l.Header(false).printStd(levelNone, string(bytes.TrimRight(p, "\r\n")))
+	return len(p), err
+}
+
+// AddWriter adds <writer> to the logger, which writes logging content to <writer> in addition to
+// the default output, eg: writing to stdout and a remote syslog socket simultaneously.
+//
+// The writing continues to remaining writers even if one returns error,
+// and the errors of all writers are aggregated and returned by Write, or passed to the handler
+// set by SetWriterErrorHandler for other logging functions.
+//
+// The writers are not shared with the loggers from Clone, so adding writers to a chaining
+// logger does not affect current logger.
+func (l *Logger) AddWriter(writer io.Writer) {
+	if writer == nil {
+		return
+	}
+	l.config.writers.add(writer)
+}
+
+// RemoveWriter removes <writer> previously added by AddWriter from the logger.
+func (l *Logger) RemoveWriter(writer io.Writer) {
+	l.config.writers.remove(writer)
+}
+
+// GetWriters returns all writers added by AddWriter.
+func (l *Logger) GetWriters() []io.Writer {
+	return l.config.writers.all()
+}
+
+// SetWriterErrorHandler sets the <handler> for the aggregated error of all writers,
+// which is called after each failed writing of logging content, in both sync and async mode.
+// It is useful for the logging functions like Info and Error, which do not return the writing error.
+func (l *Logger) SetWriterErrorHandler(handler func(err error)) {
+	l.config.writerErrorHandler = handler
+}
+
+// SetOutput sets <writer> as the output of the logger, which receives the formatted logging content
+// directly instead of stdout, eg: the testing.T.Log adapter or a third-party logger.
+// It bypasses all the file and rotation logic. If SetPath is also set, both the logging file
+// and <writer> receive the logging content.
+//
+// It resets the output to stdout if <writer> is nil.
+func (l *Logger) SetOutput(writer io.Writer) {
+	l.config.output = writer
+}
+
+// GetOutput returns the writer previously set by SetOutput.
+func (l *Logger) GetOutput() io.Writer {
+	return l.config.output
+}
+
+// add adds <writer> to the fan-out writers.
+func (w *fanoutWriter) add(writer io.Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// Copy-on-write, so writing does not need to hold the lock during the whole writing.
+	writers := make([]io.Writer, len(w.writers), len(w.writers)+1)
+	copy(writers, w.writers)
+	w.writers = append(writers, writer)
+}
+
+// remove removes <writer> from the fan-out writers.
+func (w *fanoutWriter) remove(writer io.Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	writers := make([]io.Writer, 0, len(w.writers))
+	for _, v := range w.writers {
+		if v != writer {
+			writers = append(writers, v)
+		}
+	}
+	w.writers = writers
+}
+
+// clone returns a new fan-out writer with the same writers as <w>,
+// which is safe to share the writers slice as it's copy-on-write.
+// It returns an empty fan-out writer if <w> is nil.
+func (w *fanoutWriter) clone() *fanoutWriter {
+	if w == nil {
+		return &fanoutWriter{}
+	}
+	return &fanoutWriter{writers: w.all()}
+}
+
+// all returns all the fan-out writers.
+func (w *fanoutWriter) all() []io.Writer {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.writers
+}
+
+// Write writes <p> to all writers, it continues writing to remaining writers even if one returns error.
+func (w *fanoutWriter) Write(p []byte) (n int, err error) {
+	var errs []error
+	for _, writer := range w.all() {
+		if _, err = writer.Write(p); err != nil {
+			intlog.Error(err)
+			errs = append(errs, err)
+		}
+	}
+	return len(p), aggregateErrors(errs)
+}
+
+// aggregateErrors aggregates <errs> into one error.
+// It returns nil if <errs> is empty, or the error itself if there's only one error.
+func aggregateErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return errors.New(strings.Join(messages, "; "))
 }
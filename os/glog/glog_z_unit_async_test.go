@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+// slowWriter is a concurrent safe writer which sleeps before each writing.
+type slowWriter struct {
+	concurrentBuffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.concurrentBuffer.Write(p)
+}
+
+func Test_AsyncBuffer(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		writer := &slowWriter{delay: 10 * time.Millisecond}
+		l := NewWithWriter(writer)
+		l.SetAsyncBuffer(100)
+		defer l.SetAsyncBuffer(0)
+
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			_, err := l.Write([]byte("async\n"))
+			t.Assert(err, nil)
+			l.Info("buffered")
+		}
+		t.Assert(time.Since(start) < 100*time.Millisecond, true)
+		t.Assert(l.Flush(), nil)
+		t.Assert(gstr.Count(writer.String(), "buffered"), 10)
+		t.Assert(gstr.Count(writer.String(), "async"), 10)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     = sync.WaitGroup{}
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(writer)
+		)
+		l.SetAsyncBuffer(10)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Info("concurrent")
+			}()
+		}
+		wg.Wait()
+		// Disabling also flushes the buffered content.
+		l.SetAsyncBuffer(0)
+		t.Assert(gstr.Count(writer.String(), "concurrent"), 100)
+		t.Assert(l.Flush(), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		l := NewWithWriter(&failedWriter{message: "write failed"})
+		l.SetAsyncBuffer(10)
+		defer l.SetAsyncBuffer(0)
+		l.Info("failed")
+		t.AssertNE(l.Flush(), nil)
+		t.Assert(l.Flush(), nil)
+	})
+	// The cloned logger does not close or replace the buffered writer of the parent logger.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(writer)
+		)
+		l.SetAsyncBuffer(10)
+		defer l.SetAsyncBuffer(0)
+
+		c := l.Clone()
+		c.Info("shared")
+		c.SetAsyncBuffer(0)
+		t.Assert(gstr.Count(writer.String(), "shared"), 1)
+		c.Info("sync")
+		t.Assert(gstr.Count(writer.String(), "sync"), 1)
+
+		c.SetAsyncBuffer(5)
+		t.Assert(c.config.asyncWriter != l.config.asyncWriter, true)
+		c.Info("own")
+		c.SetAsyncBuffer(0)
+		t.Assert(gstr.Count(writer.String(), "own"), 1)
+
+		// The buffered writer of the parent logger still works.
+		l.Info("parent")
+		t.Assert(l.Flush(), nil)
+		t.Assert(gstr.Count(writer.String(), "parent"), 1)
+	})
+	// The derived logger writes synchronously after the parent logger closes the shared buffered writer.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(writer)
+		)
+		l.SetAsyncBuffer(10)
+		r := l.WithRequestId("x")
+		c := l.Clone()
+		r.Info("before closing")
+		l.SetAsyncBuffer(0)
+		t.Assert(gstr.Count(writer.String(), "before closing"), 1)
+		r.Info("derived after closing")
+		c.Info("cloned after closing")
+		t.Assert(gstr.Count(writer.String(), "[x]"), 2)
+		t.Assert(gstr.Count(writer.String(), "derived after closing"), 1)
+		t.Assert(gstr.Count(writer.String(), "cloned after closing"), 1)
+		t.Assert(r.Flush(), nil)
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_NextCalendarBoundary(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		location := time.FixedZone("UTC+8", 8*3600)
+		// Wednesday.
+		now := time.Date(2021, 3, 31, 15, 4, 5, 0, location)
+		t.Assert(nextCalendarBoundary(now, RotateDaily), time.Date(2021, 4, 1, 0, 0, 0, 0, location))
+		t.Assert(nextCalendarBoundary(now, RotateWeekly), time.Date(2021, 4, 5, 0, 0, 0, 0, location))
+		t.Assert(nextCalendarBoundary(now, RotateMonthly), time.Date(2021, 4, 1, 0, 0, 0, 0, location))
+		t.Assert(nextCalendarBoundary(now, 0).IsZero(), true)
+
+		// Monday midnight, the boundary is the next Monday.
+		now = time.Date(2021, 4, 5, 0, 0, 0, 0, location)
+		t.Assert(nextCalendarBoundary(now, RotateDaily), time.Date(2021, 4, 6, 0, 0, 0, 0, location))
+		t.Assert(nextCalendarBoundary(now, RotateWeekly), time.Date(2021, 4, 12, 0, 0, 0, 0, location))
+
+		// Sunday.
+		now = time.Date(2021, 4, 11, 23, 59, 59, 0, location)
+		t.Assert(nextCalendarBoundary(now, RotateWeekly), time.Date(2021, 4, 12, 0, 0, 0, 0, location))
+
+		// End of year.
+		now = time.Date(2021, 12, 31, 12, 0, 0, 0, location)
+		t.Assert(nextCalendarBoundary(now, RotateDaily), time.Date(2022, 1, 1, 0, 0, 0, 0, location))
+		t.Assert(nextCalendarBoundary(now, RotateMonthly), time.Date(2022, 1, 1, 0, 0, 0, 0, location))
+	})
+	// Timezone of the given time is respected.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			now  = time.Date(2021, 3, 31, 10, 0, 0, 0, time.UTC)
+			next = nextCalendarBoundary(now.In(time.FixedZone("UTC+8", 8*3600)), RotateDaily)
+		)
+		t.Assert(next.Sub(now), 6*time.Hour)
+	})
+}
+
+func Test_RotateOnCalendar_Config(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := New()
+		err := l.SetConfigWithMap(map[string]interface{}{
+			"RotateOnCalendar": RotateWeekly,
+		})
+		t.Assert(err, nil)
+		t.Assert(l.config.RotateOnCalendar, RotateWeekly)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_InlineCompress(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l    = glog.New()
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = "compress.log"
+		)
+		t.Assert(l.SetPath(path), nil)
+		defer gfile.Remove(path)
+		l.SetFile(file)
+		l.SetStdoutPrint(false)
+		l.SetInlineCompress(true)
+		for i := 0; i < 100; i++ {
+			l.Infof("request %d handled, status 200", i)
+		}
+		content := gfile.GetContents(gfile.Join(path, file))
+		t.Assert(gstr.Contains(content, "handled"), false)
+
+		data, err := ioutil.ReadAll(glog.ReadCompressed(gfile.Join(path, file)))
+		t.Assert(err, nil)
+		lines := gstr.SplitAndTrim(string(data), "\n")
+		t.Assert(len(lines), 100)
+		t.Assert(gstr.Contains(lines[0], "[INFO] request 0 handled, status 200"), true)
+		t.Assert(gstr.Contains(lines[99], "[INFO] request 99 handled, status 200"), true)
+		t.Assert(len(content) < len(data), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := ioutil.ReadAll(glog.ReadCompressed("/not-exist/compress.log"))
+		t.AssertNE(err, nil)
+	})
+	// Corrupted file.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l    = glog.New()
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = gfile.Join(path, "compress.log")
+		)
+		t.Assert(l.SetPath(path), nil)
+		defer gfile.Remove(path)
+		l.SetFile("compress.log")
+		l.SetStdoutPrint(false)
+		l.SetInlineCompress(true)
+		l.Info("request handled")
+		content := gfile.GetBytes(file)
+
+		// Huge record length exceeding the max record size.
+		header := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(header, 1<<62)
+		t.Assert(gfile.PutBytes(file, append(append(content, header[:n]...), "invalid"...)), nil)
+		data, err := ioutil.ReadAll(glog.ReadCompressed(file))
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), "invalid record length"), true)
+		t.Assert(gstr.Contains(string(data), "request handled"), true)
+
+		// Record length exceeding the remaining file size.
+		n = binary.PutUvarint(header, 1024)
+		t.Assert(gfile.PutBytes(file, append(append(content, header[:n]...), "invalid"...)), nil)
+		data, err = ioutil.ReadAll(glog.ReadCompressed(file))
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), "invalid record length"), true)
+		t.Assert(gstr.Contains(string(data), "request handled"), true)
+
+		// Truncated record.
+		t.Assert(gfile.PutBytes(file, content[:len(content)-1]), nil)
+		_, err = ioutil.ReadAll(glog.ReadCompressed(file))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Benchmark_InlineCompress(b *testing.B) {
+	var (
+		l    = glog.New()
+		path = gfile.TempDir(gtime.TimestampNanoStr())
+		file = "compress.log"
+	)
+	if err := l.SetPath(path); err != nil {
+		b.Fatal(err)
+	}
+	defer gfile.Remove(path)
+	l.SetFile(file)
+	l.SetStdoutPrint(false)
+	l.SetInlineCompress(true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Infof("GET /api/user/%d HTTP/1.1, status %d, cost %dms", i, 200, i%100)
+	}
+	b.StopTimer()
+	data, _ := ioutil.ReadAll(glog.ReadCompressed(gfile.Join(path, file)))
+	b.ReportMetric(float64(len(data))/float64(gfile.Size(gfile.Join(path, file))), "ratio")
+}
+
+func Benchmark_InlineCompress_Disabled(b *testing.B) {
+	var (
+		l    = glog.New()
+		path = gfile.TempDir(gtime.TimestampNanoStr())
+	)
+	if err := l.SetPath(path); err != nil {
+		b.Fatal(err)
+	}
+	defer gfile.Remove(path)
+	l.SetFile("plain.log")
+	l.SetStdoutPrint(false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Infof("GET /api/user/%d HTTP/1.1, status %d, cost %dms", i, 200, i%100)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ichunt2019/gf/internal/json"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+type testContextKey string
+
+func Test_WithContext(t *testing.T) {
+	RegisterContextKey(testContextKey("RequestId"), "request-id")
+	RegisterContextKey(testContextKey("TraceId"), "trace-id")
+	defer func() {
+		contextKeysMu.Lock()
+		contextKeys = nil
+		contextKeysMu.Unlock()
+	}()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = NewWithWriter(buffer)
+			ctx    = context.WithValue(context.Background(), testContextKey("RequestId"), "r1")
+		)
+		ctx = context.WithValue(ctx, testContextKey("TraceId"), "t1")
+		cl := l.WithContext(ctx)
+		cl.Info("with context")
+		t.Assert(gstr.Contains(buffer.String(), "{request-id: r1, trace-id: t1} with context"), true)
+
+		// The original logger has no context.
+		buffer.Reset()
+		l.Info("without context")
+		t.Assert(gstr.Contains(buffer.String(), "request-id"), false)
+
+		// Values absent in context are ignored.
+		buffer.Reset()
+		l.WithContext(context.WithValue(context.Background(), testContextKey("TraceId"), "t2")).Info("partial")
+		t.Assert(gstr.Contains(buffer.String(), "{trace-id: t2} partial"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = NewWithWriter(buffer)
+			ctx    = context.WithValue(context.Background(), testContextKey("RequestId"), "r1")
+		)
+		l.SetFormat(FormatJSON)
+		l.WithContext(ctx).Info("json")
+		m := make(map[string]interface{})
+		t.Assert(json.Unmarshal(buffer.Bytes(), &m), nil)
+		t.Assert(m["request-id"], "r1")
+		t.Assert(m["message"], "json")
+	})
+}
+
+func Test_WithRequestId(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = NewWithWriter(buffer)
+		)
+		l.SetLevel(LEVEL_INFO)
+		rl := l.WithRequestId("req-1")
+		rl.Info("request started")
+		rl.Debug("debug message")
+		rl.WithFields(map[string]interface{}{"user": "john"}).Info("with fields")
+		t.Assert(gstr.Contains(buffer.String(), "[INFO] [req-1] request started"), true)
+		t.Assert(gstr.Contains(buffer.String(), "debug message"), false)
+		t.Assert(gstr.Contains(buffer.String(), "[req-1] {user: john} with fields"), true)
+
+		// The original logger has no request id.
+		buffer.Reset()
+		l.Info("no request id")
+		t.Assert(gstr.Contains(buffer.String(), "req-1"), false)
+	})
+	// Settings inherited from parent.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(path)
+		l := New()
+		t.Assert(l.SetPath(path), nil)
+		l.SetFile("request.log")
+		l.SetStdoutPrint(false)
+		l.WithRequestId("req-2").Error("request failed")
+		content := gfile.GetContents(gfile.Join(path, "request.log"))
+		t.Assert(gstr.Contains(content, "[ERRO] [req-2] request failed"), true)
+	})
+	// JSON format.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = NewWithWriter(buffer)
+			m      = make(map[string]interface{})
+		)
+		l.SetFormat(FormatJSON)
+		l.WithRequestId("req-3").Info("json")
+		t.Assert(json.Unmarshal(buffer.Bytes(), &m), nil)
+		t.Assert(m["requestId"], "req-3")
+		t.Assert(m["message"], "json")
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_Deduplication(t *testing.T) {
+	// Flushing counter when new distinct message arrives.
+	gtest.C(t, func(t *gtest.T) {
+		writer := &concurrentBuffer{}
+		l := NewWithWriter(writer)
+		l.SetDeduplication(time.Minute)
+		for i := 0; i < 10; i++ {
+			l.Info("health check failed")
+		}
+		t.Assert(gstr.Count(writer.String(), "health check failed"), 1)
+		t.Assert(gstr.Contains(writer.String(), "repeated"), false)
+
+		l.Info("health check recovered")
+		t.Assert(gstr.Count(writer.String(), "health check failed"), 1)
+		t.Assert(gstr.Count(writer.String(), "[INFO] last message repeated 9 times"), 1)
+		t.Assert(gstr.Count(writer.String(), "health check recovered"), 1)
+		t.Assert(
+			gstr.Pos(writer.String(), "repeated") < gstr.Pos(writer.String(), "recovered"),
+			true,
+		)
+	})
+	// Flushing counter when window expires.
+	gtest.C(t, func(t *gtest.T) {
+		writer := &concurrentBuffer{}
+		l := NewWithWriter(writer)
+		l.SetDeduplication(300 * time.Millisecond)
+		for i := 0; i < 5; i++ {
+			l.Warning("disk full")
+		}
+		t.Assert(gstr.Count(writer.String(), "disk full"), 1)
+		time.Sleep(600 * time.Millisecond)
+		t.Assert(gstr.Count(writer.String(), "[WARN] last message repeated 4 times"), 1)
+
+		l.Warning("disk full")
+		t.Assert(gstr.Count(writer.String(), "disk full"), 2)
+	})
+	// Identical messages of different levels are not suppressed.
+	gtest.C(t, func(t *gtest.T) {
+		writer := &concurrentBuffer{}
+		l := NewWithWriter(writer)
+		l.SetDeduplication(time.Minute)
+		l.Info("connection lost")
+		l.Info("connection lost")
+		l.Error("connection lost")
+		t.Assert(gstr.Count(writer.String(), "connection lost"), 2)
+		t.Assert(gstr.Count(writer.String(), "[INFO] connection lost"), 1)
+		t.Assert(gstr.Count(writer.String(), "[ERRO] connection lost"), 1)
+		t.Assert(gstr.Count(writer.String(), "[INFO] last message repeated 1 times"), 1)
+	})
+	// Disabled.
+	gtest.C(t, func(t *gtest.T) {
+		writer := &concurrentBuffer{}
+		l := NewWithWriter(writer)
+		l.SetDeduplication(time.Minute)
+		l.SetDeduplication(0)
+		for i := 0; i < 5; i++ {
+			l.Info("message")
+		}
+		t.Assert(gstr.Count(writer.String(), "message"), 5)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ichunt2019/gf/debug/gdebug"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_PackageLevelFilter_Search(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := New()
+		l.SetPackageLevelFilter("github.com/foo", LEVEL_ALL)
+		l.SetPackageLevelFilter("github.com/foo/bar", LEVEL_PROD)
+		l.SetPackageLevelFilter("github.com/foo/bar/baz", LEVEL_ERRO)
+		l.SetPackageLevelFilter("github.com/foo/bz", LEVEL_CRIT)
+		l.SetPackageLevelFilter("/home/john/project", LEVEL_INFO)
+
+		filters := l.config.packageLevelFilters
+		search := func(path string) int {
+			level, ok := searchPackageLevel(filters, path)
+			if !ok {
+				return -1
+			}
+			return level
+		}
+		t.Assert(search("github.com/foo/main.go"), LEVEL_ALL)
+		t.Assert(search("github.com/foo/bar/bar.go"), LEVEL_PROD)
+		t.Assert(search("github.com/foo/bar/baz/baz.go"), LEVEL_ERRO)
+		t.Assert(search("github.com/foo/bz/bz.go"), LEVEL_CRIT)
+		t.Assert(search("github.com/foo/c/c.go"), LEVEL_ALL)
+		t.Assert(search("/home/john/project/main.go"), LEVEL_INFO)
+		t.Assert(search("github.com/other/main.go"), -1)
+		t.Assert(search(""), -1)
+
+		// Overwrite and removal.
+		l.SetPackageLevelFilter("github.com/foo/bar", LEVEL_WARN)
+		l.RemovePackageLevelFilter("github.com/foo/bar/baz")
+		filters = l.config.packageLevelFilters
+		t.Assert(len(filters), 4)
+		t.Assert(search("github.com/foo/bar/baz/baz.go"), LEVEL_WARN)
+	})
+}
+
+func Test_PackageLevelFilter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer             = bytes.NewBuffer(nil)
+			l                  = NewWithWriter(buffer)
+			callerFnName, _, _ = gdebug.CallerWithFilter(pathFilterKey)
+		)
+		l.SetLevel(LEVEL_ALL)
+		l.Debug("debug1")
+		t.Assert(gstr.Contains(buffer.String(), "debug1"), true)
+
+		// The caller of the logging is the same as the caller here.
+		l.SetPackageLevelFilter(callerFnName, LEVEL_PROD)
+		l.Debug("debug2")
+		l.Error("error2")
+		t.Assert(gstr.Contains(buffer.String(), "debug2"), false)
+		t.Assert(gstr.Contains(buffer.String(), "error2"), true)
+
+		// Other logger is not affected.
+		l2 := NewWithWriter(buffer)
+		l2.SetLevel(LEVEL_ALL)
+		l2.Debug("debug3")
+		t.Assert(gstr.Contains(buffer.String(), "debug3"), true)
+
+		l.RemovePackageLevelFilter(callerFnName)
+		l.Debug("debug4")
+		t.Assert(gstr.Contains(buffer.String(), "debug4"), true)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ichunt2019/gf/internal/json"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_Format_JSON(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer)
+		t.Assert(l.GetFormat(), FormatText)
+		l.SetFormat(FormatJSON)
+		t.Assert(l.GetFormat(), FormatJSON)
+		l.Error("json", "message")
+
+		content := buffer.String()
+		t.Assert(gstr.Count(content, "\n"), 1)
+		t.Assert(gstr.HasPrefix(content, `{"time":`), true)
+
+		m := make(map[string]interface{})
+		t.Assert(json.Unmarshal([]byte(content), &m), nil)
+		t.Assert(m["level"], "ERRO")
+		t.Assert(gstr.HasPrefix(m["message"].(string), "json message"), true)
+		t.AssertNE(m["file"], "")
+		t.AssertGT(m["line"], 0)
+		t.AssertNE(m["func"], "")
+		t.AssertNE(m["time"], "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer)
+		err := l.SetConfigWithMap(map[string]interface{}{
+			"format": "json",
+		})
+		t.Assert(err, nil)
+		t.Assert(l.GetFormat(), FormatJSON)
+	})
+}
+
+func Test_WithFields(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer)
+		l.SetFormat(FormatJSON)
+		l1 := l.WithFields(map[string]interface{}{"service": "user", "version": 1})
+		l2 := l1.WithFields(map[string]interface{}{"version": 2})
+
+		l1.Info("l1")
+		m := make(map[string]interface{})
+		t.Assert(json.Unmarshal(buffer.Bytes(), &m), nil)
+		t.Assert(m["service"], "user")
+		t.Assert(m["version"], 1)
+
+		buffer.Reset()
+		l2.Info("l2")
+		m = make(map[string]interface{})
+		t.Assert(json.Unmarshal(buffer.Bytes(), &m), nil)
+		t.Assert(m["service"], "user")
+		t.Assert(m["version"], 2)
+
+		// The original logger is not changed.
+		buffer.Reset()
+		l.Info("l")
+		m = make(map[string]interface{})
+		t.Assert(json.Unmarshal(buffer.Bytes(), &m), nil)
+		t.Assert(m["service"], nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer).WithFields(map[string]interface{}{"b": 2, "a": 1})
+		l.Info("text")
+		t.Assert(gstr.Contains(buffer.String(), "{a: 1, b: 2} text"), true)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+// readGELFMessage reads and reassembles one GELF message from <conn>.
+func readGELFMessage(conn net.PacketConn) (map[string]interface{}, int, error) {
+	var (
+		chunks = make(map[byte][]byte)
+		count  = 0
+		buffer = make([]byte, 65536)
+	)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			return nil, 0, err
+		}
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return nil, 0, err
+		}
+		packet := buffer[:n]
+		var payload []byte
+		if bytes.HasPrefix(packet, gelfChunkMagic) {
+			chunks[packet[10]] = append([]byte(nil), packet[gelfChunkHeaderLen:]...)
+			count = int(packet[11])
+			if len(chunks) < count {
+				continue
+			}
+			for i := 0; i < count; i++ {
+				payload = append(payload, chunks[byte(i)]...)
+			}
+		} else {
+			payload = packet
+		}
+		message := make(map[string]interface{})
+		err = json.Unmarshal(payload, &message)
+		return message, count, err
+	}
+}
+
+func Test_GELFWriter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		t.Assert(err, nil)
+		defer conn.Close()
+
+		writer, err := NewGELFWriter(conn.LocalAddr().String())
+		t.Assert(err, nil)
+		defer writer.Close()
+
+		l := NewWithWriter(writer)
+		l.SetFormat(FormatJSON)
+		l.WithFields(map[string]interface{}{"user": "john", "id": 1}).Warning("disk full")
+
+		message, count, err := readGELFMessage(conn)
+		t.Assert(err, nil)
+		t.Assert(count, 0)
+		t.Assert(message["version"], "1.1")
+		t.AssertNE(message["host"], nil)
+		t.Assert(message["short_message"], "disk full")
+		t.Assert(message["full_message"], nil)
+		t.Assert(message["level"], 4)
+		t.Assert(message["timestamp"].(float64) > 0, true)
+		t.Assert(message["_user"], "john")
+		t.Assert(message["_id"], nil)
+		t.Assert(message["_level"], nil)
+	})
+	// Text format and chunked GELF.
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		t.Assert(err, nil)
+		defer conn.Close()
+
+		writer, err := NewGELFWriter(conn.LocalAddr().String())
+		t.Assert(err, nil)
+		defer writer.Close()
+
+		var (
+			l       = NewWithWriter(writer)
+			content = strings.Repeat("x", 5000)
+		)
+		l.Info("large\n" + content)
+
+		message, count, err := readGELFMessage(conn)
+		t.Assert(err, nil)
+		t.Assert(count > 1, true)
+		t.Assert(strings.HasSuffix(message["short_message"].(string), "[INFO] large"), true)
+		t.Assert(strings.HasSuffix(message["full_message"].(string), content), true)
+		t.Assert(message["level"], 6)
+	})
+}
@@ -57,3 +57,59 @@ func Test_LevelPrefix(t *testing.T) {
 		t.Assert(gstr.Contains(buffer.String(), "error"), true)
 	})
 }
+
+func Test_LevelWriter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			defaultBuffer = bytes.NewBuffer(nil)
+			errorBuffer   = bytes.NewBuffer(nil)
+			l             = NewWithWriter(defaultBuffer)
+		)
+		l.SetLevelWriter(LEVEL_ERRO|LEVEL_CRIT, errorBuffer)
+		t.Assert(l.GetLevelWriter(LEVEL_ERRO), errorBuffer)
+		t.Assert(l.GetLevelWriter(LEVEL_INFO), nil)
+
+		l.Info("info message")
+		l.Error("error message")
+		l.Critical("critical message")
+		t.Assert(gstr.Contains(defaultBuffer.String(), "info message"), true)
+		t.Assert(gstr.Contains(defaultBuffer.String(), "error message"), false)
+		t.Assert(gstr.Contains(defaultBuffer.String(), "critical message"), false)
+		t.Assert(gstr.Contains(errorBuffer.String(), "info message"), false)
+		t.Assert(gstr.Contains(errorBuffer.String(), "error message"), true)
+		t.Assert(gstr.Contains(errorBuffer.String(), "critical message"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer1 = bytes.NewBuffer(nil)
+			buffer2 = bytes.NewBuffer(nil)
+			l       = NewWithWriter(bytes.NewBuffer(nil))
+		)
+		l.SetLevelWriter(LEVEL_ERRO, buffer1)
+		l.SetLevelWriter(LEVEL_ERRO, buffer2)
+		l.Error("error message")
+		t.Assert(gstr.Contains(buffer1.String(), "error message"), true)
+		t.Assert(gstr.Contains(buffer2.String(), "error message"), true)
+	})
+	// The level writers of the cloned logger do not affect the parent logger.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer1 = bytes.NewBuffer(nil)
+			buffer2 = bytes.NewBuffer(nil)
+			l       = NewWithWriter(bytes.NewBuffer(nil))
+		)
+		l.SetLevelWriter(LEVEL_ERRO, buffer1)
+		c := l.Clone()
+		c.SetLevelWriter(LEVEL_ERRO|LEVEL_WARN, buffer2)
+		t.Assert(l.GetLevelWriter(LEVEL_ERRO), buffer1)
+		t.Assert(l.GetLevelWriter(LEVEL_WARN), nil)
+		t.Assert(c.GetLevelWriter(LEVEL_WARN), buffer2)
+
+		l.Error("parent message")
+		c.Error("clone message")
+		t.Assert(gstr.Contains(buffer1.String(), "parent message"), true)
+		t.Assert(gstr.Contains(buffer1.String(), "clone message"), true)
+		t.Assert(gstr.Contains(buffer2.String(), "parent message"), false)
+		t.Assert(gstr.Contains(buffer2.String(), "clone message"), true)
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// lineNumbersOf returns the line numbers of text logging <content>.
+func lineNumbersOf(content string) []int {
+	var numbers []int
+	for _, line := range gstr.SplitAndTrim(content, "\n") {
+		numbers = append(numbers, gconv.Int(gstr.Split(line, " ")[0]))
+	}
+	return numbers
+}
+
+func Test_LineNumbers(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = glog.NewWithWriter(buffer)
+		)
+		l.SetLineNumbers(true)
+		l.Print("1")
+		l.Info("2")
+		l.Warning("3")
+		t.Assert(lineNumbersOf(buffer.String()), []int{1, 2, 3})
+
+		// Chaining loggers share the same sequence.
+		buffer.Reset()
+		l.Line().Print("4")
+		t.Assert(lineNumbersOf(buffer.String()), []int{4})
+	})
+	// JSON format.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = glog.NewWithWriter(buffer)
+		)
+		l.SetLineNumbers(true)
+		l.SetFormat(glog.FormatJSON)
+		l.Print("1")
+		t.Assert(gstr.HasPrefix(buffer.String(), `{"lineNumber":1,"time":`), true)
+	})
+	// Disabled in default.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = glog.NewWithWriter(buffer)
+		)
+		l.SetHeaderPrint(false)
+		l.Print("content")
+		t.Assert(buffer.String(), "content\n")
+	})
+}
+
+func Test_LineNumbers_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg    = sync.WaitGroup{}
+			path  = gfile.TempDir(gtime.TimestampNanoStr())
+			l     = glog.New()
+			count = 200
+		)
+		defer gfile.Remove(path)
+		t.Assert(l.SetPath(path), nil)
+		l.SetFile("access.log")
+		l.SetStdoutPrint(false)
+		l.SetLineNumbers(true)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Print("concurrent")
+			}()
+		}
+		wg.Wait()
+		numbers := lineNumbersOf(gfile.GetContents(gfile.Join(path, "access.log")))
+		t.Assert(len(numbers), count)
+		// The line numbers are in order of writing to the logging file.
+		t.Assert(sort.IntsAreSorted(numbers), true)
+		for i, number := range numbers {
+			t.Assert(number, i+1)
+		}
+	})
+}
+
+func Test_LineNumbers_Rotate(t *testing.T) {
+	// Continue after rotation.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = gfile.Join(path, "access.log")
+			l    = glog.New()
+		)
+		defer gfile.Remove(path)
+		err := l.SetConfigWithMap(map[string]interface{}{
+			"Path":              path,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"LineNumbers":       true,
+			"RotateBackupLimit": 10,
+		})
+		t.Assert(err, nil)
+		l.Print("1")
+		l.Print("2")
+		t.Assert(l.Rotate(), nil)
+		l.Print("3")
+		t.Assert(lineNumbersOf(gfile.GetContents(file)), []int{3})
+	})
+	// Reset after rotation.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = gfile.Join(path, "access.log")
+			l    = glog.New()
+		)
+		defer gfile.Remove(path)
+		err := l.SetConfigWithMap(map[string]interface{}{
+			"Path":              path,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"LineNumbers":       true,
+			"ResetLineNumbers":  true,
+			"RotateBackupLimit": 10,
+		})
+		t.Assert(err, nil)
+		l.Print("1")
+		l.Print("2")
+		t.Assert(lineNumbersOf(gfile.GetContents(file)), []int{1, 2})
+		t.Assert(l.Rotate(), nil)
+		l.Print("1")
+		l.Print("2")
+		t.Assert(lineNumbersOf(gfile.GetContents(file)), []int{1, 2})
+	})
+	// Reset after rotation by size.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.TempDir(gtime.TimestampNanoStr())
+			file = gfile.Join(path, "access.log")
+			l    = glog.New()
+		)
+		defer gfile.Remove(path)
+		err := l.SetConfigWithMap(map[string]interface{}{
+			"Path":              path,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"LineNumbers":       true,
+			"ResetLineNumbers":  true,
+			"RotateSize":        10,
+			"RotateBackupLimit": 10,
+		})
+		t.Assert(err, nil)
+		for i := 0; i < 3; i++ {
+			l.Print("rotation by size")
+			t.Assert(lineNumbersOf(gfile.GetContents(file)), []int{1})
+		}
+	})
+}
@@ -13,7 +13,10 @@ import (
 	"github.com/ichunt2019/gf/os/glog"
 	"github.com/ichunt2019/gf/os/gtime"
 	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gregex"
 	"github.com/ichunt2019/gf/text/gstr"
+	"os"
+	"sync"
 	"testing"
 	"time"
 )
@@ -99,3 +102,123 @@ func Test_Rotate_Expire(t *testing.T) {
 		t.Assert(len(files), 0)
 	})
 }
+
+func Test_Rotate_Manual(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":              p,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"RotateBackupLimit": 10,
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		l.Print("before rotation")
+		t.Assert(l.Rotate(), nil)
+		l.Print("after rotation")
+
+		content := gfile.GetContents(gfile.Join(p, "access.log"))
+		t.Assert(gstr.Contains(content, "after rotation"), true)
+		t.Assert(gstr.Contains(content, "before rotation"), false)
+		files, err := gfile.ScanDirFile(p, "access.*.log")
+		t.Assert(err, nil)
+		t.Assert(len(files), 1)
+		t.Assert(gstr.Contains(gfile.GetContents(files[0]), "before rotation"), true)
+	})
+	// Concurrent writing.
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":              p,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"HeaderPrint":       false,
+			"RotateBackupLimit": 100,
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		var (
+			wg   = sync.WaitGroup{}
+			size = 100
+		)
+		for i := 0; i < size; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Print("concurrent")
+			}()
+			if i%20 == 0 {
+				t.Assert(l.Rotate(), nil)
+			}
+		}
+		wg.Wait()
+		files, err := gfile.ScanDirFile(p, "*.log")
+		t.Assert(err, nil)
+		count := 0
+		for _, file := range files {
+			count += gstr.Count(gfile.GetContents(file), "concurrent")
+		}
+		t.Assert(count, size)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(glog.New().Rotate(), nil)
+	})
+}
+
+func Test_Rotate_BackupNameTemplate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":                     p,
+			"File":                     "access.log",
+			"StdoutPrint":              false,
+			"RotateBackupLimit":        2,
+			"RotateBackupNameTemplate": "access-2006-01-02T15-04-05.{pid}.{seq}.log",
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		for i := 0; i < 3; i++ {
+			l.Printf("content %d", i)
+			t.Assert(l.Rotate(), nil)
+		}
+		files, err := gfile.ScanDirFile(p, "access-*.log")
+		t.Assert(err, nil)
+		// The oldest backup is removed for backup limit.
+		t.Assert(len(files), 2)
+		for _, file := range files {
+			t.Assert(gregex.IsMatchString(
+				fmt.Sprintf(`^access-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.%d\.\d+\.log$`, os.Getpid()),
+				gfile.Basename(file),
+			), true)
+		}
+		t.Assert(gfile.Exists(gfile.Join(p, "access.log")), false)
+	})
+	// Conflict without {seq}.
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":                     p,
+			"File":                     "access.log",
+			"StdoutPrint":              false,
+			"RotateBackupLimit":        10,
+			"RotateBackupNameTemplate": "access.bak.log",
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		l.Print("content 1")
+		t.Assert(l.Rotate(), nil)
+		l.Print("content 2")
+		t.AssertNE(l.Rotate(), nil)
+		t.Assert(gstr.Contains(gfile.GetContents(gfile.Join(p, "access.bak.log")), "content 1"), true)
+		t.Assert(gstr.Contains(gfile.GetContents(gfile.Join(p, "access.log")), "content 2"), true)
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_SamplingRate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer)
+		l.SetSamplingRate(0.1)
+		for i := 0; i < 100; i++ {
+			l.Info("sampling")
+		}
+		t.Assert(gstr.Count(buffer.String(), "sampling"), 10)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		l := NewWithWriter(buffer)
+		l.SetSamplingRate(0)
+		l.SetLevelSamplingRate(LEVEL_ERRO, 1)
+		l.SetLevelSamplingRate(LEVEL_DEBU|LEVEL_INFO, 0.5)
+		for i := 0; i < 100; i++ {
+			l.Debug("debug")
+			l.Info("info")
+			l.Warning("warning")
+			l.Error("error")
+		}
+		t.Assert(gstr.Count(buffer.String(), "debug"), 50)
+		t.Assert(gstr.Count(buffer.String(), "info"), 50)
+		t.Assert(gstr.Count(buffer.String(), "warning"), 0)
+		t.Assert(gstr.Count(buffer.String(), "] error"), 100)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     = sync.WaitGroup{}
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(writer)
+		)
+		l.SetSamplingRate(0.25)
+		for i := 0; i < 400; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Info("concurrent")
+			}()
+		}
+		wg.Wait()
+		t.Assert(gstr.Count(writer.String(), "concurrent"), 100)
+	})
+	// The level sampling rate of the derived logger does not affect the parent logger.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     = sync.WaitGroup{}
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(writer)
+		)
+		l.SetLevelSamplingRate(LEVEL_INFO, 1)
+		r := l.WithRequestId("x")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				r.SetLevelSamplingRate(LEVEL_INFO|LEVEL_WARN, 0)
+			}
+		}()
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Info("parent")
+			}()
+		}
+		wg.Wait()
+		r.Info("derived")
+		r.Warning("derived")
+		t.Assert(gstr.Count(writer.String(), "parent"), 10)
+		t.Assert(gstr.Count(writer.String(), "derived"), 0)
+		l.Warning("warning")
+		t.Assert(gstr.Count(writer.String(), "warning"), 1)
+	})
+}
+
+// concurrentBuffer is a bytes buffer which is safe for concurrent writing.
+type concurrentBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *concurrentBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *concurrentBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
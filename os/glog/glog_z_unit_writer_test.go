@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package glog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+// failedWriter is a writer which always returns error.
+type failedWriter struct {
+	message string
+}
+
+func (w *failedWriter) Write(p []byte) (int, error) {
+	return 0, errors.New(w.message)
+}
+
+func Test_AddWriter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer1 = bytes.NewBuffer(nil)
+			buffer2 = bytes.NewBuffer(nil)
+			buffer3 = bytes.NewBuffer(nil)
+			l       = NewWithWriter(buffer1)
+		)
+		l.AddWriter(buffer2)
+		l.AddWriter(buffer3)
+		t.Assert(len(l.GetWriters()), 2)
+		l.Info("fan-out")
+		t.Assert(gstr.Contains(buffer1.String(), "fan-out"), true)
+		t.Assert(gstr.Contains(buffer2.String(), "fan-out"), true)
+		t.Assert(gstr.Contains(buffer3.String(), "fan-out"), true)
+
+		l.RemoveWriter(buffer2)
+		t.Assert(len(l.GetWriters()), 1)
+		l.Info("removed")
+		t.Assert(gstr.Contains(buffer1.String(), "removed"), true)
+		t.Assert(gstr.Contains(buffer2.String(), "removed"), false)
+		t.Assert(gstr.Contains(buffer3.String(), "removed"), true)
+	})
+	// Errors aggregation.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = NewWithWriter(&failedWriter{message: "default failed"})
+		)
+		l.AddWriter(&failedWriter{message: "writer1 failed"})
+		l.AddWriter(buffer)
+		l.AddWriter(&failedWriter{message: "writer2 failed"})
+		_, err := l.Write([]byte("content\n"))
+		t.AssertNE(err, nil)
+		t.Assert(err.Error(), "default failed; writer1 failed; writer2 failed")
+		t.Assert(buffer.String(), "content\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		l := NewWithWriter(bytes.NewBuffer(nil))
+		_, err := l.Write([]byte("content"))
+		t.Assert(err, nil)
+	})
+	// Concurrent safety.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     = sync.WaitGroup{}
+			writer = &concurrentBuffer{}
+			l      = NewWithWriter(&concurrentBuffer{})
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				w := &concurrentBuffer{}
+				l.AddWriter(w)
+				l.RemoveWriter(w)
+			}()
+			go func() {
+				defer wg.Done()
+				l.Info("concurrent")
+			}()
+		}
+		wg.Wait()
+		l.AddWriter(writer)
+		l.Info("done")
+		t.Assert(writer.String() != "", true)
+		t.Assert(len(l.GetWriters()), 1)
+	})
+	// The writers of the cloned logger do not affect the parent logger.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer1 = bytes.NewBuffer(nil)
+			buffer2 = bytes.NewBuffer(nil)
+			l       = NewWithWriter(bytes.NewBuffer(nil))
+		)
+		l.AddWriter(buffer1)
+		c := l.Clone()
+		c.AddWriter(buffer2)
+		t.Assert(len(l.GetWriters()), 1)
+		t.Assert(len(c.GetWriters()), 2)
+
+		l.Info("parent")
+		c.Info("clone")
+		t.Assert(gstr.Contains(buffer1.String(), "parent"), true)
+		t.Assert(gstr.Contains(buffer1.String(), "clone"), true)
+		t.Assert(gstr.Contains(buffer2.String(), "parent"), false)
+		t.Assert(gstr.Contains(buffer2.String(), "clone"), true)
+
+		c.RemoveWriter(buffer1)
+		t.Assert(len(l.GetWriters()), 1)
+		t.Assert(len(c.GetWriters()), 1)
+	})
+	// The writers of the configuration are not shared among loggers.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			config = DefaultConfig()
+			l1     = New()
+			l2     = New()
+		)
+		t.Assert(l1.SetConfig(config), nil)
+		t.Assert(l2.SetConfig(config), nil)
+		l1.AddWriter(buffer)
+		t.Assert(len(l1.GetWriters()), 1)
+		t.Assert(len(l2.GetWriters()), 0)
+	})
+}
+
+func Test_SetWriterErrorHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			errs = make([]string, 0)
+			l    = NewWithWriter(bytes.NewBuffer(nil))
+		)
+		l.SetWriterErrorHandler(func(err error) {
+			errs = append(errs, err.Error())
+		})
+		l.Info("success")
+		t.Assert(len(errs), 0)
+
+		l.AddWriter(&failedWriter{message: "writer1 failed"})
+		l.AddWriter(&failedWriter{message: "writer2 failed"})
+		l.Info("failed")
+		l.Error("failed")
+		t.Assert(errs, []string{
+			"writer1 failed; writer2 failed",
+			"writer1 failed; writer2 failed",
+		})
+	})
+	// Async mode.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			errs = make(chan error, 1)
+			l    = NewWithWriter(&failedWriter{message: "default failed"})
+		)
+		l.SetAsyncBuffer(10)
+		defer l.SetAsyncBuffer(0)
+		l.SetWriterErrorHandler(func(err error) {
+			errs <- err
+		})
+		l.Info("failed")
+		t.Assert((<-errs).Error(), "default failed")
+		t.Assert(l.Flush().Error(), "default failed")
+	})
+}
+
+func Test_SetOutput(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			l      = New()
+		)
+		l.SetOutput(buffer)
+		t.Assert(l.GetOutput(), buffer)
+		l.Info("output")
+		t.Assert(gstr.Count(buffer.String(), "output"), 1)
+		t.Assert(gstr.Contains(buffer.String(), defaultLevelPrefixes[LEVEL_INFO]), true)
+
+		// Reset to stdout.
+		l.SetOutput(nil)
+		l.Info("stdout")
+		t.Assert(gstr.Contains(buffer.String(), "stdout"), false)
+	})
+	// Fan-out with file logging.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path   = gfile.TempDir(gtime.TimestampNanoStr())
+			file   = fmt.Sprintf(`%d.log`, gtime.TimestampNano())
+			buffer = bytes.NewBuffer(nil)
+			l      = New()
+		)
+		defer gfile.Remove(path)
+		t.Assert(l.SetPath(path), nil)
+		l.SetFile(file)
+		l.SetOutput(buffer)
+		l.Info("fan-out")
+		t.Assert(gstr.Count(buffer.String(), "fan-out"), 1)
+		t.Assert(gstr.Count(gfile.GetContents(gfile.Join(path, file)), "fan-out"), 1)
+	})
+}
@@ -0,0 +1,237 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+// Package ui implements a minimal web interface for browsing the log files of glog.Logger,
+// which supports searching by level/time range/pattern, downloading rotated files
+// and streaming the newly written log lines using Server-Sent Events.
+//
+// Note that authentication is not in scope of this package,
+// the handler should be mounted behind the authentication middleware, eg:
+//
+//	http.Handle("/logs/", auth(http.StripPrefix("/logs", ui.Handler(glog.DefaultLogger()))))
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/os/gres"
+)
+
+const (
+	indexFileName = "index.html" // File name of the single-page UI in resource.
+	defaultLimit  = 1000         // Default maximum count of the lines returned by searching.
+)
+
+var (
+	// resource is the resource object embedding the static files of the UI.
+	resource = gres.New()
+
+	// levels are the logging levels that can be searched, in ascending order.
+	levels = []int{
+		glog.LEVEL_DEBU,
+		glog.LEVEL_INFO,
+		glog.LEVEL_NOTI,
+		glog.LEVEL_WARN,
+		glog.LEVEL_ERRO,
+		glog.LEVEL_CRIT,
+	}
+)
+
+func init() {
+	if err := resource.AddFile(indexFileName, []byte(indexContent)); err != nil {
+		panic(err)
+	}
+}
+
+// handler is the http.Handler serving the log viewer of a logger.
+type handler struct {
+	logger *glog.Logger
+	mux    *http.ServeMux
+}
+
+// FileInfo is the information of a log file, which is returned by the files listing endpoint.
+type FileInfo struct {
+	Name    string    `json:"name"`    // File name.
+	Size    int64     `json:"size"`    // File size in bytes.
+	ModTime time.Time `json:"modTime"` // Last modification time.
+}
+
+// Handler creates and returns a http.Handler serving the log viewer for <logger>,
+// which browses the log files under the logging path of <logger>.
+//
+// The endpoints are as follows, which are relative to the mounted path:
+//
+//	GET /         The single-page UI.
+//	GET /levels   The level prefixes of <logger> in JSON.
+//	GET /files    The log files in JSON.
+//	GET /search   Search log lines with parameters: file, level, start, end, pattern and limit.
+//	GET /download Download log file with parameter: file.
+//	GET /stream   Stream newly written log lines using Server-Sent Events with parameters: file, level and pattern.
+func Handler(logger *glog.Logger) http.Handler {
+	h := &handler{
+		logger: logger,
+		mux:    http.NewServeMux(),
+	}
+	h.mux.HandleFunc("/", h.index)
+	h.mux.HandleFunc("/levels", h.levels)
+	h.mux.HandleFunc("/files", h.files)
+	h.mux.HandleFunc("/search", h.search)
+	h.mux.HandleFunc("/download", h.download)
+	h.mux.HandleFunc("/stream", h.stream)
+	return h
+}
+
+// ServeHTTP implements the interface of http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// index serves the single-page UI.
+func (h *handler) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	file, err := resource.HTTPFileSystem().Open(indexFileName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, indexFileName, info.ModTime(), file)
+}
+
+// levels responds the level prefixes of the logger in ascending level order.
+func (h *handler) levels(w http.ResponseWriter, r *http.Request) {
+	prefixes := make([]string, 0, len(levels))
+	for _, level := range levels {
+		prefixes = append(prefixes, h.logger.GetLevelPrefix(level))
+	}
+	writeJson(w, prefixes)
+}
+
+// files responds the log files under the logging path, which are sorted by name.
+func (h *handler) files(w http.ResponseWriter, r *http.Request) {
+	infos, err := h.fileInfos()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJson(w, infos)
+}
+
+// download serves the log file specified by parameter "file" as attachment.
+func (h *handler) download(w http.ResponseWriter, r *http.Request) {
+	path, err := h.filePath(r.URL.Query().Get("file"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, info.Name()))
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// fileInfos returns the information of log files under the logging path, which are sorted by name.
+func (h *handler) fileInfos() ([]FileInfo, error) {
+	infos := make([]FileInfo, 0)
+	path := h.logger.GetPath()
+	if path == "" || !gfile.IsDir(path) {
+		return infos, nil
+	}
+	paths, err := gfile.ScanDirFile(path, "*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			infos = append(infos, FileInfo{
+				Name:    info.Name(),
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+			})
+		}
+	}
+	return infos, nil
+}
+
+// filePath checks and returns the absolute path of log file <name> under the logging path.
+// It returns error if <name> is not a log file directly under the logging path,
+// which avoids accessing other files using relative path like "../".
+func (h *handler) filePath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || name == ".." {
+		return "", fmt.Errorf(`invalid log file name "%s"`, name)
+	}
+	path := h.logger.GetPath()
+	if path == "" {
+		return "", fmt.Errorf(`logging path is not set`)
+	}
+	path = filepath.Join(path, name)
+	if !gfile.IsFile(path) {
+		return "", fmt.Errorf(`log file "%s" does not exist`, name)
+	}
+	return path, nil
+}
+
+// latestFile returns the name of the latest modified log file under the logging path.
+func (h *handler) latestFile() (string, error) {
+	infos, err := h.fileInfos()
+	if err != nil {
+		return "", err
+	}
+	name := ""
+	latest := time.Time{}
+	for _, info := range infos {
+		if !strings.HasSuffix(info.Name, ".gz") && info.ModTime.After(latest) {
+			name = info.Name
+			latest = info.ModTime
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf(`no log file found`)
+	}
+	return name, nil
+}
+
+// writeJson writes <value> to <w> in JSON.
+func writeJson(w http.ResponseWriter, value interface{}) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
@@ -0,0 +1,141 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package ui
+
+// indexContent is the single-page UI, which uses relative urls for the endpoints,
+// so that the handler can be mounted at any path ending with '/'.
+const indexContent = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Log Viewer</title>
+<style>
+body { font-family: sans-serif; margin: 0; padding: 12px; }
+form { display: flex; flex-wrap: wrap; gap: 8px; align-items: center; margin-bottom: 12px; }
+#lines { font-family: monospace; font-size: 12px; white-space: pre-wrap; word-break: break-all; background: #f6f8fa; padding: 8px; min-height: 200px; }
+#lines .no { color: #999; user-select: none; }
+#status { color: #666; margin-bottom: 8px; }
+</style>
+</head>
+<body>
+<form id="form">
+	<select id="file" name="file"><option value="">All files</option></select>
+	<select id="level" name="level"><option value="">All levels</option></select>
+	<input id="start" name="start" placeholder="Start: 2006-01-02 15:04:05">
+	<input id="end" name="end" placeholder="End: 2006-01-02 15:04:05">
+	<input id="pattern" name="pattern" placeholder="Pattern (regular expression)">
+	<input id="limit" name="limit" type="number" min="1" value="1000" style="width:80px">
+	<button type="submit">Search</button>
+	<button type="button" id="stream">Stream</button>
+	<a id="download" href="#">Download</a>
+</form>
+<div id="status"></div>
+<div id="lines"></div>
+<script>
+(function () {
+	var form = document.getElementById("form"),
+		fileSelect = document.getElementById("file"),
+		levelSelect = document.getElementById("level"),
+		lines = document.getElementById("lines"),
+		status = document.getElementById("status"),
+		streamButton = document.getElementById("stream"),
+		download = document.getElementById("download"),
+		source = null;
+
+	function query(names) {
+		var params = new URLSearchParams();
+		names.forEach(function (name) {
+			var value = document.getElementById(name).value;
+			if (value !== "") {
+				params.set(name, value);
+			}
+		});
+		return params.toString();
+	}
+
+	function append(file, no, content) {
+		var row = document.createElement("div"), span = document.createElement("span");
+		span.className = "no";
+		span.textContent = (file ? file + ":" : "") + (no ? no + " " : "");
+		row.appendChild(span);
+		row.appendChild(document.createTextNode(content));
+		lines.appendChild(row);
+	}
+
+	function stopStream() {
+		if (source) {
+			source.close();
+			source = null;
+			streamButton.textContent = "Stream";
+		}
+	}
+
+	fetch("levels").then(function (r) { return r.json(); }).then(function (levels) {
+		levels.forEach(function (level) {
+			levelSelect.add(new Option(level, level));
+		});
+	});
+	fetch("files").then(function (r) { return r.json(); }).then(function (files) {
+		files.forEach(function (file) {
+			fileSelect.add(new Option(file.name + " (" + file.size + " bytes)", file.name));
+		});
+	});
+
+	fileSelect.addEventListener("change", function () {
+		download.href = fileSelect.value ? "download?file=" + encodeURIComponent(fileSelect.value) : "#";
+	});
+	download.addEventListener("click", function (e) {
+		if (!fileSelect.value) {
+			e.preventDefault();
+			status.textContent = "Select a file to download.";
+		}
+	});
+
+	form.addEventListener("submit", function (e) {
+		e.preventDefault();
+		stopStream();
+		status.textContent = "Searching...";
+		fetch("search?" + query(["file", "level", "start", "end", "pattern", "limit"])).then(function (r) {
+			if (!r.ok) {
+				return r.text().then(function (text) { throw new Error(text); });
+			}
+			return r.json();
+		}).then(function (result) {
+			lines.innerHTML = "";
+			result.lines.forEach(function (line) {
+				append(line.file, line.number, line.content);
+			});
+			status.textContent = result.lines.length + " lines" + (result.truncated ? ", showing the latest ones only" : "");
+		}).catch(function (err) {
+			status.textContent = err.message;
+		});
+	});
+
+	streamButton.addEventListener("click", function () {
+		if (source) {
+			stopStream();
+			status.textContent = "Streaming stopped.";
+			return;
+		}
+		lines.innerHTML = "";
+		source = new EventSource("stream?" + query(["file", "level", "pattern"]));
+		source.onmessage = function (e) {
+			append("", 0, e.data);
+			window.scrollTo(0, document.body.scrollHeight);
+		};
+		source.onerror = function () {
+			status.textContent = "Streaming disconnected.";
+			stopStream();
+		};
+		streamButton.textContent = "Stop";
+		status.textContent = "Streaming...";
+	});
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,214 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package ui
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gtime"
+)
+
+const (
+	maxLineSize = 1024 * 1024 // Max size of a single log line for scanning.
+)
+
+// Line is a matched log line, which is returned by the searching endpoint.
+type Line struct {
+	File    string `json:"file"`    // Name of the log file.
+	Number  int    `json:"number"`  // Line number in the log file, starting from 1.
+	Content string `json:"content"` // Content of the line.
+}
+
+// SearchResult is the result of the searching endpoint.
+type SearchResult struct {
+	Lines     []Line `json:"lines"`     // Matched lines, the latest ones are kept if exceeding the limit.
+	Truncated bool   `json:"truncated"` // Whether the matched lines exceed the limit.
+}
+
+// filter filters the log lines by level, time range and pattern.
+type filter struct {
+	levels  []string       // Level prefixes, eg: ERRO, WARN.
+	start   time.Time      // Start time of the range, inclusive.
+	end     time.Time      // End time of the range, inclusive.
+	pattern *regexp.Regexp // Regular expression pattern of the content.
+}
+
+// search searches the log lines matching the filter in the log file specified by parameter "file",
+// or all the log files if it is empty. The gzip compressed rotated files are also searched.
+func (h *handler) search(w http.ResponseWriter, r *http.Request) {
+	f, err := newFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := defaultLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			http.Error(w, fmt.Sprintf(`invalid limit "%s"`, v), http.StatusBadRequest)
+			return
+		}
+	}
+	var names []string
+	if name := r.URL.Query().Get("file"); name != "" {
+		names = []string{name}
+	} else {
+		infos, err := h.fileInfos()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+	}
+	result := &SearchResult{
+		Lines: make([]Line, 0),
+	}
+	for _, name := range names {
+		path, err := h.filePath(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err = searchFile(path, name, f, limit, result); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	writeJson(w, result)
+}
+
+// searchFile searches the lines matching <f> in file <path>, and appends them to <result>,
+// which keeps only the latest <limit> lines.
+func searchFile(path, name string, f *filter, limit int, result *SearchResult) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var reader io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+	var (
+		scanner  = bufio.NewScanner(reader)
+		number   = 0
+		lastTime time.Time
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		number++
+		line := scanner.Text()
+		// The line without time, eg: stack of error, belongs to the previous logging entry.
+		if f.hasTimeRange() {
+			if t := gtime.ParseTimeFromContent(line); t != nil {
+				lastTime = t.Time
+			}
+		}
+		if !f.match(line, lastTime) {
+			continue
+		}
+		if len(result.Lines) >= limit {
+			result.Lines = result.Lines[1:]
+			result.Truncated = true
+		}
+		result.Lines = append(result.Lines, Line{
+			File:    name,
+			Number:  number,
+			Content: line,
+		})
+	}
+	return scanner.Err()
+}
+
+// newFilter creates and returns a filter from parameters "level", "start", "end" and "pattern" of <r>.
+// The parameter "level" supports multiple level prefixes join with ',',
+// and the parameters "start" and "end" support the time formats of gtime.StrToTime.
+func newFilter(r *http.Request) (*filter, error) {
+	var (
+		query = r.URL.Query()
+		f     = &filter{}
+	)
+	for _, level := range strings.Split(query.Get("level"), ",") {
+		if level = strings.TrimSpace(level); level != "" {
+			f.levels = append(f.levels, level)
+		}
+	}
+	if v := query.Get("start"); v != "" {
+		t, err := gtime.StrToTime(v)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid start time "%s": %v`, v, err)
+		}
+		f.start = t.Time
+	}
+	if v := query.Get("end"); v != "" {
+		t, err := gtime.StrToTime(v)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid end time "%s": %v`, v, err)
+		}
+		f.end = t.Time
+	}
+	if v := query.Get("pattern"); v != "" {
+		pattern, err := regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid pattern "%s": %v`, v, err)
+		}
+		f.pattern = pattern
+	}
+	return f, nil
+}
+
+// hasTimeRange checks and returns whether the filter has time range.
+func (f *filter) hasTimeRange() bool {
+	return !f.start.IsZero() || !f.end.IsZero()
+}
+
+// match checks and returns whether <line> logged at <lineTime> matches the filter.
+// It supports both the text and JSON format log lines for level matching.
+func (f *filter) match(line string, lineTime time.Time) bool {
+	if len(f.levels) > 0 {
+		matched := false
+		for _, level := range f.levels {
+			if strings.Contains(line, "["+level+"]") || strings.Contains(line, `"level":"`+level+`"`) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if f.hasTimeRange() {
+		if lineTime.IsZero() {
+			return false
+		}
+		if !f.start.IsZero() && lineTime.Before(f.start) {
+			return false
+		}
+		if !f.end.IsZero() && lineTime.After(f.end) {
+			return false
+		}
+	}
+	if f.pattern != nil && !f.pattern.MatchString(line) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// streamInterval is the interval checking newly written content of the streaming log file.
+	streamInterval = 500 * time.Millisecond
+)
+
+// stream streams the log lines newly written to the log file specified by parameter "file"
+// using Server-Sent Events, which streams the latest modified log file if "file" is empty.
+// The lines can be filtered by parameters "level" and "pattern".
+//
+// It reopens the log file from the beginning if it is truncated or replaced by rotation.
+func (h *handler) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	f, err := newFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Time range is meaningless for streaming.
+	f.start, f.end = time.Time{}, time.Time{}
+	name := r.URL.Query().Get("file")
+	if name == "" {
+		if name, err = h.latestFile(); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+	path, err := h.filePath(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer func() {
+		file.Close()
+	}()
+	// It streams only the newly written content.
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var (
+		ticker  = time.NewTicker(streamInterval)
+		buffer  = make([]byte, 32*1024)
+		partial []byte
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		// Rotation or truncation checks.
+		if info, err := os.Stat(path); err == nil {
+			if current, err := file.Stat(); err != nil || !os.SameFile(info, current) || info.Size() < offset {
+				if newFile, err := os.Open(path); err == nil {
+					file.Close()
+					file, offset, partial = newFile, 0, nil
+				}
+			}
+		}
+		written := false
+		for {
+			n, err := file.Read(buffer)
+			if n > 0 {
+				offset += int64(n)
+				partial = append(partial, buffer[:n]...)
+				for {
+					pos := bytes.IndexByte(partial, '\n')
+					if pos == -1 {
+						break
+					}
+					line := strings.TrimRight(string(partial[:pos]), "\r")
+					partial = partial[pos+1:]
+					if f.match(line, time.Time{}) {
+						fmt.Fprintf(w, "data: %s\n\n", line)
+						written = true
+					}
+				}
+			}
+			if err != nil || n == 0 {
+				break
+			}
+		}
+		if written {
+			flusher.Flush()
+		}
+	}
+}
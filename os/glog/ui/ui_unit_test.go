@@ -0,0 +1,174 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package ui_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/os/glog/ui"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+// newServer creates a logger logging to a temporary directory with some lines,
+// and returns the test server serving its log viewer.
+func newServer(t *gtest.T) (*glog.Logger, *httptest.Server, string) {
+	path := gfile.Join(gfile.TempDir(), "glog-ui", gtime.TimestampNanoStr())
+	logger := glog.New()
+	logger.SetStdoutPrint(false)
+	t.Assert(logger.SetPath(path), nil)
+	logger.SetFile("app.log")
+	logger.Info("user login", 1)
+	logger.Warning("disk usage high")
+	logger.Info("user logout", 1)
+	logger.Notice("user login", 2)
+	t.Assert(gfile.PutContents(gfile.Join(path, "app.20200101.log"), "2020-01-01 10:00:00.000 [ERRO] old failure\n"), nil)
+	return logger, httptest.NewServer(ui.Handler(logger)), path
+}
+
+func getJson(t *gtest.T, url string, v interface{}) int {
+	resp, err := http.Get(url)
+	t.Assert(err, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Assert(json.NewDecoder(resp.Body).Decode(v), nil)
+	}
+	return resp.StatusCode
+}
+
+func Test_Search(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, s, path := newServer(t)
+		defer s.Close()
+		defer gfile.Remove(path)
+
+		var result ui.SearchResult
+		// Pattern.
+		t.Assert(getJson(t, s.URL+"/search?pattern="+url.QueryEscape(`user log(in|out)`), &result), http.StatusOK)
+		t.Assert(len(result.Lines), 3)
+		t.Assert(result.Truncated, false)
+		t.Assert(result.Lines[0].File, "app.log")
+		t.Assert(result.Lines[0].Number, 1)
+		t.Assert(gstr.Contains(result.Lines[0].Content, "[INFO] user login 1"), true)
+
+		// Level and pattern.
+		t.Assert(getJson(t, s.URL+"/search?file=app.log&level=INFO&pattern=login", &result), http.StatusOK)
+		t.Assert(len(result.Lines), 1)
+		t.Assert(gstr.Contains(result.Lines[0].Content, "user login 1"), true)
+
+		// Multiple levels.
+		t.Assert(getJson(t, s.URL+"/search?level=WARN,ERRO", &result), http.StatusOK)
+		t.Assert(len(result.Lines), 2)
+		t.Assert(result.Lines[0].File, "app.20200101.log")
+		t.Assert(gstr.Contains(result.Lines[1].Content, "disk usage high"), true)
+
+		// Time range.
+		t.Assert(getJson(t, s.URL+"/search?"+url.Values{
+			"start": {"2020-01-01 00:00:00"},
+			"end":   {"2020-01-02 00:00:00"},
+		}.Encode(), &result), http.StatusOK)
+		t.Assert(len(result.Lines), 1)
+		t.Assert(gstr.Contains(result.Lines[0].Content, "old failure"), true)
+		t.Assert(getJson(t, s.URL+"/search?start="+url.QueryEscape(time.Now().Add(-time.Hour).Format("2006-01-02 15:04:05")), &result), http.StatusOK)
+		t.Assert(len(result.Lines), 4)
+
+		// Limit keeps the latest lines.
+		t.Assert(getJson(t, s.URL+"/search?file=app.log&limit=2", &result), http.StatusOK)
+		t.Assert(len(result.Lines), 2)
+		t.Assert(result.Truncated, true)
+		t.Assert(result.Lines[1].Number, 4)
+
+		// Errors.
+		t.Assert(getJson(t, s.URL+"/search?pattern="+url.QueryEscape("("), &result), http.StatusBadRequest)
+		t.Assert(getJson(t, s.URL+"/search?start=invalid", &result), http.StatusBadRequest)
+		t.Assert(getJson(t, s.URL+"/search?file=none.log", &result), http.StatusNotFound)
+		t.Assert(getJson(t, s.URL+"/search?file="+url.QueryEscape("../app.log"), &result), http.StatusNotFound)
+	})
+}
+
+func Test_Files_Download(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, s, path := newServer(t)
+		defer s.Close()
+		defer gfile.Remove(path)
+
+		var levels []string
+		t.Assert(getJson(t, s.URL+"/levels", &levels), http.StatusOK)
+		t.Assert(levels, []string{"DEBU", "INFO", "NOTI", "WARN", "ERRO", "CRIT"})
+
+		var files []ui.FileInfo
+		t.Assert(getJson(t, s.URL+"/files", &files), http.StatusOK)
+		t.Assert(len(files), 2)
+		t.Assert(files[0].Name, "app.20200101.log")
+		t.Assert(files[1].Name, "app.log")
+		t.Assert(files[1].Size, gfile.Size(gfile.Join(path, "app.log")))
+
+		resp, err := http.Get(s.URL + "/download?file=app.20200101.log")
+		t.Assert(err, nil)
+		content, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("Content-Disposition"), `attachment; filename="app.20200101.log"`)
+		t.Assert(content, gfile.GetBytes(gfile.Join(path, "app.20200101.log")))
+
+		resp, err = http.Get(s.URL + "/download?file=" + url.QueryEscape("../../etc/passwd"))
+		t.Assert(err, nil)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+
+		// Index page.
+		resp, err = http.Get(s.URL + "/")
+		t.Assert(err, nil)
+		content, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(gstr.Contains(resp.Header.Get("Content-Type"), "text/html"), true)
+		t.Assert(gstr.Contains(string(content), "EventSource"), true)
+
+		resp, err = http.Post(s.URL+"/search", "text/plain", nil)
+		t.Assert(err, nil)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusMethodNotAllowed)
+	})
+}
+
+func Test_Stream(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		logger, s, path := newServer(t)
+		defer s.Close()
+		defer gfile.Remove(path)
+
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Get(s.URL + "/stream?file=app.log&level=ERRO")
+		t.Assert(err, nil)
+		defer resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("Content-Type"), "text/event-stream")
+
+		logger.Info("streamed info")
+		logger.Warning("streamed warning")
+		logger.Notice("streamed notice")
+		logger.Stack(false).Error("streamed error")
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		t.Assert(err, nil)
+		t.Assert(strings.HasPrefix(line, "data: "), true)
+		t.Assert(gstr.Contains(line, "[ERRO] streamed error"), true)
+	})
+}
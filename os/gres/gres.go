@@ -31,6 +31,18 @@ func Load(path string, prefix ...string) error {
 	return defaultResource.Load(path, prefix...)
 }
 
+// AddFile inserts or replaces a single virtual file <name> with <content> in the default resource object,
+// without invalidating other files.
+func AddFile(name string, content []byte) error {
+	return defaultResource.AddFile(name, content)
+}
+
+// Remove deletes the file with given <name> from the default resource object without affecting other files.
+// If <name> is a directory, all its sub-files are removed along with it.
+func Remove(name string) error {
+	return defaultResource.Remove(name)
+}
+
 // Get returns the file with given path.
 func Get(path string) *File {
 	return defaultResource.Get(path)
@@ -59,6 +71,12 @@ func IsEmpty() bool {
 	return defaultResource.tree.IsEmpty()
 }
 
+// List returns the paths of all packed files in the default resource object,
+// which are sorted in ascending order. Note that it returns only files, exclusive of directories.
+func List() []string {
+	return defaultResource.List()
+}
+
 // ScanDir returns the files under the given path, the parameter <path> should be a folder type.
 //
 // The pattern parameter <pattern> supports multiple file name patterns,
@@ -77,6 +95,14 @@ func ScanDirFile(path string, pattern string, recursive ...bool) []*File {
 	return defaultResource.ScanDirFile(path, pattern, recursive...)
 }
 
+// ScanDirPath returns the paths of files under the given path like ScanDir,
+// which mirrors gfile.ScanDir and the returned paths are sorted in ascending order.
+//
+// It scans directory recursively if given parameter <recursive> is true.
+func ScanDirPath(path string, pattern string, recursive ...bool) []string {
+	return defaultResource.ScanDirPath(path, pattern, recursive...)
+}
+
 // Dump prints the files of the default resource object.
 func Dump() {
 	defaultResource.Dump()
@@ -15,9 +15,10 @@ import (
 )
 
 type File struct {
-	file     *zip.File
-	reader   *bytes.Reader
-	resource *Resource
+	file      *zip.File
+	reader    *bytes.Reader
+	resource  *Resource
+	dirOffset int // Offset of Readdir for directory.
 }
 
 // Name returns the name of the file.
@@ -9,8 +9,10 @@ package gres
 import (
 	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"github.com/ichunt2019/gf/encoding/gbase64"
 	"github.com/ichunt2019/gf/encoding/gcompress"
 	"github.com/ichunt2019/gf/text/gstr"
@@ -46,7 +48,7 @@ func Pack(srcPaths string, keyPrefix ...string) ([]byte, error) {
 	if len(keyPrefix) > 0 && keyPrefix[0] != "" {
 		headerPrefix = keyPrefix[0]
 	}
-	err := zipPathWriter(srcPaths, buffer, headerPrefix)
+	err := zipPathWriter(srcPaths, buffer, false, false, headerPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -54,17 +56,51 @@ func Pack(srcPaths string, keyPrefix ...string) ([]byte, error) {
 	return gcompress.Gzip(buffer.Bytes(), 9)
 }
 
+// PackStream packs the path specified by <srcPaths> and writes the packed content to <dst>
+// as a gzip stream. Each file is encoded incrementally, so that it keeps at most one file's
+// content in memory at a time, which is suitable for packing large directory trees.
+// The packed content is the same as the result of Pack, which can be loaded using Load/Add.
+//
+// The unnecessary parameter <keyPrefix> indicates the prefix for each file
+// packed into the result bytes.
+//
+// Note that parameter <srcPaths> supports multiple paths join with ','.
+func PackStream(srcPaths string, dst io.Writer, keyPrefix ...string) error {
+	headerPrefix := ""
+	if len(keyPrefix) > 0 && keyPrefix[0] != "" {
+		headerPrefix = keyPrefix[0]
+	}
+	return doPackStream(srcPaths, dst, headerPrefix, false, false)
+}
+
+// doPackStream packs the path specified by <srcPaths> and writes the packed content to <dst>.
+// The parameter <deterministic> specifies whether to produce identical output for identical input.
+// The parameter <checksum> specifies whether to embed the SHA-256 checksum of each file.
+func doPackStream(srcPaths string, dst io.Writer, headerPrefix string, deterministic, checksum bool) error {
+	gzipWriter, err := gzip.NewWriterLevel(dst, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if err = zipPathWriter(srcPaths, gzipWriter, deterministic, checksum, headerPrefix); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
 // PackToFile packs the path specified by <srcPaths> to target file <dstPath>.
+// It writes the packed content to <dstPath> in streaming, see PackStream.
+//
 // The unnecessary parameter <keyPrefix> indicates the prefix for each file
 // packed into the result bytes.
 //
 // Note that parameter <srcPaths> supports multiple paths join with ','.
 func PackToFile(srcPaths, dstPath string, keyPrefix ...string) error {
-	data, err := Pack(srcPaths, keyPrefix...)
+	file, err := gfile.Create(dstPath)
 	if err != nil {
 		return err
 	}
-	return gfile.PutBytes(dstPath, data)
+	defer file.Close()
+	return PackStream(srcPaths, file, keyPrefix...)
 }
 
 // PackToGoFile packs the path specified by <srcPaths> to target go file <goFilePath>
@@ -85,6 +121,30 @@ func PackToGoFile(srcPath, goFilePath, pkgName string, keyPrefix ...string) erro
 	)
 }
 
+// PackToGoFileOptions is the options for PackToGoFileWithOptions.
+type PackToGoFileOptions struct {
+	KeyPrefix     string // Prefix for each file packed into the result bytes.
+	Deterministic bool   // Produce bit-for-bit identical output for identical input, which is used for reproducible builds.
+}
+
+// PackToGoFileWithOptions packs the path specified by <srcPaths> to target go file <goFilePath>
+// with given package name <pkgName> and <options>.
+//
+// If <options>.Deterministic is true, all the packed entries use fixed modification time
+// and are sorted alphabetically, so that identical input always produces identical go file.
+//
+// Note that parameter <srcPaths> supports multiple paths join with ','.
+func PackToGoFileWithOptions(srcPaths, goFilePath, pkgName string, options PackToGoFileOptions) error {
+	buffer := bytes.NewBuffer(nil)
+	if err := doPackStream(srcPaths, buffer, options.KeyPrefix, options.Deterministic, false); err != nil {
+		return err
+	}
+	return gfile.PutContents(
+		goFilePath,
+		fmt.Sprintf(gstr.TrimLeft(packedGoSouceTemplate), pkgName, gbase64.EncodeToString(buffer.Bytes())),
+	)
+}
+
 // Unpack unpacks the content specified by <path> to []*File.
 func Unpack(path string) ([]*File, error) {
 	realPath, err := gfile.Search(path)
@@ -98,6 +158,10 @@ func Unpack(path string) ([]*File, error) {
 func UnpackContent(content string) ([]*File, error) {
 	var data []byte
 	var err error
+	if _, ok := encryptedContent(content); ok {
+		// The encrypted content cannot be decoded without key.
+		return nil, errEncryptedWithoutKey
+	}
 	if isHexStr(content) {
 		// It here keeps compatible with old version packing string using hex string.
 		// TODO remove this support in the future.
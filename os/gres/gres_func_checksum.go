@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gres
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// checksumCommentPrefix is the prefix of the checksum embedded in the comment of zip entry.
+	checksumCommentPrefix = "sha256:"
+)
+
+// PackWithChecksums packs the path specified by <srcPaths> into bytes like Pack,
+// which also embeds the SHA-256 checksum of each file into the result bytes,
+// so that Checksum returns the pre-computed checksum without hashing at runtime.
+//
+// The unnecessary parameter <keyPrefix> indicates the prefix for each file
+// packed into the result bytes.
+//
+// Note that parameter <srcPaths> supports multiple paths join with ','.
+func PackWithChecksums(srcPaths string, keyPrefix ...string) ([]byte, error) {
+	headerPrefix := ""
+	if len(keyPrefix) > 0 && keyPrefix[0] != "" {
+		headerPrefix = keyPrefix[0]
+	}
+	buffer := bytes.NewBuffer(nil)
+	if err := doPackStream(srcPaths, buffer, headerPrefix, false, true); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Checksum returns the SHA-256 checksum of the file content with given <name>
+// in the default resource object, see Resource.Checksum.
+func Checksum(name string) ([]byte, error) {
+	return defaultResource.Checksum(name)
+}
+
+// Checksum returns the SHA-256 checksum of the file content with given <name>,
+// which can be used for ETag generation of http file server.
+//
+// It returns the checksum embedded by PackWithChecksums if any,
+// or else it computes the checksum of the file content.
+// It returns error if the <name> does not exist or it is a directory.
+func (r *Resource) Checksum(name string) ([]byte, error) {
+	file := r.Get(name)
+	if file == nil {
+		return nil, errors.New(fmt.Sprintf(`resource file "%s" does not exist`, name))
+	}
+	return file.Checksum()
+}
+
+// Checksum returns the SHA-256 checksum of the file content.
+// It returns error if the file is a directory.
+func (f *File) Checksum() ([]byte, error) {
+	if f.FileInfo().IsDir() {
+		return nil, errors.New(fmt.Sprintf(`resource file "%s" is a directory`, f.Name()))
+	}
+	if strings.HasPrefix(f.file.Comment, checksumCommentPrefix) {
+		if b, err := hex.DecodeString(f.file.Comment[len(checksumCommentPrefix):]); err == nil && len(b) == sha256.Size {
+			return b, nil
+		}
+	}
+	reader, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, reader); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
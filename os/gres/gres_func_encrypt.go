@@ -0,0 +1,138 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gres
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ichunt2019/gf/encoding/gbase64"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+const (
+	// encryptKeySize is the key size of AES-256.
+	encryptKeySize = 32
+)
+
+var (
+	// encryptedMagic is the magic header of the encrypted packed content.
+	encryptedMagic = []byte("GFRES-AES256GCM:")
+
+	// errEncryptedWithoutKey is returned when decoding encrypted content without key.
+	errEncryptedWithoutKey = errors.New("resource content is encrypted, use AddEncrypted with the key to add it")
+)
+
+// PackEncrypted packs the path specified by <srcPaths> into bytes like Pack,
+// and then encrypts the packed bytes with AES-256-GCM using <key>.
+// The parameter <key> should be 32 bytes, and the random nonce is prepended to the ciphertext.
+// The result bytes should be added using AddEncrypted with the same key.
+//
+// The unnecessary parameter <keyPrefix> indicates the prefix for each file
+// packed into the result bytes.
+//
+// Note that parameter <srcPaths> supports multiple paths join with ','.
+func PackEncrypted(srcPaths string, key []byte, keyPrefix ...string) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := Pack(srcPaths, keyPrefix...)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	buffer := bytes.NewBuffer(make([]byte, 0, len(encryptedMagic)+len(nonce)+len(data)+gcm.Overhead()))
+	buffer.Write(encryptedMagic)
+	buffer.Write(nonce)
+	buffer.Write(gcm.Seal(nil, nonce, data, encryptedMagic))
+	return buffer.Bytes(), nil
+}
+
+// AddEncrypted decrypts the <content> packed by PackEncrypted using <key>,
+// and then unpacks and adds it into the default resource object.
+// The unnecessary parameter <prefix> indicates the prefix
+// for each file storing into current resource object.
+func AddEncrypted(content string, key []byte, prefix ...string) error {
+	return defaultResource.AddEncrypted(content, key, prefix...)
+}
+
+// AddEncrypted decrypts the <content> packed by PackEncrypted using <key>,
+// and then unpacks and adds it into current resource object.
+// The <content> can be either the raw bytes or its base64 string.
+// The unnecessary parameter <prefix> indicates the prefix
+// for each file storing into current resource object.
+func (r *Resource) AddEncrypted(content string, key []byte, prefix ...string) error {
+	data, err := decryptContent(content, key)
+	if err != nil {
+		return err
+	}
+	return r.Add(gconv.UnsafeBytesToStr(data), prefix...)
+}
+
+// decryptContent decrypts the encrypted <content> using <key>.
+func decryptContent(content string, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := encryptedContent(content)
+	if !ok {
+		return nil, errors.New("resource content is not encrypted")
+	}
+	data = data[len(encryptedMagic):]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted resource content")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], encryptedMagic)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt resource content failed: %v", err)
+	}
+	return plain, nil
+}
+
+// encryptedContent checks whether <content> is encrypted by its magic header,
+// and returns its raw bytes if so. The <content> can be either the raw bytes or its base64 string.
+func encryptedContent(content string) ([]byte, bool) {
+	if strings.HasPrefix(content, string(encryptedMagic)) {
+		return []byte(content), true
+	}
+	// It decodes only the leading characters for magic header checks of base64 string,
+	// as the base64 content of non-encrypted resource might be large.
+	headerLen := (len(encryptedMagic) + 2) / 3 * 4
+	if len(content) < headerLen || !isBase64(content[:headerLen]) {
+		return nil, false
+	}
+	if b, err := gbase64.DecodeString(content[:headerLen]); err != nil || !bytes.HasPrefix(b, encryptedMagic) {
+		return nil, false
+	}
+	if b, err := gbase64.DecodeString(content); err == nil {
+		return b, true
+	}
+	return nil, false
+}
+
+// newGCM creates and returns the AES-256-GCM cipher with <key>.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != encryptKeySize {
+		return nil, fmt.Errorf("invalid key size %d, AES-256 key should be %d bytes", len(key), encryptKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -8,27 +8,37 @@ package gres
 
 import (
 	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/ichunt2019/gf/internal/fileinfo"
 	"github.com/ichunt2019/gf/internal/intlog"
 	"github.com/ichunt2019/gf/os/gfile"
 	"github.com/ichunt2019/gf/text/gregex"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 )
 
+// deterministicModTime is the fixed modification time of all zip entries for deterministic packing,
+// which is the minimum time of zip format(MS-DOS epoch).
+var deterministicModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
 // ZipPathWriter compresses <paths> to <writer> using zip compressing algorithm.
+// The parameter <deterministic> specifies whether to produce identical output for identical input,
+// which uses fixed modification time and alphabetically sorted entries.
+// The parameter <checksum> specifies whether to embed the SHA-256 checksum of each file.
 // The unnecessary parameter <prefix> indicates the path prefix for zip file.
 //
 // Note that the parameter <paths> can be either a directory or a file, which
 // supports multiple paths join with ','.
-func zipPathWriter(paths string, writer io.Writer, prefix ...string) error {
+func zipPathWriter(paths string, writer io.Writer, deterministic, checksum bool, prefix ...string) error {
 	zipWriter := zip.NewWriter(writer)
 	defer zipWriter.Close()
 	for _, path := range strings.Split(paths, ",") {
 		path = strings.TrimSpace(path)
-		if err := doZipPathWriter(path, "", zipWriter, prefix...); err != nil {
+		if err := doZipPathWriter(path, "", zipWriter, deterministic, checksum, prefix...); err != nil {
 			return err
 		}
 	}
@@ -38,8 +48,10 @@ func zipPathWriter(paths string, writer io.Writer, prefix ...string) error {
 // doZipPathWriter compresses the file of given <path> and writes the content to <zipWriter>.
 // The parameter <exclude> specifies the exclusive file path that is not compressed to <zipWriter>,
 // commonly the destination zip file path.
+// The parameter <deterministic> specifies whether to use fixed modification time and sorted entries.
+// The parameter <checksum> specifies whether to embed the SHA-256 checksum of each file.
 // The unnecessary parameter <prefix> indicates the path prefix for zip file.
-func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix ...string) error {
+func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, deterministic, checksum bool, prefix ...string) error {
 	var (
 		err   error
 		files []string
@@ -56,6 +68,9 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 	} else {
 		files = []string{path}
 	}
+	if deterministic {
+		sort.Strings(files)
+	}
 	headerPrefix := ""
 	if len(prefix) > 0 && prefix[0] != "" {
 		headerPrefix = prefix[0]
@@ -73,19 +88,25 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 			intlog.Printf(`exclude file path: %s`, file)
 			continue
 		}
-		err = zipFile(file, headerPrefix+gfile.Dir(file[len(path):]), zipWriter)
+		err = zipFile(file, headerPrefix+gfile.Dir(file[len(path):]), zipWriter, deterministic, checksum)
 		if err != nil {
 			return err
 		}
 	}
 	// Add all directories to zip archive.
 	if headerPrefix != "" {
-		var name string
+		var (
+			name    string
+			modTime = time.Now()
+		)
+		if deterministic {
+			modTime = deterministicModTime
+		}
 		path = headerPrefix
 		for {
 			name = gfile.Basename(path)
 			err = zipFileVirtual(
-				fileinfo.New(name, 0, os.ModeDir|os.ModePerm, time.Now()), path, zipWriter,
+				fileinfo.New(name, 0, os.ModeDir|os.ModePerm, modTime), path, zipWriter,
 			)
 			if err != nil {
 				return err
@@ -101,7 +122,9 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 
 // zipFile compresses the file of given <path> and writes the content to <zw>.
 // The parameter <prefix> indicates the path prefix for zip file.
-func zipFile(path string, prefix string, zw *zip.Writer) error {
+// The parameter <deterministic> specifies whether to use fixed modification time.
+// The parameter <checksum> specifies whether to embed the SHA-256 checksum of the file, see Checksum.
+func zipFile(path string, prefix string, zw *zip.Writer, deterministic, checksum bool) error {
 	prefix = strings.Replace(prefix, "//", "/", -1)
 	file, err := os.Open(path)
 	if err != nil {
@@ -119,6 +142,19 @@ func zipFile(path string, prefix string, zw *zip.Writer) error {
 	if !info.IsDir() {
 		header.Method = zip.Deflate
 	}
+	if deterministic {
+		header.Modified = deterministicModTime
+	}
+	if checksum && !info.IsDir() {
+		hash := sha256.New()
+		if _, err = io.Copy(hash, file); err != nil {
+			return err
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		header.Comment = checksumCommentPrefix + hex.EncodeToString(hash.Sum(nil))
+	}
 	writer, err := zw.CreateHeader(header)
 	if err != nil {
 		return err
@@ -8,6 +8,7 @@ package gres
 
 import (
 	"bytes"
+	"io"
 	"os"
 )
 
@@ -17,19 +18,34 @@ func (f *File) Close() error {
 }
 
 // Readdir implements Readdir interface of http.File.
+//
+// If <count> > 0, it returns at most <count> file infos from where the previous call left off,
+// and it returns io.EOF if there's no more file in the directory.
+// If <count> <= 0, it returns all the remaining file infos.
 func (f *File) Readdir(count int) ([]os.FileInfo, error) {
 	files := f.resource.ScanDir(f.Name(), "*", false)
-	if len(files) > 0 {
-		if count <= 0 || count > len(files) {
-			count = len(files)
+	if f.dirOffset < len(files) {
+		files = files[f.dirOffset:]
+	} else {
+		files = nil
+	}
+	if count > 0 {
+		if len(files) == 0 {
+			return nil, io.EOF
 		}
-		infos := make([]os.FileInfo, count)
-		for k, v := range files {
-			infos[k] = v.FileInfo()
+		if count < len(files) {
+			files = files[:count]
 		}
-		return infos, nil
 	}
-	return nil, nil
+	f.dirOffset += len(files)
+	if len(files) == 0 {
+		return nil, nil
+	}
+	infos := make([]os.FileInfo, len(files))
+	for k, v := range files {
+		infos[k] = v.FileInfo()
+	}
+	return infos, nil
 }
 
 // Stat implements Stat interface of http.File.
@@ -0,0 +1,61 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gres
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// httpFileSystem implements the http.FileSystem interface over resource object.
+type httpFileSystem struct {
+	resource *Resource
+	prefix   string
+}
+
+// HTTPFileSystem returns the default resource object as http.FileSystem,
+// see Resource.HTTPFileSystem.
+func HTTPFileSystem(prefix ...string) http.FileSystem {
+	return defaultResource.HTTPFileSystem(prefix...)
+}
+
+// HTTPFileSystem returns current resource object as http.FileSystem, which can be used by
+// http.FileServer serving the packed files directly without extracting them to disk.
+//
+// The unnecessary parameter <prefix> specifies the packed directory as root of the file system,
+// eg: "public" for files packed with prefix "public".
+func (r *Resource) HTTPFileSystem(prefix ...string) http.FileSystem {
+	fs := &httpFileSystem{
+		resource: r,
+	}
+	if len(prefix) > 0 {
+		fs.prefix = strings.TrimRight(prefix[0], "/")
+	}
+	return fs
+}
+
+// Open implements the http.FileSystem interface.
+// The returned file is a copy of the packed file, which has its own reading offset,
+// so the same file can be opened and read concurrently.
+func (fs *httpFileSystem) Open(name string) (http.File, error) {
+	name = path.Clean("/" + name)
+	var file *File
+	if fs.prefix != "" {
+		file = fs.resource.Get(fs.prefix + name)
+	} else if file = fs.resource.Get(name); file == nil && name != "/" {
+		file = fs.resource.Get(name[1:])
+	}
+	if file == nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return &File{
+		file:     file.file,
+		resource: file.resource,
+	}, nil
+}
@@ -7,11 +7,17 @@
 package gres
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
+	"github.com/ichunt2019/gf/internal/fileinfo"
 	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/text/gregex"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ichunt2019/gf/os/gtime"
 
@@ -68,6 +74,92 @@ func (r *Resource) Load(path string, prefix ...string) error {
 	return r.Add(gfile.GetContents(realPath), prefix...)
 }
 
+// AddFile inserts or replaces a single virtual file <name> with <content> in current resource object,
+// without invalidating other files. The parent directories of <name> are added automatically
+// if they do not exist. It is commonly used for runtime-generated resources, eg: rendered templates
+// stored in memory, which coexist with the compile-time packed files.
+func (r *Resource) AddFile(name string, content []byte) error {
+	name = strings.Replace(name, "\\", "/", -1)
+	name, _ = gregex.ReplaceString(`/{2,}`, `/`, name)
+	name = strings.TrimRight(name, "/")
+	if name == "" {
+		return errors.New("resource file name cannot be empty")
+	}
+	var (
+		now       = time.Now()
+		buffer    = bytes.NewBuffer(nil)
+		zipWriter = zip.NewWriter(buffer)
+	)
+	// Parent directories that do not exist.
+	for dir := gfile.Dir(name); dir != "." && dir != "/" && dir != "" && !r.Contains(dir); dir = gfile.Dir(dir) {
+		if err := zipFileVirtual(fileinfo.New(gfile.Basename(dir), 0, os.ModeDir|os.ModePerm, now), dir, zipWriter); err != nil {
+			return err
+		}
+	}
+	header, err := createFileHeader(fileinfo.New(gfile.Basename(name), int64(len(content)), 0666, now), "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(content); err != nil {
+		return err
+	}
+	if err = zipWriter.Close(); err != nil {
+		return err
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		return err
+	}
+	for _, file := range reader.File {
+		r.tree.Set(file.Name, &File{
+			file:     file,
+			resource: r,
+		})
+	}
+	return nil
+}
+
+// Remove deletes the file with given <name> from current resource object without
+// affecting other files, which is commonly used for hot-reloading resources that are
+// re-packed and re-added at runtime. If <name> is a directory, all its sub-files are
+// removed along with it.
+//
+// It returns error if <name> does not exist in current resource object.
+func (r *Resource) Remove(name string) error {
+	file := r.Get(name)
+	if file == nil {
+		return errors.New(fmt.Sprintf(`resource file "%s" does not exist`, name))
+	}
+	name = strings.Replace(name, "\\", "/", -1)
+	name = strings.Replace(name, "//", "/", -1)
+	if name != "/" {
+		name = strings.TrimRight(name, "/")
+	}
+	names := []interface{}{name}
+	if file.FileInfo().IsDir() {
+		dirPrefix := strings.TrimRight(name, "/") + "/"
+		r.tree.IteratorFrom(name, true, func(key, value interface{}) bool {
+			// Keys like "/i18n-dir" are sorted between "/i18n" and "/i18n/", so it iterates
+			// until the prefix does not match.
+			if !strings.HasPrefix(key.(string), name) {
+				return false
+			}
+			if strings.HasPrefix(key.(string), dirPrefix) {
+				names = append(names, key)
+			}
+			return true
+		})
+	}
+	r.tree.Removes(names)
+	return nil
+}
+
 // Get returns the file with given path.
 func (r *Resource) Get(path string) *File {
 	if path == "" {
@@ -133,6 +225,21 @@ func (r *Resource) IsEmpty() bool {
 	return r.tree.IsEmpty()
 }
 
+// List returns the paths of all packed files in current resource object,
+// which are sorted in ascending order. Note that it returns only files, exclusive of directories.
+//
+// It is commonly used for generating indexes of the packed static assets.
+func (r *Resource) List() []string {
+	paths := make([]string, 0)
+	r.tree.Iterator(func(key, value interface{}) bool {
+		if !value.(*File).FileInfo().IsDir() {
+			paths = append(paths, key.(string))
+		}
+		return true
+	})
+	return paths
+}
+
 // ScanDir returns the files under the given path, the parameter <path> should be a folder type.
 //
 // The pattern parameter <pattern> supports multiple file name patterns,
@@ -146,7 +253,8 @@ func (r *Resource) ScanDir(path string, pattern string, recursive ...bool) []*Fi
 	if len(recursive) > 0 {
 		isRecursive = recursive[0]
 	}
-	return r.doScanDir(path, pattern, isRecursive, false)
+	files, _ := r.doScanDir(path, pattern, isRecursive, false)
+	return files
 }
 
 // ScanDirFile returns all sub-files with absolute paths of given <path>,
@@ -158,17 +266,34 @@ func (r *Resource) ScanDirFile(path string, pattern string, recursive ...bool) [
 	if len(recursive) > 0 {
 		isRecursive = recursive[0]
 	}
-	return r.doScanDir(path, pattern, isRecursive, true)
+	files, _ := r.doScanDir(path, pattern, isRecursive, true)
+	return files
+}
+
+// ScanDirPath returns the paths of files under the given path like ScanDir,
+// which mirrors gfile.ScanDir and the returned paths are sorted in ascending order.
+//
+// The pattern parameter <pattern> supports multiple file name patterns,
+// using the ',' symbol to separate multiple patterns.
+//
+// It scans directory recursively if given parameter <recursive> is true.
+func (r *Resource) ScanDirPath(path string, pattern string, recursive ...bool) []string {
+	isRecursive := false
+	if len(recursive) > 0 {
+		isRecursive = recursive[0]
+	}
+	_, paths := r.doScanDir(path, pattern, isRecursive, false)
+	return paths
 }
 
 // doScanDir is an internal method which scans directory
-// and returns the absolute path list of files that are not sorted.
+// and returns the files along with their absolute paths in the resource object.
 //
 // The pattern parameter <pattern> supports multiple file name patterns,
 // using the ',' symbol to separate multiple patterns.
 //
 // It scans directory recursively if given parameter <recursive> is true.
-func (r *Resource) doScanDir(path string, pattern string, recursive bool, onlyFile bool) []*File {
+func (r *Resource) doScanDir(path string, pattern string, recursive bool, onlyFile bool) ([]*File, []string) {
 	path = strings.Replace(path, "\\", "/", -1)
 	path = strings.Replace(path, "//", "/", -1)
 	if path != "/" {
@@ -179,6 +304,7 @@ func (r *Resource) doScanDir(path string, pattern string, recursive bool, onlyFi
 	var (
 		name     = ""
 		files    = make([]*File, 0)
+		paths    = make([]string, 0)
 		length   = len(path)
 		patterns = strings.Split(pattern, ",")
 	)
@@ -216,12 +342,13 @@ func (r *Resource) doScanDir(path string, pattern string, recursive bool, onlyFi
 		for _, p := range patterns {
 			if match, err := filepath.Match(p, gfile.Basename(name)); err == nil && match {
 				files = append(files, value.(*File))
+				paths = append(paths, name)
 				return true
 			}
 		}
 		return true
 	})
-	return files
+	return files, paths
 }
 
 // Dump prints the files of current resource object.
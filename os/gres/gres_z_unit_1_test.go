@@ -7,9 +7,21 @@
 package gres_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"github.com/ichunt2019/gf/encoding/gbase64"
 	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/text/gregex"
+	"github.com/ichunt2019/gf/text/gstr"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ichunt2019/gf/os/gfile"
 
@@ -58,6 +70,359 @@ func Test_PackToFile(t *testing.T) {
 	})
 }
 
+func Test_PackStream(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			srcPath = gdebug.TestDataPath("files")
+			buffer  = bytes.NewBuffer(nil)
+		)
+		err := gres.PackStream(srcPath, buffer)
+		t.Assert(err, nil)
+
+		data, err := gres.Pack(srcPath)
+		t.Assert(err, nil)
+		streamFiles, err := gres.UnpackContent(buffer.String())
+		t.Assert(err, nil)
+		packFiles, err := gres.UnpackContent(string(data))
+		t.Assert(err, nil)
+		t.Assert(len(streamFiles), len(packFiles))
+
+		r := gres.New()
+		err = r.Add(buffer.String())
+		t.Assert(err, nil)
+		t.Assert(r.Contains("files/"), true)
+		t.Assert(r.Get("files/root/image/logo.png").Content(), gfile.GetBytes(gfile.Join(srcPath, "root", "image", "logo.png")))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gres.PackStream("/none-exist-path", bytes.NewBuffer(nil))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_PackEncrypted(t *testing.T) {
+	var (
+		srcPath = gdebug.TestDataPath("files")
+		key     = []byte("0123456789abcdef0123456789abcdef")
+	)
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gres.PackEncrypted(srcPath, key)
+		t.Assert(err, nil)
+		t.Assert(bytes.Contains(data, []byte("config.toml")), false)
+
+		// Refuses to decode without key.
+		r := gres.New()
+		t.AssertNE(r.Add(string(data)), nil)
+		t.AssertNE(r.Add(gbase64.EncodeToString(data)), nil)
+		t.Assert(r.Contains("files/"), false)
+
+		// Wrong key.
+		t.AssertNE(r.AddEncrypted(string(data), []byte("0123456789abcdef0123456789abcdeX")), nil)
+		t.Assert(r.Contains("files/"), false)
+
+		// Raw bytes.
+		t.Assert(r.AddEncrypted(string(data), key), nil)
+		t.Assert(r.Contains("files/"), true)
+		t.Assert(r.GetContent("files/config/config.toml"), gfile.GetBytes(gfile.Join(srcPath, "config", "config.toml")))
+
+		// Base64 string.
+		r = gres.New()
+		t.Assert(r.AddEncrypted(gbase64.EncodeToString(data), key), nil)
+		t.Assert(r.Contains("files/"), true)
+	})
+	// Load refuses encrypted file.
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gres.PackEncrypted(srcPath, key)
+		t.Assert(err, nil)
+		dstPath := gfile.TempDir(gtime.TimestampNanoStr())
+		t.Assert(gfile.PutBytes(dstPath, data), nil)
+		defer gfile.Remove(dstPath)
+		t.AssertNE(gres.New().Load(dstPath), nil)
+	})
+	// Invalid key size.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gres.PackEncrypted(srcPath, []byte("short"))
+		t.AssertNE(err, nil)
+		t.AssertNE(gres.New().AddEncrypted("content", []byte("short")), nil)
+	})
+	// Not encrypted content.
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gres.Pack(srcPath)
+		t.Assert(err, nil)
+		t.AssertNE(gres.New().AddEncrypted(string(data), key), nil)
+	})
+}
+
+func Test_List(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.List(), []string{})
+		t.Assert(r.Add(string(data), "/prefix/"), nil)
+
+		list := r.List()
+		t.Assert(sort.StringsAreSorted(list), true)
+		t.Assert(len(list), len(r.ScanDirFile("/prefix/files", "*", true)))
+		t.Assert(gstr.InArray(list, "/prefix/files/config/config.toml"), true)
+		t.Assert(gstr.InArray(list, "/prefix/files/config"), false)
+	})
+}
+
+func Test_ScanDirPath(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.Add(string(data)), nil)
+		t.Assert(r.ScanDirPath("files/config", "*"), []string{
+			"files/config/config.toml",
+			"files/config/my.ini",
+		})
+		t.Assert(r.ScanDirPath("files/root", "*.css,*.png", true), []string{
+			"files/root/css/style.css",
+			"files/root/image/logo.png",
+		})
+		t.Assert(r.ScanDirPath("files/none", "*", true), []string{})
+
+		// Containing directories.
+		paths := r.ScanDirPath("files/root", "*")
+		t.Assert(gstr.InArray(paths, "files/root/css"), true)
+		t.Assert(gstr.InArray(paths, "files/root/index.html"), true)
+	})
+}
+
+func Test_AddFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.Add(string(data)), nil)
+		count := len(r.List())
+
+		// Insert.
+		t.Assert(r.AddFile("files/root/rendered.html", []byte("rendered")), nil)
+		t.Assert(r.GetContent("files/root/rendered.html"), "rendered")
+		t.Assert(r.Get("files/root/rendered.html").FileInfo().IsDir(), false)
+		t.Assert(r.Get("files/root/rendered.html").FileInfo().Size(), 8)
+		t.Assert(len(r.List()), count+1)
+		t.Assert(gstr.InArray(r.ScanDirPath("files/root", "*.html"), "files/root/rendered.html"), true)
+
+		// Replace.
+		t.Assert(r.AddFile("files/config/config.toml", []byte("replaced")), nil)
+		t.Assert(r.GetContent("files/config/config.toml"), "replaced")
+		t.Assert(r.GetContent("files/config/my.ini"), gfile.GetBytes(gfile.Join(srcPath, "config", "my.ini")))
+		t.Assert(len(r.List()), count+1)
+
+		// Parent directories are added automatically.
+		t.Assert(r.AddFile("/generated//sub/file.txt", []byte("generated")), nil)
+		t.Assert(r.Get("/generated").FileInfo().IsDir(), true)
+		t.Assert(r.Get("/generated/sub").FileInfo().IsDir(), true)
+		t.Assert(r.ScanDirPath("/generated", "*", true), []string{"/generated/sub", "/generated/sub/file.txt"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gres.New().AddFile("", []byte("content")), nil)
+		t.AssertNE(gres.New().AddFile("/", []byte("content")), nil)
+	})
+}
+
+func Test_Remove(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.Add(string(data)), nil)
+		count := len(r.List())
+
+		// File.
+		t.Assert(r.Remove("files/config/config.toml"), nil)
+		t.Assert(r.Contains("files/config/config.toml"), false)
+		t.Assert(r.Contains("files/config/my.ini"), true)
+		t.Assert(len(r.List()), count-1)
+		t.AssertNE(r.Remove("files/config/config.toml"), nil)
+
+		// Directory with its sub-files, which does not affect the directory with same prefix.
+		i18nDirCount := len(r.ScanDirPath("files/i18n-dir", "*", true))
+		t.Assert(r.Remove("files/i18n/"), nil)
+		t.Assert(r.Contains("files/i18n"), false)
+		t.Assert(r.ScanDirPath("files/i18n", "*", true), []string{})
+		t.Assert(r.Contains("files/i18n-dir"), true)
+		t.Assert(len(r.ScanDirPath("files/i18n-dir", "*", true)), i18nDirCount)
+
+		// Re-adding.
+		t.Assert(r.AddFile("files/config/config.toml", []byte("reloaded")), nil)
+		t.Assert(r.GetContent("files/config/config.toml"), "reloaded")
+	})
+}
+
+func Test_Checksum(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		content := gfile.GetBytes(gfile.Join(srcPath, "config", "config.toml"))
+		expect := sha256.Sum256(content)
+
+		data1, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+		data2, err := gres.PackWithChecksums(srcPath, "files")
+		t.Assert(err, nil)
+
+		r1 := gres.New()
+		t.Assert(r1.Add(string(data1)), nil)
+		r2 := gres.New()
+		t.Assert(r2.Add(string(data2)), nil)
+		t.Assert(r2.List(), r1.List())
+		t.Assert(r2.GetContent("files/config/config.toml"), content)
+
+		// Computed at runtime.
+		checksum, err := r1.Checksum("files/config/config.toml")
+		t.Assert(err, nil)
+		t.Assert(checksum, expect[:])
+
+		// Pre-computed on packing.
+		checksum, err = r2.Checksum("files/config/config.toml")
+		t.Assert(err, nil)
+		t.Assert(checksum, expect[:])
+
+		for _, name := range r2.List() {
+			checksum1, err := r1.Checksum(name)
+			t.Assert(err, nil)
+			checksum2, err := r2.Checksum(name)
+			t.Assert(err, nil)
+			t.Assert(checksum1, checksum2)
+		}
+
+		_, err = r2.Checksum("files/config")
+		t.AssertNE(err, nil)
+		_, err = r2.Checksum("files/none")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_HTTPFileSystem(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.Add(string(data)), nil)
+
+		s := httptest.NewServer(http.FileServer(r.HTTPFileSystem("files")))
+		defer s.Close()
+
+		resp, err := http.Get(s.URL + "/config/config.toml")
+		t.Assert(err, nil)
+		content, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(content, gfile.GetBytes(gfile.Join(srcPath, "config", "config.toml")))
+
+		resp, err = http.Get(s.URL + "/config/")
+		t.Assert(err, nil)
+		content, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(gstr.Contains(string(content), "my.ini"), true)
+
+		resp, err = http.Get(s.URL + "/none")
+		t.Assert(err, nil)
+		resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := gdebug.TestDataPath("files")
+		data, err := gres.Pack(srcPath, "files")
+		t.Assert(err, nil)
+
+		r := gres.New()
+		t.Assert(r.Add(string(data)), nil)
+		fs := r.HTTPFileSystem()
+
+		_, err = fs.Open("/none")
+		t.Assert(os.IsNotExist(err), true)
+
+		// Stat and Read.
+		file, err := fs.Open("/files/dir1/test1")
+		t.Assert(err, nil)
+		info, err := file.Stat()
+		t.Assert(err, nil)
+		t.Assert(info.IsDir(), false)
+		content, err := ioutil.ReadAll(file)
+		t.Assert(err, nil)
+		t.Assert(int64(len(content)), info.Size())
+		t.Assert(file.Close(), nil)
+
+		// Readdir in batches.
+		dir, err := fs.Open("files/config/../config")
+		t.Assert(err, nil)
+		infos, err := dir.Readdir(1)
+		t.Assert(err, nil)
+		t.Assert(len(infos), 1)
+		infos, err = dir.Readdir(-1)
+		t.Assert(err, nil)
+		t.Assert(len(infos), 1)
+		infos, err = dir.Readdir(1)
+		t.Assert(err, io.EOF)
+		t.Assert(len(infos), 0)
+	})
+}
+
+func Test_PackToGoFileWithOptions(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			tempPath = gfile.TempDir(gtime.TimestampNanoStr())
+			srcPath  = gfile.Join(tempPath, "files")
+			goFile1  = gfile.Join(tempPath, "data1.go")
+			goFile2  = gfile.Join(tempPath, "data2.go")
+			options  = gres.PackToGoFileOptions{
+				KeyPrefix:     "www",
+				Deterministic: true,
+			}
+		)
+		defer gfile.Remove(tempPath)
+		t.Assert(gfile.CopyDir(gdebug.TestDataPath("files"), srcPath), nil)
+
+		t.Assert(gres.PackToGoFileWithOptions(srcPath, goFile1, "data", options), nil)
+		// Changes the modification time of the source files.
+		mtime := time.Now().Add(time.Hour)
+		t.Assert(os.Chtimes(gfile.Join(srcPath, "config", "config.toml"), mtime, mtime), nil)
+		t.Assert(os.Chtimes(gfile.Join(srcPath, "root"), mtime, mtime), nil)
+		time.Sleep(time.Second)
+		t.Assert(gres.PackToGoFileWithOptions(srcPath, goFile2, "data", options), nil)
+		t.Assert(gfile.GetContents(goFile1) != "", true)
+		t.Assert(gfile.GetContents(goFile1), gfile.GetContents(goFile2))
+
+		// Non-deterministic packing.
+		options.Deterministic = false
+		t.Assert(gres.PackToGoFileWithOptions(srcPath, goFile2, "data", options), nil)
+		t.AssertNE(gfile.GetContents(goFile1), gfile.GetContents(goFile2))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The deterministic packed content can be added as usual.
+		var (
+			srcPath = gdebug.TestDataPath("files")
+			goFile  = gfile.TempDir(gtime.TimestampNanoStr(), "data.go")
+			content = gfile.GetContents(gfile.Join(srcPath, "config", "config.toml"))
+		)
+		defer gfile.Remove(gfile.Dir(goFile))
+		t.Assert(gres.PackToGoFileWithOptions(srcPath, goFile, "data", gres.PackToGoFileOptions{Deterministic: true}), nil)
+		match, err := gregex.MatchString(`gres.Add\("(.+?)"\)`, gfile.GetContents(goFile))
+		t.Assert(err, nil)
+		t.Assert(len(match), 2)
+		r := gres.New()
+		t.Assert(r.Add(match[1]), nil)
+		t.Assert(r.GetContent("files/config/config.toml"), content)
+		t.Assert(r.Get("files/config/config.toml").FileInfo().ModTime().Year(), 1980)
+	})
+}
+
 func Test_PackMulti(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		srcPath := gdebug.TestDataPath("files")
@@ -17,10 +17,16 @@ import (
 
 // Goroutine Pool
 type Pool struct {
-	limit  int         // Max goroutine count limit.
-	count  *gtype.Int  // Current running goroutine count.
-	list   *glist.List // Job list for asynchronous job adding purpose.
-	closed *gtype.Bool // Is pool closed or not.
+	limit   int              // Max goroutine count limit.
+	count   *gtype.Int       // Current running goroutine count.
+	list    *glist.List      // Job list for asynchronous job adding purpose.
+	queue   *priorityQueue   // Priority job queue, which replaces the job list if the pool is created by NewWithPriority.
+	closed  *gtype.Bool      // Is pool closed or not.
+	stats   poolStats        // Statistics counters.
+	elastic *elasticConfig   // Elastic configuration, which is nil if the pool is not created by NewElastic.
+	handler *gtype.Interface // Panic handler of jobs, see SetPanicHandler.
+	slots   chan struct{}    // Queue space of jobs, which is nil if the queue is not limited, see SetQueueCap.
+	done    chan struct{}    // Closed when the pool is closed.
 }
 
 // Default goroutine pool.
@@ -31,10 +37,13 @@ var pool = New()
 // which is not limited in default.
 func New(limit ...int) *Pool {
 	p := &Pool{
-		limit:  -1,
-		count:  gtype.NewInt(),
-		list:   glist.New(true),
-		closed: gtype.NewBool(),
+		limit:   -1,
+		count:   gtype.NewInt(),
+		list:    glist.New(true),
+		closed:  gtype.NewBool(),
+		stats:   newPoolStats(),
+		handler: gtype.NewInterface(),
+		done:    make(chan struct{}),
 	}
 	if len(limit) > 0 && limit[0] > 0 {
 		p.limit = limit[0]
@@ -68,11 +77,30 @@ func Jobs() int {
 
 // Add pushes a new job to the pool.
 // The job will be executed asynchronously.
+// It returns ErrQueueFull if the queue of the pool is full, see SetQueueCap.
 func (p *Pool) Add(f func()) error {
+	if !p.acquireSlot() {
+		return ErrQueueFull
+	}
+	return p.addSlotJob(f, 0)
+}
+
+// addJob pushes a new job with <priority> to the job list or priority queue,
+// and forks a new goroutine worker if the goroutine count does not exceed the limit.
+func (p *Pool) addJob(f func(), priority int) error {
 	for p.closed.Val() {
-		return errors.New("pool closed")
+		return errPoolClosed
+	}
+	if p.queue != nil {
+		p.queue.Push(f, priority)
+	} else {
+		p.list.PushFront(f)
+	}
+	p.stats.queued.Add(1)
+	// The idle goroutine of elastic pool takes the job.
+	if p.elastic != nil && p.wakeIdle() {
+		return nil
 	}
-	p.list.PushFront(f)
 	// Check whether fork new goroutine or not.
 	var n int
 	for {
@@ -98,6 +126,7 @@ func (p *Pool) AddWithRecover(userFunc func(), recoverFunc ...func(err error)) e
 	return p.Add(func() {
 		defer func() {
 			if err := recover(); err != nil {
+				p.stats.errors.Add(1)
 				if len(recoverFunc) > 0 && recoverFunc[0] != nil {
 					recoverFunc[0](errors.New(fmt.Sprintf(`%v`, err)))
 				}
@@ -107,6 +136,15 @@ func (p *Pool) AddWithRecover(userFunc func(), recoverFunc ...func(err error)) e
 	})
 }
 
+// SetPanicHandler sets the panic handler <fn> of the pool, which recovers the panics of jobs,
+// so that the goroutine worker survives and continues executing the following jobs.
+// The <fn> is called with the panicking job function as <task> and the recovered value.
+//
+// The panic of job crashes the program if the panic handler is not set.
+func (p *Pool) SetPanicHandler(fn func(task interface{}, recovered interface{})) {
+	p.handler.Set(fn)
+}
+
 // Cap returns the capacity of the pool.
 // This capacity is defined when pool is created.
 // It returns -1 if there's no limit.
@@ -122,19 +160,38 @@ func (p *Pool) Size() int {
 // Jobs returns current job count of the pool.
 // Note that, it does not return worker/goroutine count but the job/task count.
 func (p *Pool) Jobs() int {
+	if p.queue != nil {
+		return p.queue.Size()
+	}
 	return p.list.Size()
 }
 
+// popJob pops and returns the next job from the job list or priority queue,
+// or nil if there's no job.
+func (p *Pool) popJob() func() {
+	if p.queue != nil {
+		return p.queue.Pop()
+	}
+	if job := p.list.PopBack(); job != nil {
+		return job.(func())
+	}
+	return nil
+}
+
 // fork creates a new goroutine worker.
-// Note that the worker dies if the job function panics.
+// Note that the worker dies if the job function panics without panic handler.
 func (p *Pool) fork() {
+	if p.elastic != nil {
+		p.forkElastic()
+		return
+	}
 	go func() {
 		defer p.count.Add(-1)
 
-		var job interface{}
+		var job func()
 		for !p.closed.Val() {
-			if job = p.list.PopBack(); job != nil {
-				job.(func())()
+			if job = p.popJob(); job != nil {
+				p.runJob(job)
 			} else {
 				return
 			}
@@ -142,6 +199,23 @@ func (p *Pool) fork() {
 	}()
 }
 
+// runJob executes <job> and updates the statistics.
+// The panic of <job> is recovered and passed to the panic handler if it is set.
+func (p *Pool) runJob(job func()) {
+	p.stats.queued.Add(-1)
+	p.stats.active.Add(1)
+	defer func() {
+		p.stats.active.Add(-1)
+		p.stats.processed.Add(1)
+		if p.handler.Val() != nil {
+			if e := recover(); e != nil {
+				p.handlePanic(job, e)
+			}
+		}
+	}()
+	job()
+}
+
 // IsClosed returns if pool is closed.
 func (p *Pool) IsClosed() bool {
 	return p.closed.Val()
@@ -149,5 +223,7 @@ func (p *Pool) IsClosed() bool {
 
 // Close closes the goroutine pool, which makes all goroutines exit.
 func (p *Pool) Close() {
-	p.closed.Set(true)
+	if p.closed.Cas(false, true) {
+		close(p.done)
+	}
 }
@@ -9,6 +9,8 @@
 package grpool_test
 
 import (
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/ichunt2019/gf/os/grpool"
@@ -30,3 +32,62 @@ func BenchmarkGoroutine_1(b *testing.B) {
 		go increment()
 	}
 }
+
+// The CPU-bound jobs on the CPU pool should outperform the ones on the IO pool,
+// as there's less context switching among the goroutines.
+func BenchmarkSeparatePool_CPUBoundOnCPU(b *testing.B) {
+	benchmarkSeparatePool(b, true)
+}
+
+func BenchmarkSeparatePool_CPUBoundOnIO(b *testing.B) {
+	benchmarkSeparatePool(b, false)
+}
+
+func benchmarkSeparatePool(b *testing.B, onCPU bool) {
+	var (
+		wg = sync.WaitGroup{}
+		p  = grpool.NewSeparatePool(0, 0)
+	)
+	defer p.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		job := func() {
+			increment()
+			wg.Done()
+		}
+		if onCPU {
+			p.SubmitCPU(job)
+		} else {
+			p.SubmitIO(job)
+		}
+	}
+	wg.Wait()
+}
+
+// The priority pool costs more than the FIFO pool for maintaining the heap of jobs.
+func BenchmarkPool_FIFO(b *testing.B) {
+	benchmarkPool(b, grpool.New(runtime.NumCPU()), false)
+}
+
+func BenchmarkPool_Priority(b *testing.B) {
+	benchmarkPool(b, grpool.NewWithPriority(runtime.NumCPU()), true)
+}
+
+func benchmarkPool(b *testing.B, p *grpool.Pool, withPriority bool) {
+	wg := sync.WaitGroup{}
+	defer p.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		job := func() {
+			wg.Done()
+		}
+		if withPriority {
+			p.AddWithPriority(job, i%10)
+		} else {
+			p.Add(job)
+		}
+	}
+	wg.Wait()
+}
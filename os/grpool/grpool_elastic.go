@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gtype"
+)
+
+// elasticConfig is the configuration and state of elastic pool.
+type elasticConfig struct {
+	min         int           // Min goroutine count kept alive even if they're idle.
+	idleTimeout time.Duration // Idle duration after which the goroutine above <min> exits.
+	idle        *gtype.Int    // Current idle goroutine count waiting for jobs.
+	notify      chan struct{} // Notification channel of new jobs for idle goroutines.
+}
+
+const (
+	// defaultElasticIdleTimeout is the default idle timeout of elastic pool.
+	defaultElasticIdleTimeout = time.Minute
+)
+
+// NewElastic creates and returns an elastic goroutine pool object, which keeps at least <min>
+// goroutine workers and spawns new workers up to <max> when the jobs grow.
+// The idle workers above <min> exit after <idleTimeout>, which is one minute if it is <= 0.
+//
+// It reduces the goroutine creating and destroying costs for bursty workloads,
+// as the idle workers wait for new jobs instead of exiting immediately like other pools.
+func NewElastic(min, max int, idleTimeout time.Duration) *Pool {
+	if min < 0 {
+		min = 0
+	}
+	if max <= 0 || min > max {
+		panic(fmt.Sprintf(`invalid elastic pool size, min: %d, max: %d`, min, max))
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = defaultElasticIdleTimeout
+	}
+	p := New(max)
+	p.elastic = &elasticConfig{
+		min:         min,
+		idleTimeout: idleTimeout,
+		idle:        gtype.NewInt(),
+		notify:      make(chan struct{}, max),
+	}
+	p.count.Set(min)
+	for i := 0; i < min; i++ {
+		p.fork()
+	}
+	return p
+}
+
+// wakeIdle notifies the idle workers of new job, it returns true if there're idle workers.
+func (p *Pool) wakeIdle() bool {
+	select {
+	case p.elastic.notify <- struct{}{}:
+	default:
+	}
+	return p.elastic.idle.Val() > 0
+}
+
+// forkElastic creates a new goroutine worker of elastic pool, which waits for new jobs
+// when it is idle, and exits if it is idle for idle timeout and the worker count is above min.
+func (p *Pool) forkElastic() {
+	go func() {
+		var (
+			job   func()
+			timer = time.NewTimer(p.elastic.idleTimeout)
+		)
+		defer timer.Stop()
+		for !p.closed.Val() {
+			if job = p.popJob(); job != nil {
+				p.runJob(job)
+				continue
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(p.elastic.idleTimeout)
+			p.elastic.idle.Add(1)
+			select {
+			case <-p.elastic.notify:
+				p.elastic.idle.Add(-1)
+				continue
+			case <-p.done:
+				// The pool is closed, which exits the loop.
+				p.elastic.idle.Add(-1)
+				continue
+			case <-timer.C:
+				p.elastic.idle.Add(-1)
+			}
+			if p.Jobs() > 0 || !p.shrinkElastic() {
+				continue
+			}
+			// Re-check the jobs which might be added during exiting.
+			if p.Jobs() == 0 || !p.growElastic() {
+				return
+			}
+		}
+		p.count.Add(-1)
+	}()
+}
+
+// shrinkElastic decreases the worker count if it is above min, it returns false if it cannot shrink.
+func (p *Pool) shrinkElastic() bool {
+	for {
+		n := p.count.Val()
+		if n <= p.elastic.min {
+			return false
+		}
+		if p.count.Cas(n, n-1) {
+			return true
+		}
+	}
+}
+
+// growElastic increases the worker count if it is below max, it returns false if it cannot grow.
+func (p *Pool) growElastic() bool {
+	for {
+		n := p.count.Val()
+		if n >= p.limit {
+			return false
+		}
+		if p.count.Cas(n, n+1) {
+			return true
+		}
+	}
+}
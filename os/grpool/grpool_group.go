@@ -0,0 +1,141 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TaskGroup is a group of jobs running in the pool with sync.WaitGroup semantics,
+// which waits for all the jobs done and collects all their errors.
+type TaskGroup struct {
+	pool   *Pool          // Pool running the jobs.
+	wg     sync.WaitGroup // Wait group for all the jobs.
+	mu     sync.Mutex     // Mutex for concurrent safety of errors.
+	errors []error        // Errors returned by the jobs.
+}
+
+// MultiError is the error collecting all the errors returned by jobs of TaskGroup.
+type MultiError []error
+
+// Group creates and returns a TaskGroup using default goroutine pool.
+func Group() *TaskGroup {
+	return pool.Group()
+}
+
+// Group creates and returns a TaskGroup, the jobs of which run in current pool.
+func (p *Pool) Group() *TaskGroup {
+	return &TaskGroup{
+		pool: p,
+	}
+}
+
+// Add pushes a new job <f> of the group to the pool, which will be executed asynchronously.
+// The error returned by <f> is collected, and the panic of <f> is recovered and collected as error.
+// It is also collected as error if the job cannot be added, eg: the pool is closed.
+func (g *TaskGroup) Add(f func() error) *TaskGroup {
+	g.wg.Add(1)
+	err := g.pool.Add(func() {
+		defer g.wg.Done()
+		defer func() {
+			if e := recover(); e != nil {
+				g.pool.stats.errors.Add(1)
+				g.addError(fmt.Errorf(`%v`, e))
+			}
+		}()
+		if err := f(); err != nil {
+			g.pool.stats.errors.Add(1)
+			g.addError(err)
+		}
+	})
+	if err != nil {
+		g.addError(err)
+		g.wg.Done()
+	}
+	return g
+}
+
+// Wait blocks until all the jobs of the group are done.
+// It returns a MultiError containing all the errors of the jobs,
+// or nil if all the jobs succeed.
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errors) == 0 {
+		return nil
+	}
+	errs := make(MultiError, len(g.errors))
+	copy(errs, g.errors)
+	return errs
+}
+
+// WaitContext blocks until all the jobs of the group are done or <ctx> is done.
+// It returns the error of <ctx> if <ctx> is done before all the jobs are done,
+// note that the running jobs are not cancelled.
+func (g *TaskGroup) WaitContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// addError adds <err> to the errors of the group.
+func (g *TaskGroup) addError(err error) {
+	g.mu.Lock()
+	g.errors = append(g.errors, err)
+	g.mu.Unlock()
+}
+
+// Error implements the error interface, which joins all the error messages.
+func (e MultiError) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf(`%d errors occurred: %s`, len(e), strings.Join(messages, "; "))
+}
+
+// Barrier blocks until all the jobs added to it are done, which is like sync.WaitGroup
+// but for jobs of the pool. It is the TaskGroup for jobs without returned error.
+type Barrier struct {
+	group *TaskGroup // Task group running the jobs.
+}
+
+// Barrier creates and returns a Barrier, the jobs of which run in current pool.
+func (p *Pool) Barrier() *Barrier {
+	return &Barrier{
+		group: p.Group(),
+	}
+}
+
+// Add pushes a new job <f> of the barrier to the pool, which will be executed asynchronously.
+// The panic of <f> is recovered and collected as error, and it is also collected as error
+// if the job cannot be added, eg: the pool is closed.
+func (b *Barrier) Add(f func()) *Barrier {
+	b.group.Add(func() error {
+		f()
+		return nil
+	})
+	return b
+}
+
+// Wait blocks until all the jobs added to the barrier are done.
+// It returns a MultiError containing all the errors of the jobs,
+// or nil if all the jobs succeed.
+func (b *Barrier) Wait() error {
+	return b.group.Wait()
+}
@@ -0,0 +1,114 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// priorityJob is the job item of priorityQueue.
+type priorityJob struct {
+	job      func() // Job function.
+	priority int    // Job priority, the higher priority job is executed first.
+	seq      uint64 // Adding sequence, which keeps FIFO order among jobs of the same priority.
+}
+
+// priorityQueue is a concurrent-safe job queue ordered by priority,
+// which is FIFO among jobs of the same priority.
+type priorityQueue struct {
+	mu   sync.Mutex
+	jobs priorityJobs
+	seq  uint64
+}
+
+// priorityJobs implements heap.Interface for priority jobs.
+type priorityJobs []*priorityJob
+
+// NewWithPriority creates and returns a new goroutine pool object, the jobs of which are executed
+// in priority order instead of FIFO order, see AddWithPriority.
+// The parameter <size> is used to limit the max goroutine count, which is not limited if <size> <= 0.
+func NewWithPriority(size int) *Pool {
+	p := New(size)
+	p.queue = newPriorityQueue()
+	return p
+}
+
+// AddWithPriority pushes a new job with <priority> to the pool, the higher priority job is executed
+// before the lower ones, and the jobs of the same priority are executed in FIFO order.
+// The <priority> is ignored and the job is executed in FIFO order if the pool is not created
+// by NewWithPriority. The job will be executed asynchronously.
+// It returns ErrQueueFull if the queue of the pool is full, see SetQueueCap.
+func (p *Pool) AddWithPriority(f func(), priority int) error {
+	if !p.acquireSlot() {
+		return ErrQueueFull
+	}
+	return p.addSlotJob(f, priority)
+}
+
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{
+		jobs: make(priorityJobs, 0),
+	}
+}
+
+// Push pushes <job> with <priority> to the queue.
+func (q *priorityQueue) Push(job func(), priority int) {
+	q.mu.Lock()
+	q.seq++
+	heap.Push(&q.jobs, &priorityJob{
+		job:      job,
+		priority: priority,
+		seq:      q.seq,
+	})
+	q.mu.Unlock()
+}
+
+// Pop pops and returns the job of the highest priority, or nil if the queue is empty.
+func (q *priorityQueue) Pop() func() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.jobs) == 0 {
+		return nil
+	}
+	return heap.Pop(&q.jobs).(*priorityJob).job
+}
+
+// Size returns the job count of the queue.
+func (q *priorityQueue) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
+
+func (h priorityJobs) Len() int {
+	return len(h)
+}
+
+func (h priorityJobs) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityJobs) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *priorityJobs) Push(x interface{}) {
+	*h = append(*h, x.(*priorityJob))
+}
+
+func (h *priorityJobs) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
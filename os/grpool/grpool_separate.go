@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"runtime"
+)
+
+const (
+	// Default IO pool size is multiple of the CPU number.
+	defaultIOPoolSizeMultiple = 5
+)
+
+// SeparatePool contains separate goroutine pools for CPU-bound and IO-bound jobs.
+//
+// The CPU-bound jobs should not exceed the CPU number, as the GOMAXPROCS scheduling
+// makes excessive running goroutines only cause more context switching,
+// while the IO-bound jobs spend most time on waiting, which needs more goroutines.
+type SeparatePool struct {
+	cpu *Pool // Pool for CPU-bound jobs.
+	io  *Pool // Pool for IO-bound jobs.
+}
+
+// SeparatePoolStats is the statistics snapshot of SeparatePool.
+type SeparatePoolStats struct {
+	CPUCap  int // Capacity of CPU pool.
+	CPUSize int // Current goroutine count of CPU pool.
+	CPUJobs int // Current job count of CPU pool.
+	IOCap   int // Capacity of IO pool.
+	IOSize  int // Current goroutine count of IO pool.
+	IOJobs  int // Current job count of IO pool.
+}
+
+// NewSeparatePool creates and returns a SeparatePool.
+// The parameter <cpuSize> limits the goroutine count of CPU pool, which is runtime.NumCPU() if it is <= 0.
+// The parameter <ioSize> limits the goroutine count of IO pool, which is 5 * runtime.NumCPU() if it is <= 0.
+func NewSeparatePool(cpuSize, ioSize int) *SeparatePool {
+	if cpuSize <= 0 {
+		cpuSize = runtime.NumCPU()
+	}
+	if ioSize <= 0 {
+		ioSize = defaultIOPoolSizeMultiple * runtime.NumCPU()
+	}
+	return &SeparatePool{
+		cpu: New(cpuSize),
+		io:  New(ioSize),
+	}
+}
+
+// SubmitCPU pushes a new CPU-bound job to the CPU pool.
+// The job will be executed asynchronously.
+func (p *SeparatePool) SubmitCPU(f func()) error {
+	return p.cpu.Add(f)
+}
+
+// SubmitIO pushes a new IO-bound job to the IO pool.
+// The job will be executed asynchronously.
+func (p *SeparatePool) SubmitIO(f func()) error {
+	return p.io.Add(f)
+}
+
+// CPU returns the underlying pool for CPU-bound jobs.
+func (p *SeparatePool) CPU() *Pool {
+	return p.cpu
+}
+
+// IO returns the underlying pool for IO-bound jobs.
+func (p *SeparatePool) IO() *Pool {
+	return p.io
+}
+
+// Stats returns the statistics snapshot of both CPU and IO pools.
+func (p *SeparatePool) Stats() SeparatePoolStats {
+	return SeparatePoolStats{
+		CPUCap:  p.cpu.Cap(),
+		CPUSize: p.cpu.Size(),
+		CPUJobs: p.cpu.Jobs(),
+		IOCap:   p.io.Cap(),
+		IOSize:  p.io.Size(),
+		IOJobs:  p.io.Jobs(),
+	}
+}
+
+// IsClosed returns if the pool is closed.
+func (p *SeparatePool) IsClosed() bool {
+	return p.cpu.IsClosed() && p.io.IsClosed()
+}
+
+// Close closes both CPU and IO pools.
+func (p *SeparatePool) Close() {
+	p.cpu.Close()
+	p.io.Close()
+}
@@ -0,0 +1,69 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"expvar"
+
+	"github.com/ichunt2019/gf/container/gtype"
+)
+
+// PoolStats is the statistics snapshot of Pool.
+type PoolStats struct {
+	QueueDepth     int64 // Current count of jobs waiting in queue.
+	ActiveWorkers  int64 // Current count of goroutine workers executing jobs.
+	TotalProcessed int64 // Total count of executed jobs.
+	TotalErrors    int64 // Total count of failed jobs, see Stats.
+	TotalCancelled int64 // Total count of jobs cancelled for timeout, see AddWithTimeout.
+	MaxWorkers     int   // Max goroutine count limit, which is -1 if there's no limit.
+}
+
+// poolStats holds the atomic statistics counters of Pool.
+type poolStats struct {
+	queued    *gtype.Int64 // Count of jobs waiting in queue.
+	active    *gtype.Int64 // Count of goroutine workers executing jobs.
+	processed *gtype.Int64 // Total count of executed jobs.
+	errors    *gtype.Int64 // Total count of failed jobs.
+	cancelled *gtype.Int64 // Total count of jobs cancelled for timeout.
+}
+
+func newPoolStats() poolStats {
+	return poolStats{
+		queued:    gtype.NewInt64(),
+		active:    gtype.NewInt64(),
+		processed: gtype.NewInt64(),
+		errors:    gtype.NewInt64(),
+		cancelled: gtype.NewInt64(),
+	}
+}
+
+// Stats returns the statistics snapshot of the pool, which is maintained with atomic counters
+// without locking, so it is cheap enough for periodical exporting, eg: to Prometheus or expvar.
+//
+// The failed jobs in TotalErrors are the jobs added by AddWithRecover that panic, the panicking
+// jobs recovered by the panic handler of SetPanicHandler,
+// and the jobs of TaskGroup that return error or panic.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		QueueDepth:     p.stats.queued.Val(),
+		ActiveWorkers:  p.stats.active.Val(),
+		TotalProcessed: p.stats.processed.Val(),
+		TotalErrors:    p.stats.errors.Val(),
+		TotalCancelled: p.stats.cancelled.Val(),
+		MaxWorkers:     p.limit,
+	}
+}
+
+// ExposeExpvar publishes the statistics of the pool to expvar with <name>,
+// whose value is the latest PoolStats as JSON object.
+//
+// Note that it panics if <name> is already published, as expvar.Publish does.
+func (p *Pool) ExposeExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return p.Stats()
+	}))
+}
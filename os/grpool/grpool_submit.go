@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned when adding job to the pool whose queue is full.
+	ErrQueueFull = errors.New("pool queue is full")
+	// ErrSubmitTimeout is returned when the queue of pool is still full after the submitting timeout.
+	ErrSubmitTimeout = errors.New("pool submitting timeout")
+	// errPoolClosed is returned when adding job to the closed pool.
+	errPoolClosed = errors.New("pool closed")
+)
+
+// SetQueueCap sets the capacity of the job queue waiting for goroutine workers,
+// which is not limited if <cap> <= 0. The pool rejects the new job with ErrQueueFull
+// if the queue is full, or blocks the caller of SubmitBlocking and SubmitWithTimeout.
+//
+// Note that it should be called before adding any job to the pool.
+func (p *Pool) SetQueueCap(cap int) {
+	if cap > 0 {
+		p.slots = make(chan struct{}, cap)
+	} else {
+		p.slots = nil
+	}
+}
+
+// Submit pushes a new job to the pool without blocking, which is the same as Add.
+// It returns ErrQueueFull if the queue of the pool is full, see SetQueueCap.
+// The job will be executed asynchronously.
+func (p *Pool) Submit(f func()) error {
+	return p.Add(f)
+}
+
+// SubmitBlocking pushes a new job to the pool, which blocks the caller until there's space
+// in the queue of the pool if the queue is full, see SetQueueCap.
+// It returns error if the pool is closed. The job will be executed asynchronously.
+func (p *Pool) SubmitBlocking(f func()) error {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		case <-p.done:
+			return errPoolClosed
+		}
+	}
+	return p.addSlotJob(f, 0)
+}
+
+// SubmitWithTimeout pushes a new job to the pool, which blocks the caller for at most <timeout>
+// until there's space in the queue of the pool if the queue is full, see SetQueueCap.
+// It returns ErrSubmitTimeout if the queue is still full after <timeout>,
+// or error if the pool is closed. The job will be executed asynchronously.
+func (p *Pool) SubmitWithTimeout(f func(), timeout time.Duration) error {
+	if p.slots != nil {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case p.slots <- struct{}{}:
+		case <-timer.C:
+			return ErrSubmitTimeout
+		case <-p.done:
+			return errPoolClosed
+		}
+	}
+	return p.addSlotJob(f, 0)
+}
+
+// acquireSlot acquires the queue space for a new job without blocking.
+// It returns false if the queue is full.
+func (p *Pool) acquireSlot() bool {
+	if p.slots == nil {
+		return true
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// addSlotJob pushes job <f> with <priority> whose queue space is acquired, the queue space
+// is released when the job is dequeued to execute, or the job cannot be added.
+func (p *Pool) addSlotJob(f func(), priority int) error {
+	if p.slots == nil {
+		return p.addJob(f, priority)
+	}
+	slots := p.slots
+	err := p.addJob(func() {
+		<-slots
+		f()
+	}, priority)
+	if err != nil {
+		<-slots
+	}
+	return err
+}
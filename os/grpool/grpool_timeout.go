@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package grpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gtype"
+)
+
+// AddWithTimeout pushes a new job <f> to the pool, which is called with a context
+// that is cancelled if <f> does not return in <timeout> since it starts executing.
+// The job will be executed asynchronously.
+//
+// The goroutine worker no longer waits for the cancelled job and is released for other jobs,
+// so <f> should return as soon as possible when the context is done, as the goroutine
+// of a cancelled job cannot be stopped forcibly. The cancelled jobs are counted
+// in TotalCancelled of Stats.
+//
+// The panic of <f> is handled like other jobs, see SetPanicHandler, even if it panics after timeout.
+func (p *Pool) AddWithTimeout(f func(ctx context.Context), timeout time.Duration) error {
+	return p.Add(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		var (
+			done      = make(chan struct{})
+			settled   = gtype.NewBool() // Whether the job returns or is cancelled firstly.
+			recovered interface{}
+		)
+		go func() {
+			defer func() {
+				e := recover()
+				if settled.Cas(false, true) {
+					// The worker is waiting, which handles the panic like other jobs.
+					recovered = e
+					close(done)
+					return
+				}
+				if e != nil {
+					// The worker is released for timeout.
+					p.handlePanic(f, e)
+				}
+			}()
+			f(ctx)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if settled.Cas(false, true) {
+				p.stats.cancelled.Add(1)
+				return
+			}
+			<-done
+		}
+		if recovered != nil {
+			panic(recovered)
+		}
+	})
+}
+
+// handlePanic passes the panic <recovered> of <task> to the panic handler, which counts the error
+// in pool statistics. It panics with <recovered> again if the panic handler is not set.
+func (p *Pool) handlePanic(task interface{}, recovered interface{}) {
+	handler := p.handler.Val()
+	if handler == nil {
+		panic(recovered)
+	}
+	p.stats.errors.Add(1)
+	handler.(func(task interface{}, recovered interface{}))(task, recovered)
+}
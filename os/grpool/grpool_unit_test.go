@@ -9,6 +9,11 @@
 package grpool_test
 
 import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -16,6 +21,7 @@ import (
 	"github.com/ichunt2019/gf/container/garray"
 	"github.com/ichunt2019/gf/os/grpool"
 	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
 )
 
 func Test_Basic(t *testing.T) {
@@ -119,3 +125,486 @@ func Test_AddWithRecover(t *testing.T) {
 		t.Assert(array.Len(), 2)
 	})
 }
+
+func Test_SeparatePool(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		p := grpool.NewSeparatePool(0, 0)
+		defer p.Close()
+		stats := p.Stats()
+		t.Assert(stats.CPUCap, runtime.NumCPU())
+		t.Assert(stats.IOCap, 5*runtime.NumCPU())
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg    = sync.WaitGroup{}
+			array = garray.NewArray(true)
+			p     = grpool.NewSeparatePool(2, 10)
+		)
+		wg.Add(20)
+		for i := 0; i < 10; i++ {
+			t.Assert(p.SubmitCPU(func() {
+				array.Append("cpu")
+				wg.Done()
+			}), nil)
+			t.Assert(p.SubmitIO(func() {
+				time.Sleep(10 * time.Millisecond)
+				array.Append("io")
+				wg.Done()
+			}), nil)
+		}
+		stats := p.Stats()
+		t.Assert(stats.CPUCap, 2)
+		t.Assert(stats.IOCap, 10)
+		t.AssertLE(stats.CPUSize, 2)
+		t.AssertLE(stats.IOSize, 10)
+		wg.Wait()
+		t.Assert(array.Len(), 20)
+
+		p.Close()
+		t.Assert(p.IsClosed(), true)
+		t.AssertNE(p.SubmitCPU(func() {}), nil)
+		t.AssertNE(p.SubmitIO(func() {}), nil)
+	})
+}
+
+func Test_TaskGroup(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.New(4)
+			array = garray.NewArray(true)
+			group = p.Group()
+		)
+		for i := 0; i < 10; i++ {
+			i := i
+			group.Add(func() error {
+				time.Sleep(10 * time.Millisecond)
+				array.Append(i)
+				if i%3 == 0 && i > 0 {
+					return fmt.Errorf("error %d", i)
+				}
+				return nil
+			})
+		}
+		err := group.Wait()
+		t.Assert(array.Len(), 10)
+		t.AssertNE(err, nil)
+		merr, ok := err.(grpool.MultiError)
+		t.Assert(ok, true)
+		t.Assert(len(merr), 3)
+		t.Assert(gstr.Contains(err.Error(), "error 3"), true)
+		t.Assert(gstr.Contains(err.Error(), "error 6"), true)
+		t.Assert(gstr.Contains(err.Error(), "error 9"), true)
+	})
+	// No errors.
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewArray(true)
+		err := grpool.Group().Add(func() error {
+			array.Append(1)
+			return nil
+		}).Add(func() error {
+			array.Append(2)
+			return nil
+		}).Wait()
+		t.Assert(err, nil)
+		t.Assert(array.Len(), 2)
+	})
+	// Panic and closed pool.
+	gtest.C(t, func(t *gtest.T) {
+		p := grpool.New(1)
+		err := p.Group().Add(func() error {
+			panic("job panic")
+		}).Wait()
+		t.Assert(len(err.(grpool.MultiError)), 1)
+		t.Assert(gstr.Contains(err.Error(), "job panic"), true)
+
+		p.Close()
+		err = p.Group().Add(func() error {
+			return nil
+		}).Wait()
+		t.Assert(len(err.(grpool.MultiError)), 1)
+	})
+}
+
+func Test_TaskGroup_WaitContext(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		group := grpool.New().Group().Add(func() error {
+			time.Sleep(500 * time.Millisecond)
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		t.Assert(group.WaitContext(ctx), context.DeadlineExceeded)
+		t.Assert(group.WaitContext(context.Background()), nil)
+	})
+}
+
+func Test_PriorityPool(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p       = grpool.NewWithPriority(1)
+			array   = garray.NewArray(true)
+			blocker = make(chan struct{})
+			wg      = sync.WaitGroup{}
+		)
+		defer p.Close()
+		// The first job blocks the only worker, so that the following jobs are queued.
+		wg.Add(1)
+		t.Assert(p.AddWithPriority(func() {
+			<-blocker
+			wg.Done()
+		}, 0), nil)
+		time.Sleep(100 * time.Millisecond)
+		for _, priority := range []int{1, 3, 2, 3, 1} {
+			priority := priority
+			wg.Add(1)
+			t.Assert(p.AddWithPriority(func() {
+				array.Append(priority)
+				wg.Done()
+			}, priority), nil)
+		}
+		t.Assert(p.Jobs(), 5)
+		close(blocker)
+		wg.Wait()
+		t.Assert(array.Slice(), []int{3, 3, 2, 1, 1})
+		t.Assert(p.Jobs(), 0)
+	})
+	// Jobs of the same priority are executed in FIFO order.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p      = grpool.NewWithPriority(1)
+			array  = garray.NewArray(true)
+			wg     = sync.WaitGroup{}
+			expect = make([]int, 0)
+		)
+		defer p.Close()
+		for i := 0; i < 100; i++ {
+			i := i
+			wg.Add(1)
+			expect = append(expect, i)
+			t.Assert(p.Add(func() {
+				array.Append(i)
+				wg.Done()
+			}), nil)
+		}
+		wg.Wait()
+		t.Assert(array.Slice(), expect)
+
+		p.Close()
+		t.AssertNE(p.AddWithPriority(func() {}, 1), nil)
+	})
+}
+
+func Test_Barrier(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.New(2)
+			array = garray.NewArray(true)
+			b     = p.Barrier()
+		)
+		defer p.Close()
+		for i := 0; i < 10; i++ {
+			b.Add(func() {
+				time.Sleep(10 * time.Millisecond)
+				array.Append(1)
+			})
+		}
+		t.Assert(b.Wait(), nil)
+		t.Assert(array.Len(), 10)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.New(2)
+			array = garray.NewArray(true)
+			b     = p.Barrier()
+		)
+		defer p.Close()
+		b.Add(func() {
+			array.Append(1)
+		}).Add(func() {
+			panic("error1")
+		}).Add(func() {
+			panic("error2")
+		})
+		err := b.Wait()
+		t.AssertNE(err, nil)
+		t.Assert(len(err.(grpool.MultiError)), 2)
+		t.Assert(array.Len(), 1)
+
+		p.Close()
+		err = p.Barrier().Add(func() {}).Wait()
+		t.Assert(len(err.(grpool.MultiError)), 1)
+	})
+}
+
+func Test_Stats(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p       = grpool.New(1)
+			blocker = make(chan struct{})
+		)
+		defer p.Close()
+		t.Assert(p.Stats(), grpool.PoolStats{MaxWorkers: 1})
+
+		p.Add(func() {
+			<-blocker
+		})
+		p.AddWithRecover(func() {
+			panic("error")
+		})
+		p.Add(func() {})
+		time.Sleep(100 * time.Millisecond)
+		stats := p.Stats()
+		t.Assert(stats.QueueDepth, 2)
+		t.Assert(stats.ActiveWorkers, 1)
+		t.Assert(stats.TotalProcessed, 0)
+
+		close(blocker)
+		t.Assert(p.Group().Add(func() error {
+			return errors.New("error")
+		}).Wait() != nil, true)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(p.Stats(), grpool.PoolStats{
+			QueueDepth:     0,
+			ActiveWorkers:  0,
+			TotalProcessed: 4,
+			TotalErrors:    2,
+			MaxWorkers:     1,
+		})
+	})
+}
+
+func Test_ExposeExpvar(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p    = grpool.New()
+			name = fmt.Sprintf("grpool_test_stats_%d", time.Now().UnixNano())
+		)
+		defer p.Close()
+		p.ExposeExpvar(name)
+		p.Group().Add(func() error { return nil }).Wait()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(
+			expvar.Get(name).String(),
+			`{"QueueDepth":0,"ActiveWorkers":0,"TotalProcessed":1,"TotalErrors":0,"TotalCancelled":0,"MaxWorkers":-1}`,
+		)
+	})
+}
+
+func Test_AddWithTimeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.New(1)
+			array = garray.NewArray(true)
+			wg    = sync.WaitGroup{}
+		)
+		defer p.Close()
+		wg.Add(2)
+		// The cancelled job releases the only worker for the next job.
+		t.Assert(p.AddWithTimeout(func(ctx context.Context) {
+			defer wg.Done()
+			<-ctx.Done()
+			time.Sleep(500 * time.Millisecond)
+			array.Append(ctx.Err())
+		}, 50*time.Millisecond), nil)
+		t.Assert(p.AddWithTimeout(func(ctx context.Context) {
+			defer wg.Done()
+			array.Append(ctx.Err())
+		}, time.Second), nil)
+
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		t.Assert(array.Slice()[0], nil)
+		stats := p.Stats()
+		t.Assert(stats.TotalCancelled, 1)
+		t.Assert(stats.TotalProcessed, 2)
+
+		wg.Wait()
+		t.Assert(array.Len(), 2)
+		t.Assert(array.Slice()[1], context.DeadlineExceeded)
+	})
+}
+
+func Test_AddWithTimeout_Panic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.New(1)
+			array = garray.NewArray(true)
+		)
+		defer p.Close()
+		p.SetPanicHandler(func(task interface{}, recovered interface{}) {
+			array.Append(recovered)
+		})
+		// Panics before timeout.
+		t.Assert(p.AddWithTimeout(func(ctx context.Context) {
+			panic("before")
+		}, time.Second), nil)
+		// Panics after timeout, when the worker is released.
+		t.Assert(p.AddWithTimeout(func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(100 * time.Millisecond)
+			panic("after")
+		}, 50*time.Millisecond), nil)
+
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(array.Slice(), []interface{}{"before", "after"})
+		stats := p.Stats()
+		t.Assert(stats.TotalErrors, 2)
+		t.Assert(stats.TotalCancelled, 1)
+		t.Assert(stats.TotalProcessed, 2)
+		t.Assert(stats.ActiveWorkers, 0)
+	})
+}
+
+func Test_ElasticPool(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p     = grpool.NewElastic(1, 4, 100*time.Millisecond)
+			array = garray.NewArray(true)
+			wg    = sync.WaitGroup{}
+		)
+		defer p.Close()
+		t.Assert(p.Cap(), 4)
+		t.Assert(p.Size(), 1)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			p.Add(func() {
+				defer wg.Done()
+				time.Sleep(100 * time.Millisecond)
+				array.Append(1)
+			})
+		}
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(p.Size(), 4)
+		wg.Wait()
+		t.Assert(array.Len(), 8)
+
+		// The idle workers above min exit after idle timeout.
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(p.Size(), 1)
+
+		// The idle worker takes the new job.
+		wg.Add(1)
+		p.Add(func() {
+			defer wg.Done()
+			array.Append(1)
+		})
+		wg.Wait()
+		t.Assert(array.Len(), 9)
+		t.Assert(p.Size(), 1)
+		t.Assert(p.Stats().TotalProcessed, 9)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		p := grpool.NewElastic(0, 2, 50*time.Millisecond)
+		t.Assert(p.Size(), 0)
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		p.Add(func() { wg.Done() })
+		wg.Wait()
+		t.Assert(p.Size(), 1)
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(p.Size(), 0)
+		p.Close()
+		t.AssertNE(p.Add(func() {}), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		grpool.NewElastic(2, 1, time.Second)
+	})
+}
+
+func Test_ElasticPool_Close(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		p := grpool.NewElastic(2, 4, time.Hour)
+		t.Assert(p.Size(), 2)
+		time.Sleep(100 * time.Millisecond)
+		// The idle workers exit immediately after the pool is closed, not after idle timeout.
+		p.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(p.Size(), 0)
+	})
+}
+
+func Test_SetPanicHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p         = grpool.New(1)
+			array     = garray.NewArray(true)
+			recovered = garray.NewArray(true)
+			wg        = sync.WaitGroup{}
+		)
+		defer p.Close()
+		p.SetPanicHandler(func(task interface{}, e interface{}) {
+			_, ok := task.(func())
+			t.Assert(ok, true)
+			recovered.Append(e)
+			wg.Done()
+		})
+		wg.Add(3)
+		p.Add(func() {
+			panic("error1")
+		})
+		// The only worker survives the panic and executes the following jobs.
+		p.Add(func() {
+			array.Append(1)
+			wg.Done()
+		})
+		p.Add(func() {
+			panic("error2")
+		})
+		wg.Wait()
+		t.Assert(array.Len(), 1)
+		t.Assert(recovered.Slice(), []string{"error1", "error2"})
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(p.Stats().TotalErrors, 2)
+		t.Assert(p.Stats().ActiveWorkers, 0)
+	})
+}
+
+func Test_Submit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			p       = grpool.New(1)
+			array   = garray.NewArray(true)
+			blocker = make(chan struct{})
+		)
+		defer p.Close()
+		p.SetQueueCap(2)
+		t.Assert(p.Submit(func() {
+			<-blocker
+		}), nil)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(p.Submit(func() { array.Append(1) }), nil)
+		t.Assert(p.AddWithPriority(func() { array.Append(2) }, 1), nil)
+		t.Assert(p.Submit(func() { array.Append(3) }), grpool.ErrQueueFull)
+		t.Assert(p.SubmitWithTimeout(func() { array.Append(4) }, 50*time.Millisecond), grpool.ErrSubmitTimeout)
+		t.Assert(p.Jobs(), 2)
+
+		// The blocking caller is unblocked when the queue has space.
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(blocker)
+		}()
+		start := time.Now()
+		t.Assert(p.SubmitBlocking(func() { array.Append(5) }), nil)
+		t.Assert(time.Since(start) >= 50*time.Millisecond, true)
+		t.Assert(p.SubmitWithTimeout(func() { array.Append(6) }, time.Second), nil)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(array.Slice(), []int{1, 2, 5, 6})
+	})
+	// The blocking caller returns error if the pool is closed.
+	gtest.C(t, func(t *gtest.T) {
+		p := grpool.New(1)
+		p.SetQueueCap(1)
+		p.Submit(func() { time.Sleep(time.Second) })
+		time.Sleep(50 * time.Millisecond)
+		p.Submit(func() {})
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			p.Close()
+		}()
+		t.AssertNE(p.SubmitBlocking(func() {}), nil)
+		t.AssertNE(p.SubmitWithTimeout(func() {}, time.Second), nil)
+	})
+}
@@ -0,0 +1,258 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+// Package bench provides benchmarking tool for session storages,
+// which helps choosing between storage backends.
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gsession"
+)
+
+const (
+	// defaultTTL is the session TTL for benchmarking, which is long enough for the whole benchmarking.
+	defaultTTL = time.Hour
+	// benchKey is the session key used for benchmarking.
+	benchKey = "bench"
+)
+
+// Operation names of the benchmarking.
+const (
+	OpSet    = "Set"
+	OpGet    = "Get"
+	OpUpdate = "Update"
+	OpRemove = "Remove"
+)
+
+// operations is the ordered operations for each benchmarking iteration.
+var operations = []string{OpSet, OpGet, OpUpdate, OpRemove}
+
+// Report is the benchmarking result.
+type Report struct {
+	Concurrency int                   // Concurrency of the benchmarking.
+	Iterations  int                   // Iterations for each concurrent worker.
+	Duration    time.Duration         // Total duration of the benchmarking.
+	Throughput  float64               // Operations per second of all operations.
+	Errors      int                   // Count of the failed operations.
+	Races       int                   // Count of the data inconsistency detected, which indicates data race in the storage.
+	Operations  map[string]*Latencies // Operation name to its latencies mapping, eg: OpSet, OpGet.
+}
+
+// Latencies is the latency statistics of one operation.
+type Latencies struct {
+	Count      int           // Count of the operation.
+	P50        time.Duration // 50th percentile latency.
+	P95        time.Duration // 95th percentile latency.
+	P99        time.Duration // 99th percentile latency.
+	P999       time.Duration // 99.9th percentile latency.
+	Max        time.Duration // Max latency.
+	Throughput float64       // Operations per second of the operation.
+}
+
+// worker is the result of one concurrent worker.
+type worker struct {
+	durations map[string][]time.Duration // Operation name to its latencies.
+	errors    int                        // Count of the failed operations.
+	races     int                        // Count of the data inconsistency.
+}
+
+// Run benchmarks <storage> with <concurrency> concurrent workers, each of which runs <iterations>
+// times of Set, Get, Update and Remove operations through session manager, and returns the report.
+//
+// Each worker uses its own session, and it checks the value retrieved by Get and Update
+// is the one it previously set, or else it's counted as a data race in the report.
+// Note that it's recommended running the benchmarking with "-race" flag to detect
+// the memory data races of the storage additionally.
+func Run(storage gsession.Storage, concurrency, iterations int) *Report {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if iterations < 1 {
+		iterations = 1
+	}
+	var (
+		wg      = sync.WaitGroup{}
+		manager = gsession.New(defaultTTL, storage)
+		workers = make([]*worker, concurrency)
+		start   = time.Now()
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			workers[i] = runWorker(manager, iterations)
+		}(i)
+	}
+	wg.Wait()
+	return newReport(concurrency, iterations, time.Since(start), workers)
+}
+
+// runWorker runs <iterations> times of benchmarking operations with a new session.
+func runWorker(manager *gsession.Manager, iterations int) *worker {
+	var (
+		w = &worker{
+			durations: make(map[string][]time.Duration, len(operations)),
+		}
+		id string
+	)
+	for _, op := range operations {
+		w.durations[op] = make([]time.Duration, 0, iterations)
+	}
+	// Create the session id for this worker.
+	if err := doSession(manager, "", func(s *gsession.Session) error {
+		id = s.Id()
+		return nil
+	}); err != nil {
+		w.errors++
+		return w
+	}
+	for i := 0; i < iterations; i++ {
+		var (
+			value   = fmt.Sprintf("%s-%d", id, i)
+			updated = value + "-updated"
+		)
+		w.run(OpSet, manager, id, func(s *gsession.Session) error {
+			return s.Set(benchKey, value)
+		})
+		w.run(OpGet, manager, id, func(s *gsession.Session) error {
+			if v := s.GetString(benchKey); v != value {
+				w.races++
+			}
+			return nil
+		})
+		w.run(OpUpdate, manager, id, func(s *gsession.Session) error {
+			if v := s.GetString(benchKey); v != value {
+				w.races++
+			}
+			return s.Set(benchKey, updated)
+		})
+		w.run(OpRemove, manager, id, func(s *gsession.Session) error {
+			return s.Remove(benchKey)
+		})
+	}
+	return w
+}
+
+// run runs the operation <op> with session <id> and records its latency.
+func (w *worker) run(op string, manager *gsession.Manager, id string, f func(s *gsession.Session) error) {
+	start := time.Now()
+	if err := doSession(manager, id, f); err != nil {
+		w.errors++
+	}
+	w.durations[op] = append(w.durations[op], time.Since(start))
+}
+
+// doSession calls <f> with the session of <id> and closes the session after that.
+// It recovers the panic of session closing, which is caused by storage error.
+func doSession(manager *gsession.Manager, id string, f func(s *gsession.Session) error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf(`%v`, e)
+		}
+	}()
+	var s *gsession.Session
+	if id == "" {
+		s = manager.New()
+	} else {
+		s = manager.New(id)
+	}
+	if err = f(s); err != nil {
+		return err
+	}
+	s.Close()
+	return nil
+}
+
+// newReport creates and returns the report from the results of all <workers>.
+func newReport(concurrency, iterations int, duration time.Duration, workers []*worker) *Report {
+	var (
+		report = &Report{
+			Concurrency: concurrency,
+			Iterations:  iterations,
+			Duration:    duration,
+			Operations:  make(map[string]*Latencies, len(operations)),
+		}
+		total = 0
+	)
+	for _, op := range operations {
+		var durations []time.Duration
+		for _, w := range workers {
+			durations = append(durations, w.durations[op]...)
+		}
+		report.Operations[op] = newLatencies(durations, duration)
+		total += len(durations)
+	}
+	for _, w := range workers {
+		report.Errors += w.errors
+		report.Races += w.races
+	}
+	if duration > 0 {
+		report.Throughput = float64(total) / duration.Seconds()
+	}
+	return report
+}
+
+// newLatencies calculates and returns the latency statistics of <durations>.
+func newLatencies(durations []time.Duration, total time.Duration) *Latencies {
+	latencies := &Latencies{
+		Count: len(durations),
+	}
+	if len(durations) == 0 {
+		return latencies
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	latencies.P50 = percentile(durations, 0.50)
+	latencies.P95 = percentile(durations, 0.95)
+	latencies.P99 = percentile(durations, 0.99)
+	latencies.P999 = percentile(durations, 0.999)
+	latencies.Max = durations[len(durations)-1]
+	if total > 0 {
+		latencies.Throughput = float64(len(durations)) / total.Seconds()
+	}
+	return latencies
+}
+
+// percentile returns the <p> percentile of the sorted <durations> using nearest-rank method.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	index := int(math.Ceil(p*float64(len(durations)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return durations[index]
+}
+
+// String returns the report as readable table string.
+func (r *Report) String() string {
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteString(fmt.Sprintf(
+		"concurrency: %d, iterations: %d, duration: %s, throughput: %.2f ops/s, errors: %d, races: %d\n",
+		r.Concurrency, r.Iterations, r.Duration, r.Throughput, r.Errors, r.Races,
+	))
+	buffer.WriteString(fmt.Sprintf(
+		"%-8s %10s %12s %12s %12s %12s %12s %14s\n",
+		"op", "count", "p50", "p95", "p99", "p999", "max", "ops/s",
+	))
+	for _, op := range operations {
+		l, ok := r.Operations[op]
+		if !ok {
+			continue
+		}
+		buffer.WriteString(fmt.Sprintf(
+			"%-8s %10d %12s %12s %12s %12s %12s %14.2f\n",
+			op, l.Count, l.P50, l.P95, l.P99, l.P999, l.Max, l.Throughput,
+		))
+	}
+	return buffer.String()
+}
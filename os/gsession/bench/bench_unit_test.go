@@ -0,0 +1,61 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package bench_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/os/gsession/bench"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_Run_StorageMemory(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		report := bench.Run(gsession.NewStorageMemory(), 4, 50)
+		t.Assert(report.Concurrency, 4)
+		t.Assert(report.Iterations, 50)
+		t.Assert(report.Errors, 0)
+		t.Assert(report.Races, 0)
+		t.Assert(report.Throughput > 0, true)
+		for _, op := range []string{bench.OpSet, bench.OpGet, bench.OpUpdate, bench.OpRemove} {
+			l := report.Operations[op]
+			t.AssertNE(l, nil)
+			t.Assert(l.Count, 200)
+			t.Assert(l.P50 <= l.P95, true)
+			t.Assert(l.P95 <= l.P99, true)
+			t.Assert(l.P99 <= l.P999, true)
+			t.Assert(l.P999 <= l.Max, true)
+			t.Assert(l.Throughput > 0, true)
+		}
+		t.AssertNE(report.String(), "")
+	})
+}
+
+func Test_Run_StorageFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.TempDir(gtime.TimestampNanoStr())
+		t.Assert(gfile.Mkdir(path), nil)
+		defer gfile.Remove(path)
+		report := bench.Run(gsession.NewStorageFile(path), 2, 10)
+		t.Assert(report.Errors, 0)
+		t.Assert(report.Races, 0)
+		t.Assert(report.Throughput > 0, true)
+		t.Assert(report.Operations[bench.OpSet].Count, 20)
+	})
+}
+
+func Test_Run_InvalidArguments(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		report := bench.Run(gsession.NewStorageMemory(), 0, 0)
+		t.Assert(report.Concurrency, 1)
+		t.Assert(report.Iterations, 1)
+		t.Assert(report.Operations[bench.OpGet].Count, 1)
+	})
+}
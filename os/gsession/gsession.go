@@ -14,6 +14,7 @@ import (
 
 var (
 	ErrorDisabled = errors.New("this feature is disabled in this storage")
+	ErrorReadOnly = errors.New("session of the regenerated session id is read-only")
 )
 
 // NewSessionId creates and returns a new and unique session id string,
@@ -21,3 +22,21 @@ var (
 func NewSessionId() string {
 	return guid.S()
 }
+
+// isUrlSafeId checks and returns whether <id> is not empty and contains only
+// URL-safe characters, which are letters, digits, '-', '_', '.' and '~'.
+func isUrlSafeId(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -8,6 +8,7 @@ package gsession
 
 import (
 	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/internal/intlog"
 	"time"
 
 	"github.com/ichunt2019/gf/os/gcache"
@@ -15,16 +16,32 @@ import (
 
 // Manager for sessions.
 type Manager struct {
-	ttl         time.Duration // TTL for sessions.
-	storage     Storage       // Storage interface for session storage.
-	sessionData *gcache.Cache // Session data cache for session TTL.
+	ttl         time.Duration        // TTL for sessions.
+	storage     Storage              // Storage interface for session storage.
+	sessionData *gcache.Cache        // Session data cache for session TTL.
+	idGenerator func() string        // Custom session id generator, which replaces NewSessionId.
+	idValidator func(id string) bool // Custom session id validator, which replaces the default URL-safe checks.
+	gracePeriod time.Duration        // Grace period in which the old session id is still valid after regeneration.
+	regenerated *gcache.Cache        // Regenerated old session id to session data snapshot mapping in grace period.
+	hooks       managerHooks         // Session event hooks.
+	lockTTL     time.Duration        // TTL of session lock.
+	locker      *localLocker         // In-process session locker for storage not implementing Locker.
 }
 
+const (
+	// DefaultRegenerateGracePeriod is the default grace period of the old session id after regeneration.
+	DefaultRegenerateGracePeriod = 10 * time.Second
+)
+
 // New creates and returns a new session manager.
 func New(ttl time.Duration, storage ...Storage) *Manager {
 	m := &Manager{
 		ttl:         ttl,
 		sessionData: gcache.New(),
+		gracePeriod: DefaultRegenerateGracePeriod,
+		regenerated: gcache.New(),
+		lockTTL:     DefaultLockTTL,
+		locker:      newLocalLocker(),
 	}
 	if len(storage) > 0 && storage[0] != nil {
 		m.storage = storage[0]
@@ -40,7 +57,24 @@ func New(ttl time.Duration, storage ...Storage) *Manager {
 func (m *Manager) New(sessionId ...string) *Session {
 	var id string
 	if len(sessionId) > 0 && sessionId[0] != "" {
-		id = sessionId[0]
+		// Malformed session id is rejected before it hits the storage,
+		// and a new session is created instead.
+		if m.ValidateID(sessionId[0]) {
+			id = sessionId[0]
+			// The old session id in grace period is served by the read-only snapshot of
+			// the session data before regeneration, which never refers to the new session.
+			if v, _ := m.regenerated.Get(id); v != nil {
+				return &Session{
+					id:       id,
+					data:     v.(*gmap.StrAnyMap),
+					start:    true,
+					readonly: true,
+					manager:  m,
+				}
+			}
+		} else {
+			intlog.Printf(`invalid session id "%s", a new session is created`, sessionId[0])
+		}
 	}
 	return &Session{
 		id:      id,
@@ -53,6 +87,50 @@ func (m *Manager) SetStorage(storage Storage) {
 	m.storage = storage
 }
 
+// SetIDGenerator sets custom session id generator <f> for the manager,
+// which replaces the default NewSessionId.
+// Note that the generated session id should be URL-safe, which needs no encoding for cookies.
+func (m *Manager) SetIDGenerator(f func() string) {
+	m.idGenerator = f
+}
+
+// SetIDValidator sets custom session id validator <f> for the manager,
+// which replaces the default URL-safe checks of ValidateID.
+func (m *Manager) SetIDValidator(f func(id string) bool) {
+	m.idValidator = f
+}
+
+// ValidateID checks and returns whether given session <id> is valid.
+// It uses the custom validator if it is set by SetIDValidator,
+// or else it checks whether <id> contains only URL-safe characters.
+func (m *Manager) ValidateID(id string) bool {
+	if m.idValidator != nil {
+		return m.idValidator(id)
+	}
+	return isUrlSafeId(id)
+}
+
+// newSessionId creates and returns a new session id using custom session id generator.
+// It uses NewSessionId if there's no custom generator or the generated id is invalid.
+func (m *Manager) newSessionId() string {
+	if m.idGenerator != nil {
+		id := m.idGenerator()
+		if m.ValidateID(id) {
+			return id
+		}
+		intlog.Printf(`invalid session id "%s" generated by custom generator`, id)
+	}
+	return NewSessionId()
+}
+
+// SetRegenerateGracePeriod sets the grace period of the old session id after Session.Regenerate,
+// in which the old session id is served by the read-only snapshot of the session data before
+// regeneration for in-flight requests.
+// The old session id is invalidated immediately if <period> is not positive.
+func (m *Manager) SetRegenerateGracePeriod(period time.Duration) {
+	m.gracePeriod = period
+}
+
 // SetTTL the TTL for the session manager.
 func (m *Manager) SetTTL(ttl time.Duration) {
 	m.ttl = ttl
@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"sync"
+)
+
+// HookFunc is the session event hook function, which is called with the session id.
+type HookFunc func(id string)
+
+// managerHooks holds the session event hooks of manager.
+type managerHooks struct {
+	mu      sync.RWMutex
+	create  []HookFunc
+	destroy []HookFunc
+	expire  []HookFunc
+}
+
+// OnCreate adds hook <fn>, which is called when a new session id is created,
+// including the new session id created by Session.Regenerate.
+// It returns the manager for chaining, and multiple hooks are called in adding order.
+func (m *Manager) OnCreate(fn HookFunc) *Manager {
+	m.hooks.mu.Lock()
+	m.hooks.create = append(m.hooks.create, fn)
+	m.hooks.mu.Unlock()
+	return m
+}
+
+// OnDestroy adds hook <fn>, which is called when a session is destroyed, that is,
+// all its data is removed by Session.RemoveAll, or its id is replaced by Session.Regenerate.
+// It returns the manager for chaining, and multiple hooks are called in adding order.
+func (m *Manager) OnDestroy(fn HookFunc) *Manager {
+	m.hooks.mu.Lock()
+	m.hooks.destroy = append(m.hooks.destroy, fn)
+	m.hooks.mu.Unlock()
+	return m
+}
+
+// OnExpire adds hook <fn>, which is called when a session starts with an id
+// whose data is expired in storage or memory, or which does not exist.
+// It returns the manager for chaining, and multiple hooks are called in adding order.
+func (m *Manager) OnExpire(fn HookFunc) *Manager {
+	m.hooks.mu.Lock()
+	m.hooks.expire = append(m.hooks.expire, fn)
+	m.hooks.mu.Unlock()
+	return m
+}
+
+// callHooks calls the hooks returned by <get> with session id <id> synchronously.
+func (m *Manager) callHooks(get func(h *managerHooks) []HookFunc, id string) {
+	m.hooks.mu.RLock()
+	hooks := get(&m.hooks)
+	m.hooks.mu.RUnlock()
+	for _, fn := range hooks {
+		fn(id)
+	}
+}
+
+func (m *Manager) callCreateHooks(id string) {
+	m.callHooks(func(h *managerHooks) []HookFunc { return h.create }, id)
+}
+
+func (m *Manager) callDestroyHooks(id string) {
+	m.callHooks(func(h *managerHooks) []HookFunc { return h.destroy }, id)
+}
+
+func (m *Manager) callExpireHooks(id string) {
+	m.callHooks(func(h *managerHooks) []HookFunc { return h.expire }, id)
+}
@@ -26,6 +26,10 @@ type Session struct {
 	start   bool            // Used to mark session is started.
 	manager *Manager        // Parent manager.
 
+	// readonly marks the session of the old session id in regeneration grace period,
+	// whose data is the snapshot before regeneration.
+	readonly bool
+
 	// idFunc is a callback function used for creating custom session id.
 	// This is called if session id is empty ever when session starts.
 	idFunc func(ttl time.Duration) (id string)
@@ -50,18 +54,13 @@ func (s *Session) init() {
 				intlog.Errorf("session restoring failed for id '%s': %v", s.id, err)
 			}
 		}
+		if s.data == nil && err == nil {
+			s.manager.callExpireHooks(s.id)
+		}
 	}
-	// Use custom session id creating function.
-	if s.id == "" && s.idFunc != nil {
-		s.id = s.idFunc(s.manager.ttl)
-	}
-	// Use default session id creating function of storage.
-	if s.id == "" {
-		s.id = s.manager.storage.New(s.manager.ttl)
-	}
-	// Use default session id creating function.
 	if s.id == "" {
-		s.id = NewSessionId()
+		s.id = s.newId()
+		s.manager.callCreateHooks(s.id)
 	}
 	if s.data == nil {
 		s.data = gmap.NewStrAnyMap(true)
@@ -69,12 +68,84 @@ func (s *Session) init() {
 	s.start = true
 }
 
+// newId creates and returns a new session id.
+func (s *Session) newId() (id string) {
+	// Use custom session id creating function.
+	if s.idFunc != nil {
+		id = s.idFunc(s.manager.ttl)
+	}
+	// Use default session id creating function of storage.
+	if id == "" {
+		id = s.manager.storage.New(s.manager.ttl)
+	}
+	// Use session id creating function of manager.
+	if id == "" {
+		id = s.manager.newSessionId()
+	}
+	return
+}
+
+// Regenerate replaces the session id with a new one while preserving the session data,
+// which is used for session fixation protection after privilege changes, eg: user login.
+//
+// It stores the session data with the new session id before invalidating the old one.
+// The old session id is served by the read-only snapshot of the session data before regeneration
+// in the grace period of manager, see Manager.SetRegenerateGracePeriod. It never refers to the
+// new session, so that the data written after regeneration is not visible through the old id.
+// Note that the snapshot is kept in memory of the manager.
+//
+// The caller should send the new session id returned by Id to the client, eg: in cookie or header.
+func (s *Session) Regenerate() error {
+	if s.readonly {
+		return ErrorReadOnly
+	}
+	s.init()
+	var (
+		oldId = s.id
+		newId = s.newId()
+		data  = gmap.NewStrAnyMapFrom(s.Map(), true)
+		ttl   = s.manager.ttl
+	)
+	if newId == oldId {
+		return errors.New("regenerated session id is the same as the old one")
+	}
+	// Firstly stores the data with the new session id.
+	if data.Size() > 0 {
+		if err := s.manager.storage.SetMap(newId, data.Map(), ttl); err != nil && err != ErrorDisabled {
+			return err
+		}
+	}
+	if err := s.manager.storage.SetSession(newId, data, ttl); err != nil {
+		return err
+	}
+	s.manager.UpdateSessionTTL(newId, data)
+	// Secondly invalidates the old session id.
+	if gracePeriod := s.manager.gracePeriod; gracePeriod > 0 {
+		s.manager.regenerated.Set(oldId, gmap.NewStrAnyMapFrom(data.MapCopy(), true), gracePeriod)
+	}
+	if err := s.manager.storage.RemoveAll(oldId); err != nil {
+		if err != ErrorDisabled {
+			return err
+		}
+		if err = s.manager.storage.SetSession(oldId, gmap.NewStrAnyMap(true), ttl); err != nil {
+			return err
+		}
+	}
+	s.manager.sessionData.Remove(oldId)
+	s.id = newId
+	s.data = data
+	s.dirty = true
+	s.manager.callDestroyHooks(oldId)
+	s.manager.callCreateHooks(newId)
+	return nil
+}
+
 // Close closes current session and updates its ttl in the session manager.
 // If this session is dirty, it also exports it to storage.
 //
 // NOTE that this function must be called ever after a session request done.
 func (s *Session) Close() {
-	if s.start && s.id != "" {
+	if s.start && s.id != "" && !s.readonly {
 		size := s.data.Size()
 		if s.manager.storage != nil {
 			if s.dirty {
@@ -95,6 +166,9 @@ func (s *Session) Close() {
 
 // Set sets key-value pair to this session.
 func (s *Session) Set(key string, value interface{}) error {
+	if s.readonly {
+		return ErrorReadOnly
+	}
 	s.init()
 	if err := s.manager.storage.Set(s.id, key, value, s.manager.ttl); err != nil {
 		if err == ErrorDisabled {
@@ -115,6 +189,9 @@ func (s *Session) Sets(data map[string]interface{}) error {
 
 // SetMap batch sets the session using map.
 func (s *Session) SetMap(data map[string]interface{}) error {
+	if s.readonly {
+		return ErrorReadOnly
+	}
 	s.init()
 	if err := s.manager.storage.SetMap(s.id, data, s.manager.ttl); err != nil {
 		if err == ErrorDisabled {
@@ -132,6 +209,9 @@ func (s *Session) Remove(keys ...string) error {
 	if s.id == "" {
 		return nil
 	}
+	if s.readonly {
+		return ErrorReadOnly
+	}
 	s.init()
 	for _, key := range keys {
 		if err := s.manager.storage.Remove(s.id, key); err != nil {
@@ -156,6 +236,9 @@ func (s *Session) RemoveAll() error {
 	if s.id == "" {
 		return nil
 	}
+	if s.readonly {
+		return ErrorReadOnly
+	}
 	s.init()
 	if err := s.manager.storage.RemoveAll(s.id); err != nil {
 		if err == ErrorDisabled {
@@ -165,6 +248,7 @@ func (s *Session) RemoveAll() error {
 		}
 	}
 	s.dirty = true
+	s.manager.callDestroyHooks(s.id)
 	return nil
 }
 
@@ -200,6 +284,9 @@ func (s *Session) SetIdFunc(f func(ttl time.Duration) string) error {
 func (s *Session) Map() map[string]interface{} {
 	if s.id != "" {
 		s.init()
+		if s.readonly {
+			return s.data.Map()
+		}
 		if data := s.manager.storage.GetMap(s.id); data != nil {
 			return data
 		}
@@ -208,10 +295,35 @@ func (s *Session) Map() map[string]interface{} {
 	return nil
 }
 
+// GetAll returns a copy of all the session data as map, which is commonly used for debugging,
+// session migration and audit logging. Modifying the returned map does not affect the session.
+// It returns an empty map if the session is not started and has no session id.
+func (s *Session) GetAll() (map[string]interface{}, error) {
+	if s.id == "" {
+		return make(map[string]interface{}), nil
+	}
+	s.init()
+	if s.readonly {
+		return s.data.Map(), nil
+	}
+	data := s.manager.storage.GetMap(s.id)
+	if data == nil {
+		return s.data.Map(), nil
+	}
+	m := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		m[k] = v
+	}
+	return m, nil
+}
+
 // Size returns the size of the session.
 func (s *Session) Size() int {
 	if s.id != "" {
 		s.init()
+		if s.readonly {
+			return s.data.Size()
+		}
 		if size := s.manager.storage.GetSize(s.id); size >= 0 {
 			return size
 		}
@@ -239,8 +351,10 @@ func (s *Session) Get(key string, def ...interface{}) interface{} {
 		return nil
 	}
 	s.init()
-	if v := s.manager.storage.Get(s.id, key); v != nil {
-		return v
+	if !s.readonly {
+		if v := s.manager.storage.Get(s.id, key); v != nil {
+			return v
+		}
 	}
 	if v := s.data.Get(key); v != nil {
 		return v
@@ -0,0 +1,166 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/util/gconv"
+	"github.com/ichunt2019/gf/util/guid"
+)
+
+// Locker is the optional interface for session storage, which implements the session lock
+// shared by all the processes using the same storage, eg: redis.
+// The manager uses an in-process lock if its storage does not implement Locker.
+type Locker interface {
+	// TryLock tries acquiring the lock of session <id> with <token>, which expires after <ttl>.
+	// It returns false without blocking if the lock is held by others.
+	TryLock(id string, token string, ttl time.Duration) (bool, error)
+
+	// Unlock releases the lock of session <id> if it is still held by <token>.
+	Unlock(id string, token string) error
+}
+
+var (
+	// DefaultLockTTL is the default TTL of session lock, after which the lock is released automatically.
+	DefaultLockTTL = 10 * time.Second
+	// DefaultLockRetryInterval is the interval of retrying acquiring session lock held by others.
+	DefaultLockRetryInterval = 10 * time.Millisecond
+)
+
+const (
+	// redisLockKeySuffix is the suffix of redis key for session lock.
+	redisLockKeySuffix = ":lock"
+	// redisUnlockScript deletes the lock key only if it's still held by the token.
+	redisUnlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+	// localLockSweepInterval is the interval of removing the expired in-process locks,
+	// which are never unlocked by their holders.
+	localLockSweepInterval = time.Minute
+)
+
+// Lock acquires the write lock of the session, which blocks until the lock is acquired.
+// It returns function <unlock> releasing the lock, which should be called after the critical section.
+//
+// The lock is backed by the storage if it implements Locker, eg: StorageRedis using "SET NX",
+// so that only one goroutine of all processes holds the lock at a time.
+// The lock expires automatically after the lock TTL of manager, see Manager.SetLockTTL.
+func (s *Session) Lock() (unlock func(), err error) {
+	s.init()
+	var (
+		id     = s.id
+		ttl    = s.manager.lockTTL
+		token  = guid.S()
+		locker = s.manager.getLocker()
+	)
+	for {
+		var ok bool
+		if ok, err = locker.TryLock(id, token, ttl); err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		time.Sleep(DefaultLockRetryInterval)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if err := locker.Unlock(id, token); err != nil {
+				intlog.Errorf("session unlocking failed for id '%s': %v", id, err)
+			}
+		})
+	}, nil
+}
+
+// SetLockTTL sets the TTL of session lock, after which the lock acquired by Session.Lock
+// is released automatically, in case of that the lock holder never unlocks it.
+func (m *Manager) SetLockTTL(ttl time.Duration) {
+	m.lockTTL = ttl
+}
+
+// getLocker returns the storage as Locker if it implements Locker, or else the in-process locker.
+func (m *Manager) getLocker() Locker {
+	if locker, ok := m.storage.(Locker); ok {
+		return locker
+	}
+	return m.locker
+}
+
+// localLock is the lock of a session held in process.
+type localLock struct {
+	token  string
+	expire time.Time
+}
+
+// localLocker implements Locker in process, which is used for storages not implementing Locker.
+type localLocker struct {
+	mu    sync.Mutex
+	locks map[string]localLock
+	swept time.Time // Last time of removing the expired locks.
+}
+
+func newLocalLocker() *localLocker {
+	return &localLocker{
+		locks: make(map[string]localLock),
+		swept: time.Now(),
+	}
+}
+
+// TryLock tries acquiring the lock of session <id> with <token>, which expires after <ttl>.
+// It also removes the expired locks periodically, in case of that their holders never unlock them.
+func (l *localLocker) TryLock(id string, token string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.swept) >= localLockSweepInterval {
+		for k, lock := range l.locks {
+			if !now.Before(lock.expire) {
+				delete(l.locks, k)
+			}
+		}
+		l.swept = now
+	}
+	if lock, ok := l.locks[id]; ok && now.Before(lock.expire) {
+		return false, nil
+	}
+	l.locks[id] = localLock{
+		token:  token,
+		expire: now.Add(ttl),
+	}
+	return true, nil
+}
+
+// Unlock releases the lock of session <id> if it is still held by <token>.
+func (l *localLocker) Unlock(id string, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[id]; ok && lock.token == token {
+		delete(l.locks, id)
+	}
+	return nil
+}
+
+// redisTryLock tries acquiring the redis lock <key> with <token> using "SET NX PX".
+func redisTryLock(do func(command string, args ...interface{}) (interface{}, error), key string, token string, ttl time.Duration) (bool, error) {
+	ms := int64(ttl / time.Millisecond)
+	if ms <= 0 {
+		ms = 1
+	}
+	r, err := do("SET", key, token, "NX", "PX", ms)
+	if err != nil {
+		return false, err
+	}
+	return r != nil && gconv.String(r) == "OK", nil
+}
+
+// redisUnlock releases the redis lock <key> if it is still held by <token>.
+func redisUnlock(do func(command string, args ...interface{}) (interface{}, error), key string, token string) error {
+	_, err := do("EVAL", redisUnlockScript, 1, key, token)
+	return err
+}
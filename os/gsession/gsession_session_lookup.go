@@ -0,0 +1,173 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// LookupString retrieves the session value of <key> as string.
+// The returned <ok> is false if the key does not exist, or the value is not a scalar value
+// that can be converted to string, like map or slice.
+func (s *Session) LookupString(key string) (value string, ok bool) {
+	v := s.Get(key)
+	if v == nil || !isScalar(v) {
+		return "", false
+	}
+	return gconv.String(v), true
+}
+
+// LookupInt retrieves the session value of <key> as int.
+// The returned <ok> is false if the key does not exist, or the value is not an integer
+// or a string of integer, eg: "1".
+func (s *Session) LookupInt(key string) (value int, ok bool) {
+	v := s.Get(key)
+	if v == nil || !isInteger(v) {
+		return 0, false
+	}
+	return gconv.Int(v), true
+}
+
+// LookupBool retrieves the session value of <key> as bool.
+// The returned <ok> is false if the key does not exist, or the value is not a bool, a number,
+// or a string of "true", "false", "1", "0", "on", "off", "yes" and "no" in case-insensitive.
+func (s *Session) LookupBool(key string) (value bool, ok bool) {
+	v := s.Get(key)
+	if v == nil || !isBool(v) {
+		return false, false
+	}
+	return gconv.Bool(v), true
+}
+
+// LookupDuration retrieves the session value of <key> as time.Duration.
+// The returned <ok> is false if the key does not exist, or the value is not a time.Duration,
+// an integer in nanoseconds, or a string of duration, eg: "1h30m".
+func (s *Session) LookupDuration(key string) (value time.Duration, ok bool) {
+	v := s.Get(key)
+	if v == nil || !isDuration(v) {
+		return 0, false
+	}
+	return gconv.Duration(v), true
+}
+
+// MustGetString acts as LookupString, but it panics if the key does not exist or type mismatches.
+func (s *Session) MustGetString(key string) string {
+	v, ok := s.LookupString(key)
+	if !ok {
+		panic(s.lookupError(key, "string"))
+	}
+	return v
+}
+
+// MustGetInt acts as LookupInt, but it panics if the key does not exist or type mismatches.
+func (s *Session) MustGetInt(key string) int {
+	v, ok := s.LookupInt(key)
+	if !ok {
+		panic(s.lookupError(key, "int"))
+	}
+	return v
+}
+
+// MustGetBool acts as LookupBool, but it panics if the key does not exist or type mismatches.
+func (s *Session) MustGetBool(key string) bool {
+	v, ok := s.LookupBool(key)
+	if !ok {
+		panic(s.lookupError(key, "bool"))
+	}
+	return v
+}
+
+// MustGetDuration acts as LookupDuration, but it panics if the key does not exist or type mismatches.
+func (s *Session) MustGetDuration(key string) time.Duration {
+	v, ok := s.LookupDuration(key)
+	if !ok {
+		panic(s.lookupError(key, "time.Duration"))
+	}
+	return v
+}
+
+// lookupError returns the error for failed lookup of <key> as type <typeName>.
+func (s *Session) lookupError(key, typeName string) error {
+	v := s.Get(key)
+	if v == nil {
+		return fmt.Errorf(`session key "%s" does not exist`, key)
+	}
+	return fmt.Errorf(`session value of key "%s" cannot be converted to %s: %T(%v)`, key, typeName, v, v)
+}
+
+// isScalar checks whether <v> is a scalar value, which is not a map, slice, array or struct, except []byte.
+func isScalar(v interface{}) bool {
+	if _, ok := v.([]byte); ok {
+		return true
+	}
+	switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Func, reflect.Chan:
+		return false
+	}
+	return true
+}
+
+// isInteger checks whether <v> is an integer or can be parsed as integer.
+// Floating number without fractional part is also treated as integer,
+// as numbers decoded from JSON are float64.
+func isInteger(v interface{}) bool {
+	switch value := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	case float32:
+		return float32(int64(value)) == value
+	case float64:
+		return float64(int64(value)) == value
+	case bool:
+		return false
+	}
+	if !isScalar(v) {
+		return false
+	}
+	_, err := strconv.ParseInt(strings.TrimSpace(gconv.String(v)), 10, 64)
+	return err == nil
+}
+
+// isBool checks whether <v> is a bool or can be converted to bool unambiguously.
+func isBool(v interface{}) bool {
+	switch v.(type) {
+	case bool:
+		return true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	if !isScalar(v) {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(gconv.String(v))) {
+	case "true", "false", "1", "0", "on", "off", "yes", "no":
+		return true
+	}
+	return false
+}
+
+// isDuration checks whether <v> is a duration, an integer or a string of duration.
+func isDuration(v interface{}) bool {
+	if _, ok := v.(time.Duration); ok {
+		return true
+	}
+	if isInteger(v) {
+		return true
+	}
+	if !isScalar(v) {
+		return false
+	}
+	_, err := gtime.ParseDuration(gconv.String(v))
+	return err == nil
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"sort"
+	"strings"
+)
+
+// NamespacedSession is a view of Session, in which all keys are prefixed with its namespace
+// and a ':', so that different modules using the same session do not share keys.
+type NamespacedSession struct {
+	session *Session // The underlying session.
+	prefix  string   // Key prefix, which is the namespace and ':'.
+}
+
+// Namespace returns a view of the session for namespace <ns>,
+// in which all keys are prefixed with "<ns>:".
+func (s *Session) Namespace(ns string) *NamespacedSession {
+	return &NamespacedSession{
+		session: s,
+		prefix:  ns + ":",
+	}
+}
+
+// Get retrieves session value with given key in the namespace.
+// It returns <def> if the key does not exist in the session if <def> is given,
+// or else it return nil.
+func (n *NamespacedSession) Get(key string, def ...interface{}) interface{} {
+	return n.session.Get(n.prefix+key, def...)
+}
+
+// Set sets key-value pair in the namespace.
+func (n *NamespacedSession) Set(key string, value interface{}) error {
+	return n.session.Set(n.prefix+key, value)
+}
+
+// SetMap batch sets the session in the namespace using map.
+func (n *NamespacedSession) SetMap(data map[string]interface{}) error {
+	m := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		m[n.prefix+k] = v
+	}
+	return n.session.SetMap(m)
+}
+
+// Remove removes keys along with their values in the namespace.
+func (n *NamespacedSession) Remove(keys ...string) error {
+	prefixedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		prefixedKeys[i] = n.prefix + key
+	}
+	return n.session.Remove(prefixedKeys...)
+}
+
+// RemoveAll deletes all key-value pairs in the namespace,
+// which does not affect the keys of other namespaces.
+func (n *NamespacedSession) RemoveAll() error {
+	return n.Remove(n.Keys()...)
+}
+
+// Contains checks whether key exist in the namespace.
+func (n *NamespacedSession) Contains(key string) bool {
+	return n.session.Contains(n.prefix + key)
+}
+
+// Keys returns all the keys in the namespace without the namespace prefix, in sorted order.
+func (n *NamespacedSession) Keys() []string {
+	keys := make([]string, 0)
+	for k := range n.session.Map() {
+		if strings.HasPrefix(k, n.prefix) {
+			keys = append(keys, k[len(n.prefix):])
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map returns all data in the namespace as map, whose keys are without the namespace prefix.
+func (n *NamespacedSession) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	for k, v := range n.session.Map() {
+		if strings.HasPrefix(k, n.prefix) {
+			m[k[len(n.prefix):]] = v
+		}
+	}
+	return m
+}
+
+// Size returns the count of keys in the namespace.
+func (n *NamespacedSession) Size() int {
+	return len(n.Keys())
+}
@@ -0,0 +1,246 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/os/gcache"
+)
+
+// StorageCookie implements the Session Storage interface by storing the whole session data
+// in the cookie value of client, which needs no storage at server side.
+//
+// The cookie value is the session id, which is the gob encoded session data along with its
+// creation timestamp, signed with HMAC-SHA256 and encoded in base64url as "<payload>.<signature>".
+// As the cookie value changes when the session data changes, the caller should retrieve the
+// latest cookie value using Value after session closed and send it to the client.
+type StorageCookie struct {
+	secret []byte        // HMAC-SHA256 secret key for signing.
+	maxAge time.Duration // Max age of the cookie value from its creation, uses session TTL if not positive.
+	values *gcache.Cache // Latest encoded cookie values for session ids.
+}
+
+// cookiePayload is the session data encoded in cookie value.
+type cookiePayload struct {
+	Created int64                  // Creation timestamp in nanoseconds for replay protection.
+	Data    map[string]interface{} // Session data.
+}
+
+const (
+	// CookieMaxSize is the max size of the encoded cookie value.
+	CookieMaxSize = 4096
+)
+
+var (
+	// ErrorCookieTooLarge is returned when the encoded cookie value exceeds CookieMaxSize.
+	ErrorCookieTooLarge = errors.New(fmt.Sprintf("session cookie value exceeds max size %d", CookieMaxSize))
+	// ErrorCookieInvalid is returned when the cookie value is malformed or its signature mismatches.
+	ErrorCookieInvalid = errors.New("invalid session cookie value")
+	// ErrorCookieExpired is returned when the cookie value exceeds its max age.
+	ErrorCookieExpired = errors.New("session cookie value expired")
+)
+
+func init() {
+	// Registers the common composite types for session values of interface{}.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// NewStorageCookie creates and returns a cookie storage object for session,
+// which signs the cookie value with <secret>.
+func NewStorageCookie(secret []byte) *StorageCookie {
+	if len(secret) == 0 {
+		panic("secret for cookie storage cannot be empty")
+	}
+	return &StorageCookie{
+		secret: secret,
+		values: gcache.New(),
+	}
+}
+
+// SetMaxAge sets the max age of the cookie value from its creation, the cookie value
+// exceeding the max age is rejected even if its signature is valid, which prevents
+// the replay of old cookie values. It uses the session TTL if <maxAge> is not positive.
+func (s *StorageCookie) SetMaxAge(maxAge time.Duration) {
+	s.maxAge = maxAge
+}
+
+// Value returns the latest encoded cookie value for session <id>, which should be sent to
+// the client as the new session id. It returns <id> itself if the session is not changed.
+func (s *StorageCookie) Value(id string) string {
+	if v, _ := s.values.Get(id); v != nil {
+		return v.(string)
+	}
+	return id
+}
+
+// Encode encodes and signs session <data> as cookie value.
+// It returns ErrorCookieTooLarge if the encoded value exceeds CookieMaxSize.
+func (s *StorageCookie) Encode(data map[string]interface{}) (string, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := gob.NewEncoder(buffer).Encode(cookiePayload{
+		Created: time.Now().UnixNano(),
+		Data:    data,
+	})
+	if err != nil {
+		return "", err
+	}
+	payload := buffer.Bytes()
+	value := base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(s.sign(payload))
+	if len(value) > CookieMaxSize {
+		return "", ErrorCookieTooLarge
+	}
+	return value, nil
+}
+
+// Decode verifies and decodes cookie <value> to session data.
+// The parameter <maxAge> specifies the max age of the cookie value, which is not checked
+// if it is not positive.
+func (s *StorageCookie) Decode(value string, maxAge time.Duration) (map[string]interface{}, error) {
+	array := strings.Split(value, ".")
+	if len(array) != 2 {
+		return nil, ErrorCookieInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(array[0])
+	if err != nil {
+		return nil, ErrorCookieInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(array[1])
+	if err != nil {
+		return nil, ErrorCookieInvalid
+	}
+	if !hmac.Equal(signature, s.sign(payload)) {
+		return nil, ErrorCookieInvalid
+	}
+	var p cookiePayload
+	if err = gob.NewDecoder(bytes.NewReader(payload)).Decode(&p); err != nil {
+		return nil, ErrorCookieInvalid
+	}
+	if maxAge > 0 && time.Since(time.Unix(0, p.Created)) > maxAge {
+		return nil, ErrorCookieExpired
+	}
+	if p.Data == nil {
+		p.Data = make(map[string]interface{})
+	}
+	return p.Data, nil
+}
+
+// sign returns the HMAC-SHA256 signature of <payload>.
+func (s *StorageCookie) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// getMaxAge returns the max age of cookie value for session <ttl>.
+func (s *StorageCookie) getMaxAge(ttl time.Duration) time.Duration {
+	if s.maxAge > 0 {
+		return s.maxAge
+	}
+	return ttl
+}
+
+// New creates a session id, which is the encoded cookie value of empty session.
+func (s *StorageCookie) New(ttl time.Duration) (id string) {
+	id, _ = s.Encode(nil)
+	return
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+func (s *StorageCookie) Get(id string, key string) interface{} {
+	return nil
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageCookie) GetMap(id string) map[string]interface{} {
+	return nil
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageCookie) GetSize(id string) int {
+	return -1
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageCookie) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageCookie) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageCookie) Remove(id string, key string) error {
+	return ErrorDisabled
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageCookie) RemoveAll(id string) error {
+	return ErrorDisabled
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter <data> is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// This function is called ever when session starts.
+func (s *StorageCookie) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	if data != nil {
+		return data, nil
+	}
+	m, err := s.Decode(s.Value(id), s.getMaxAge(ttl))
+	if err != nil {
+		if err == ErrorCookieExpired {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession encodes the session data for specified session id as the latest cookie value,
+// which can be retrieved using Value.
+// It returns ErrorCookieTooLarge if the encoded value exceeds CookieMaxSize.
+func (s *StorageCookie) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	value, err := s.Encode(data.Map())
+	if err != nil {
+		return err
+	}
+	s.values.Set(id, value, s.getMaxAge(ttl))
+	return nil
+}
+
+// UpdateTTL updates the TTL for specified session id, which re-encodes the session data
+// with the current creation timestamp as the latest cookie value.
+// It does nothing if the cookie value is invalid or expired.
+func (s *StorageCookie) UpdateTTL(id string, ttl time.Duration) error {
+	m, err := s.Decode(s.Value(id), s.getMaxAge(ttl))
+	if err != nil {
+		intlog.Printf("session cookie value refreshing ignored for id '%s': %v", id, err)
+		return nil
+	}
+	return s.SetSession(id, gmap.NewStrAnyMapFrom(m, true), ttl)
+}
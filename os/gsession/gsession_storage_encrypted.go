@@ -0,0 +1,255 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/internal/json"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// StorageEncrypted implements the Session Storage interface by wrapping another storage,
+// which encrypts the session values with AES-256-GCM before passing them to the inner storage,
+// and decrypts them after retrieving from the inner storage.
+//
+// Each value is encoded as JSON and encrypted with a random nonce, which is stored with the
+// ciphertext as base64 string. The session id and key are authenticated as additional data,
+// so that the encrypted value cannot be moved to another session or key.
+type StorageEncrypted struct {
+	inner  Storage      // Inner storage storing the encrypted values.
+	aead   cipher.AEAD  // AES-256-GCM cipher.
+	locker *localLocker // In-process session locker if the inner storage does not implement Locker.
+}
+
+// NewEncryptedStorage creates and returns an encrypted storage object for session,
+// which wraps storage <inner> and encrypts the session values with 32 bytes AES key <key>.
+func NewEncryptedStorage(inner Storage, key []byte) *StorageEncrypted {
+	if inner == nil {
+		panic("inner storage for encrypted storage cannot be empty")
+	}
+	if len(key) != 32 {
+		panic(fmt.Sprintf("invalid AES-256 key size %d, it should be 32 bytes", len(key)))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return &StorageEncrypted{
+		inner:  inner,
+		aead:   aead,
+		locker: newLocalLocker(),
+	}
+}
+
+// New creates a session id.
+// This function can be used for custom session creation.
+func (s *StorageEncrypted) New(ttl time.Duration) (id string) {
+	return s.inner.New(ttl)
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session, or the value cannot be decrypted.
+func (s *StorageEncrypted) Get(id string, key string) interface{} {
+	v := s.inner.Get(id, key)
+	if v == nil {
+		return nil
+	}
+	value, err := s.decrypt(id, key, v)
+	if err != nil {
+		intlog.Errorf("session value decrypting failed for id '%s' key '%s': %v", id, key, err)
+		return nil
+	}
+	return value
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageEncrypted) GetMap(id string) map[string]interface{} {
+	m := s.inner.GetMap(id)
+	if m == nil {
+		return nil
+	}
+	data, err := s.decryptMap(id, m)
+	if err != nil {
+		intlog.Errorf("session data decrypting failed for id '%s': %v", id, err)
+		return nil
+	}
+	return data
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageEncrypted) GetSize(id string) int {
+	return s.inner.GetSize(id)
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageEncrypted) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	encrypted, err := s.encrypt(id, key, value)
+	if err != nil {
+		return err
+	}
+	return s.inner.Set(id, key, encrypted, ttl)
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageEncrypted) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	encrypted, err := s.encryptMap(id, data)
+	if err != nil {
+		return err
+	}
+	return s.inner.SetMap(id, encrypted, ttl)
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageEncrypted) Remove(id string, key string) error {
+	return s.inner.Remove(id, key)
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageEncrypted) RemoveAll(id string) error {
+	return s.inner.RemoveAll(id)
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter <data> is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// The session data in memory is not encrypted, so it is returned directly if <data> is not nil.
+//
+// This function is called ever when session starts.
+func (s *StorageEncrypted) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	if data != nil {
+		return data, nil
+	}
+	encrypted, err := s.inner.GetSession(id, ttl, nil)
+	if err != nil || encrypted == nil {
+		return encrypted, err
+	}
+	m, err := s.decryptMap(id, encrypted.Map())
+	if err != nil {
+		return nil, err
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession updates the data map for specified session id.
+// This function is called ever after session, which is changed dirty, is closed.
+// This copy all session data map from memory to storage.
+func (s *StorageEncrypted) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	encrypted, err := s.encryptMap(id, data.Map())
+	if err != nil {
+		return err
+	}
+	return s.inner.SetSession(id, gmap.NewStrAnyMapFrom(encrypted, true), ttl)
+}
+
+// UpdateTTL updates the TTL for specified session id.
+// This function is called ever after session, which is not dirty, is closed.
+func (s *StorageEncrypted) UpdateTTL(id string, ttl time.Duration) error {
+	return s.inner.UpdateTTL(id, ttl)
+}
+
+// encrypt encodes <value> as JSON and encrypts it, returning the base64 string of nonce and ciphertext.
+func (s *StorageEncrypted) encrypt(id, key string, value interface{}) (string, error) {
+	plainText, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plainText)+s.aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	cipherText := s.aead.Seal(nonce, nonce, plainText, additionalData(id, key))
+	return base64.StdEncoding.EncodeToString(cipherText), nil
+}
+
+// decrypt decrypts <value> encrypted by encrypt and decodes it from JSON.
+func (s *StorageEncrypted) decrypt(id, key string, value interface{}) (interface{}, error) {
+	cipherText, err := base64.StdEncoding.DecodeString(gconv.String(value))
+	if err != nil {
+		return nil, err
+	}
+	if len(cipherText) < s.aead.NonceSize() {
+		return nil, errors.New("invalid encrypted session value")
+	}
+	nonceSize := s.aead.NonceSize()
+	plainText, err := s.aead.Open(nil, cipherText[:nonceSize], cipherText[nonceSize:], additionalData(id, key))
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err = json.Unmarshal(plainText, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TryLock tries acquiring the lock of session <id> with <token>, which expires after <ttl>.
+// It forwards to the inner storage if it implements Locker, eg: the distributed lock of
+// StorageRedisClient, or else it uses an in-process lock.
+func (s *StorageEncrypted) TryLock(id string, token string, ttl time.Duration) (bool, error) {
+	if locker, ok := s.inner.(Locker); ok {
+		return locker.TryLock(id, token, ttl)
+	}
+	return s.locker.TryLock(id, token, ttl)
+}
+
+// Unlock releases the lock of session <id> if it is still held by <token>.
+// It forwards to the inner storage if it implements Locker, or else it uses an in-process lock.
+func (s *StorageEncrypted) Unlock(id string, token string) error {
+	if locker, ok := s.inner.(Locker); ok {
+		return locker.Unlock(id, token)
+	}
+	return s.locker.Unlock(id, token)
+}
+
+// encryptMap encrypts all values of <data>.
+func (s *StorageEncrypted) encryptMap(id string, data map[string]interface{}) (map[string]interface{}, error) {
+	encrypted := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		value, err := s.encrypt(id, k, v)
+		if err != nil {
+			return nil, err
+		}
+		encrypted[k] = value
+	}
+	return encrypted, nil
+}
+
+// decryptMap decrypts all values of <data>.
+func (s *StorageEncrypted) decryptMap(id string, data map[string]interface{}) (map[string]interface{}, error) {
+	decrypted := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		value, err := s.decrypt(id, k, v)
+		if err != nil {
+			return nil, fmt.Errorf(`decrypting value of key "%s" failed: %v`, k, err)
+		}
+		decrypted[k] = value
+	}
+	return decrypted, nil
+}
+
+// additionalData returns the additional authenticated data for the value of <key> in session <id>.
+func additionalData(id, key string) []byte {
+	return []byte(id + "\x00" + key)
+}
@@ -0,0 +1,217 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"time"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// RedisClient is the minimal redis client interface for StorageRedisClient,
+// which sends a command to redis server and returns the reply.
+//
+// It is satisfied by *gredis.Redis and redigo connection directly,
+// and can be easily adapted for go-redis client, eg:
+//
+//	type goRedisClient struct {
+//	    client *redis.Client
+//	}
+//
+//	func (c *goRedisClient) Do(command string, args ...interface{}) (interface{}, error) {
+//	    return c.client.Do(context.Background(), append([]interface{}{command}, args...)...).Result()
+//	}
+//
+// Note that the client should return nil reply without error for non-existing key.
+type RedisClient interface {
+	Do(command string, args ...interface{}) (reply interface{}, err error)
+}
+
+// RedisOptions is the options for StorageRedisClient.
+type RedisOptions struct {
+	Prefix string // Redis key prefix for session id, which is "session:" in default.
+}
+
+const (
+	// defaultRedisKeyPrefix is the default redis key prefix for session id.
+	defaultRedisKeyPrefix = "session:"
+)
+
+// StorageRedisClient implements the Session Storage interface with redis hash,
+// which stores each session as a redis hash with key of prefix and session id,
+// using any redis client implementing RedisClient.
+type StorageRedisClient struct {
+	client RedisClient // Redis client for session storage.
+	prefix string      // Redis key prefix for session id.
+}
+
+// NewStorageRedisClient creates and returns a redis hash storage object for session,
+// using the redis client <client>.
+func NewStorageRedisClient(client RedisClient, options RedisOptions) *StorageRedisClient {
+	if client == nil {
+		panic("redis client for storage cannot be empty")
+	}
+	s := &StorageRedisClient{
+		client: client,
+		prefix: options.Prefix,
+	}
+	if s.prefix == "" {
+		s.prefix = defaultRedisKeyPrefix
+	}
+	return s
+}
+
+// New creates a session id.
+// This function can be used for custom session creation.
+func (s *StorageRedisClient) New(ttl time.Duration) (id string) {
+	return ""
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+func (s *StorageRedisClient) Get(id string, key string) interface{} {
+	r, _ := s.client.Do("HGET", s.key(id), key)
+	if r != nil {
+		return gconv.String(r)
+	}
+	return nil
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageRedisClient) GetMap(id string) map[string]interface{} {
+	r, err := s.client.Do("HGETALL", s.key(id))
+	if err != nil {
+		return nil
+	}
+	m := make(map[string]interface{})
+	switch v := r.(type) {
+	case map[interface{}]interface{}:
+		// Map reply of RESP3.
+		for key, value := range v {
+			m[gconv.String(key)] = gconv.String(value)
+		}
+	case map[string]string:
+		for key, value := range v {
+			m[key] = value
+		}
+	default:
+		array := gconv.Interfaces(r)
+		for i := 0; i+1 < len(array); i += 2 {
+			m[gconv.String(array[i])] = gconv.String(array[i+1])
+		}
+	}
+	return m
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageRedisClient) GetSize(id string) int {
+	r, _ := s.client.Do("HLEN", s.key(id))
+	return gconv.Int(r)
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageRedisClient) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	if _, err := s.client.Do("HSET", s.key(id), key, value); err != nil {
+		return err
+	}
+	return s.doUpdateTTL(id, ttl)
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageRedisClient) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	array := make([]interface{}, len(data)*2+1)
+	array[0] = s.key(id)
+
+	index := 1
+	for k, v := range data {
+		array[index] = k
+		array[index+1] = v
+		index += 2
+	}
+	if _, err := s.client.Do("HSET", array...); err != nil {
+		return err
+	}
+	return s.doUpdateTTL(id, ttl)
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageRedisClient) Remove(id string, key string) error {
+	_, err := s.client.Do("HDEL", s.key(id), key)
+	return err
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageRedisClient) RemoveAll(id string) error {
+	_, err := s.client.Do("DEL", s.key(id))
+	return err
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter <data> is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// This function is called ever when session starts.
+func (s *StorageRedisClient) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	intlog.Printf("StorageRedisClient.GetSession: %s, %v", id, ttl)
+	r, err := s.client.Do("EXISTS", s.key(id))
+	if err != nil {
+		return nil, err
+	}
+	if gconv.Bool(r) {
+		return gmap.NewStrAnyMap(true), nil
+	}
+	return nil, nil
+}
+
+// SetSession updates the data map for specified session id.
+// This function is called ever after session, which is changed dirty, is closed.
+// The session data is already stored in redis by Set/SetMap, so it just updates the TTL.
+func (s *StorageRedisClient) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	intlog.Printf("StorageRedisClient.SetSession: %s, %v", id, ttl)
+	return s.doUpdateTTL(id, ttl)
+}
+
+// UpdateTTL updates the TTL for specified session id.
+// This function is called ever after session, which is not dirty, is closed.
+func (s *StorageRedisClient) UpdateTTL(id string, ttl time.Duration) error {
+	intlog.Printf("StorageRedisClient.UpdateTTL: %s, %v", id, ttl)
+	return s.doUpdateTTL(id, ttl)
+}
+
+// doUpdateTTL sets the TTL of the redis hash of session id <id>, in seconds.
+func (s *StorageRedisClient) doUpdateTTL(id string, ttl time.Duration) error {
+	seconds := int64(ttl.Seconds())
+	if seconds <= 0 {
+		seconds = 1
+	}
+	_, err := s.client.Do("EXPIRE", s.key(id), seconds)
+	return err
+}
+
+func (s *StorageRedisClient) key(id string) string {
+	return s.prefix + id
+}
+
+// TryLock tries acquiring the lock of session <id> with <token> using redis "SET NX",
+// which expires after <ttl>.
+func (s *StorageRedisClient) TryLock(id string, token string, ttl time.Duration) (bool, error) {
+	return redisTryLock(s.client.Do, s.key(id)+redisLockKeySuffix, token, ttl)
+}
+
+// Unlock releases the lock of session <id> if it is still held by <token>.
+func (s *StorageRedisClient) Unlock(id string, token string) error {
+	return redisUnlock(s.client.Do, s.key(id)+redisLockKeySuffix, token)
+}
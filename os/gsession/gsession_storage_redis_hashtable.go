@@ -151,3 +151,14 @@ func (s *StorageRedisHashTable) UpdateTTL(id string, ttl time.Duration) error {
 func (s *StorageRedisHashTable) key(id string) string {
 	return s.prefix + id
 }
+
+// TryLock tries acquiring the lock of session <id> with <token> using redis "SET NX",
+// which expires after <ttl>.
+func (s *StorageRedisHashTable) TryLock(id string, token string, ttl time.Duration) (bool, error) {
+	return redisTryLock(s.redis.Do, s.key(id)+redisLockKeySuffix, token, ttl)
+}
+
+// Unlock releases the lock of session <id> if it is still held by <token>.
+func (s *StorageRedisHashTable) Unlock(id string, token string) error {
+	return redisUnlock(s.redis.Do, s.key(id)+redisLockKeySuffix, token)
+}
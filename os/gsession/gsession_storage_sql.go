@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/internal/json"
+	"github.com/ichunt2019/gf/os/gtime"
+)
+
+// StorageSQL implements the Session Storage interface with relational database,
+// which stores each session as a row of table with columns as follows:
+//
+//	id        VARCHAR(128) PRIMARY KEY, the session id.
+//	data      TEXT, the session data encoded as JSON.
+//	expire_at BIGINT, the expiring timestamp in milliseconds.
+//
+// The table is created automatically if it does not exist. The statements use "?" placeholders,
+// which are rebound to "$n" placeholders for PostgreSQL drivers.
+type StorageSQL struct {
+	db         *sql.DB    // Database for session storage.
+	table      string     // Table name for session storage.
+	dollar     bool       // Whether the driver uses "$n" placeholders.
+	initMu     sync.Mutex // Mutex for table initialization.
+	initDone   bool       // Whether the table is initialized.
+	queryCache sync.Map   // Rebound statements, which is used for "$n" placeholders.
+}
+
+var (
+	// sqlTableNameRegex is the regular expression for table name validation.
+	sqlTableNameRegex = regexp.MustCompile(`^[A-Za-z_][\w]*(\.[A-Za-z_][\w]*)?$`)
+)
+
+// NewStorageSQL creates and returns a database storage object for session,
+// which stores session in table <tableName> of database <db>.
+func NewStorageSQL(db *sql.DB, tableName string) *StorageSQL {
+	if db == nil {
+		panic("database for storage cannot be empty")
+	}
+	if !sqlTableNameRegex.MatchString(tableName) {
+		panic(fmt.Sprintf(`invalid table name "%s" for storage`, tableName))
+	}
+	driverType := strings.ToLower(fmt.Sprintf("%T", db.Driver()))
+	return &StorageSQL{
+		db:     db,
+		table:  tableName,
+		dollar: strings.Contains(driverType, "pq.") || strings.Contains(driverType, "pgx") || strings.Contains(driverType, "postgres"),
+	}
+}
+
+// New creates a session id.
+// This function can be used for custom session creation.
+func (s *StorageSQL) New(ttl time.Duration) (id string) {
+	return ""
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+func (s *StorageSQL) Get(id string, key string) interface{} {
+	return nil
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageSQL) GetMap(id string) map[string]interface{} {
+	return nil
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageSQL) GetSize(id string) int {
+	return -1
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageSQL) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageSQL) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageSQL) Remove(id string, key string) error {
+	return ErrorDisabled
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageSQL) RemoveAll(id string) error {
+	return ErrorDisabled
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter <data> is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// This function is called ever when session starts.
+func (s *StorageSQL) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	intlog.Printf("StorageSQL.GetSession: %s, %v", id, ttl)
+	if data != nil {
+		return data, nil
+	}
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	var (
+		content  string
+		expireAt int64
+	)
+	err := s.db.QueryRow(
+		s.query(`SELECT data, expire_at FROM %s WHERE id = ?`), id,
+	).Scan(&content, &expireAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if expireAt < gtime.TimestampMilli() {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal([]byte(content), &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession updates the data map for specified session id.
+// This function is called ever after session, which is changed dirty, is closed.
+// This copy all session data map from memory to storage.
+//
+// It updates the row of the session, or inserts one if it does not exist, in a transaction.
+// If the inserting fails as the row is inserted concurrently, it updates the row again.
+func (s *StorageSQL) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	intlog.Printf("StorageSQL.SetSession: %s, %v, %v", id, data, ttl)
+	if err := s.init(); err != nil {
+		return err
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	expireAt := gtime.TimestampMilli() + ttl.Milliseconds()
+	for i := 0; i < 2; i++ {
+		if err = s.doUpsert(id, string(content), expireAt); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// UpdateTTL updates the TTL for specified session id.
+// This function is called ever after session, which is not dirty, is closed.
+func (s *StorageSQL) UpdateTTL(id string, ttl time.Duration) error {
+	intlog.Printf("StorageSQL.UpdateTTL: %s, %v", id, ttl)
+	if err := s.init(); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(
+		s.query(`UPDATE %s SET expire_at = ? WHERE id = ?`),
+		gtime.TimestampMilli()+ttl.Milliseconds(), id,
+	)
+	return err
+}
+
+// GC deletes the expired sessions from storage.
+// It is not called automatically, the caller should call it periodically, eg: using gcron.
+func (s *StorageSQL) GC(ctx context.Context) error {
+	if err := s.init(); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, s.query(`DELETE FROM %s WHERE expire_at < ?`), gtime.TimestampMilli())
+	return err
+}
+
+// doUpsert updates the row of session <id> or inserts one if it does not exist.
+func (s *StorageSQL) doUpsert(id, content string, expireAt int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(s.query(`UPDATE %s SET data = ?, expire_at = ? WHERE id = ?`), content, expireAt, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// Note that some databases report zero affected rows if the values are not changed,
+	// which leads to a failed inserting and then a successful updating in retry.
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+		return tx.Commit()
+	}
+	if _, err = tx.Exec(s.query(`INSERT INTO %s (id, data, expire_at) VALUES (?, ?, ?)`), id, content, expireAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// init creates the table if it does not exist. It does nothing after it succeeds.
+func (s *StorageSQL) init() error {
+	s.initMu.Lock()
+	defer s.initMu.Unlock()
+	if s.initDone {
+		return nil
+	}
+	_, err := s.db.Exec(fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (id VARCHAR(128) NOT NULL PRIMARY KEY, data TEXT NOT NULL, expire_at BIGINT NOT NULL)`,
+		s.table,
+	))
+	if err != nil {
+		return err
+	}
+	s.initDone = true
+	return nil
+}
+
+// query formats the statement <format> with table name, and rebinds the placeholders if necessary.
+func (s *StorageSQL) query(format string) string {
+	if v, ok := s.queryCache.Load(format); ok {
+		return v.(string)
+	}
+	query := fmt.Sprintf(format, s.table)
+	if s.dollar {
+		var (
+			builder strings.Builder
+			index   = 0
+		)
+		for _, c := range query {
+			if c == '?' {
+				index++
+				builder.WriteString("$" + strconv.Itoa(index))
+			} else {
+				builder.WriteRune(c)
+			}
+		}
+		query = builder.String()
+	}
+	s.queryCache.Store(format, query)
+	return query
+}
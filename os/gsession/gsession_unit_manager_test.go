@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_Manager_SetIDGenerator(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			index   = 0
+			manager = gsession.New(time.Second, gsession.NewStorageMemory())
+		)
+		manager.SetIDGenerator(func() string {
+			index++
+			return fmt.Sprintf("session-%d", index)
+		})
+		s1 := manager.New()
+		t.Assert(s1.Set("k", "v"), nil)
+		t.Assert(s1.Id(), "session-1")
+		s1.Close()
+
+		s2 := manager.New()
+		t.Assert(s2.Id(), "session-2")
+
+		s3 := manager.New("session-1")
+		t.Assert(s3.Id(), "session-1")
+		t.Assert(s3.Get("k"), "v")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Second, gsession.NewStorageMemory())
+		manager.SetIDGenerator(func() string {
+			return "not url safe"
+		})
+		s := manager.New()
+		t.AssertNE(s.Id(), "not url safe")
+		t.Assert(len(s.Id()), 32)
+	})
+}
+
+func Test_Manager_ValidateID(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Second, gsession.NewStorageMemory())
+		t.Assert(manager.ValidateID(gsession.NewSessionId()), true)
+		t.Assert(manager.ValidateID("abc-DEF_123.~"), true)
+		t.Assert(manager.ValidateID(""), false)
+		t.Assert(manager.ValidateID("a b"), false)
+		t.Assert(manager.ValidateID("a;b=c"), false)
+
+		s := manager.New("a;b=c")
+		t.AssertNE(s.Id(), "a;b=c")
+		t.Assert(len(s.Id()), 32)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Second, gsession.NewStorageMemory())
+		manager.SetIDValidator(func(id string) bool {
+			return gstr.HasPrefix(id, "sid-")
+		})
+		t.Assert(manager.ValidateID("sid-1"), true)
+		t.Assert(manager.ValidateID("abc"), false)
+		t.Assert(manager.New("sid-1").Id(), "sid-1")
+		t.AssertNE(manager.New("abc").Id(), "abc")
+	})
+}
+
+func Test_Manager_Hooks(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			events  = make([]string, 0)
+			manager = gsession.New(100*time.Millisecond, gsession.NewStorageMemory())
+		)
+		manager.OnCreate(func(id string) {
+			events = append(events, "create:"+id)
+		}).OnCreate(func(id string) {
+			events = append(events, "create2:"+id)
+		}).OnDestroy(func(id string) {
+			events = append(events, "destroy:"+id)
+		}).OnExpire(func(id string) {
+			events = append(events, "expire:"+id)
+		})
+
+		s := manager.New()
+		t.Assert(s.Set("k", "v"), nil)
+		id := s.Id()
+		s.Close()
+		t.Assert(events, []string{"create:" + id, "create2:" + id})
+
+		// Existing session.
+		events = events[:0]
+		s = manager.New(id)
+		t.Assert(s.Get("k"), "v")
+		s.Close()
+		t.Assert(len(events), 0)
+
+		// Regeneration.
+		s = manager.New(id)
+		t.Assert(s.Regenerate(), nil)
+		newId := s.Id()
+		s.Close()
+		t.Assert(events, []string{"destroy:" + id, "create:" + newId, "create2:" + newId})
+
+		// Destroy.
+		events = events[:0]
+		s = manager.New(newId)
+		t.Assert(s.RemoveAll(), nil)
+		s.Close()
+		t.Assert(events, []string{"destroy:" + newId})
+
+		// Expire.
+		events = events[:0]
+		s = manager.New()
+		t.Assert(s.Set("k", "v"), nil)
+		id = s.Id()
+		s.Close()
+		time.Sleep(500 * time.Millisecond)
+		events = events[:0]
+		s = manager.New(id)
+		t.Assert(s.Get("k"), nil)
+		t.Assert(events, []string{"expire:" + id})
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_Session_Regenerate(t *testing.T) {
+	storages := []gsession.Storage{
+		gsession.NewStorageMemory(),
+		gsession.NewStorageFile(),
+		gsession.NewStorageRedisClient(newFakeRedisClient(), gsession.RedisOptions{}),
+	}
+	for _, storage := range storages {
+		gtest.C(t, func(t *gtest.T) {
+			manager := gsession.New(time.Minute, storage)
+			manager.SetRegenerateGracePeriod(500 * time.Millisecond)
+
+			s := manager.New()
+			t.Assert(s.SetMap(g.Map{"k1": "v1", "k2": "v2"}), nil)
+			oldId := s.Id()
+			s.Close()
+
+			s = manager.New(oldId)
+			t.Assert(s.Regenerate(), nil)
+			newId := s.Id()
+			t.AssertNE(newId, oldId)
+			t.Assert(s.GetString("k1"), "v1")
+			t.Assert(s.Set("k3", "v3"), nil)
+			s.Close()
+
+			// The data is preserved with new session id.
+			s = manager.New(newId)
+			t.Assert(s.Id(), newId)
+			t.Assert(s.GetString("k1"), "v1")
+			t.Assert(s.GetString("k2"), "v2")
+			t.Assert(s.GetString("k3"), "v3")
+			s.Close()
+
+			// The old session id is served by the read-only snapshot in grace period,
+			// which never refers to the new session.
+			s = manager.New(oldId)
+			t.Assert(s.Id(), oldId)
+			t.AssertNE(s.Id(), newId)
+			t.Assert(s.GetString("k1"), "v1")
+			t.Assert(s.GetString("k2"), "v2")
+			t.Assert(s.Get("k3"), nil)
+			t.Assert(s.Map(), g.Map{"k1": "v1", "k2": "v2"})
+			t.Assert(s.Size(), 2)
+			t.Assert(s.Set("k4", "v4"), gsession.ErrorReadOnly)
+			t.Assert(s.Remove("k1"), gsession.ErrorReadOnly)
+			t.Assert(s.Regenerate(), gsession.ErrorReadOnly)
+			s.Close()
+
+			// The writes after regeneration are not visible through the old session id.
+			s = manager.New(newId)
+			t.Assert(s.Set("user", "admin"), nil)
+			s.Close()
+			s = manager.New(oldId)
+			t.Assert(s.Get("user"), nil)
+			t.Assert(s.Get("k4"), nil)
+			s.Close()
+			s = manager.New(newId)
+			t.Assert(s.GetString("user"), "admin")
+			t.Assert(s.Get("k4"), nil)
+			s.Close()
+
+			// The old session id is invalidated after grace period.
+			time.Sleep(1000 * time.Millisecond)
+			s = manager.New(oldId)
+			t.Assert(s.Id(), oldId)
+			t.Assert(s.Get("k1"), nil)
+			t.Assert(s.Size(), 0)
+			s.Close()
+		})
+	}
+	// Without grace period.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Minute, gsession.NewStorageMemory())
+		manager.SetRegenerateGracePeriod(0)
+		s := manager.New()
+		t.Assert(s.Set("k", "v"), nil)
+		oldId := s.Id()
+		t.Assert(s.Regenerate(), nil)
+		s.Close()
+
+		s = manager.New(oldId)
+		t.Assert(s.Id(), oldId)
+		t.Assert(s.Get("k"), nil)
+	})
+	// Same session id generated.
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, gsession.NewStorageMemory()).New()
+		t.Assert(s.SetIdFunc(func(ttl time.Duration) string {
+			return "same"
+		}), nil)
+		t.Assert(s.Set("k", "v"), nil)
+		t.AssertNE(s.Regenerate(), nil)
+	})
+}
+
+func Test_Session_Lookup(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, gsession.NewStorageMemory()).New()
+		defer s.Close()
+		t.Assert(s.SetMap(g.Map{
+			"string":      "john",
+			"int":         100,
+			"intString":   "200",
+			"float":       float64(300),
+			"floatFrac":   1.5,
+			"bool":        true,
+			"boolString":  "off",
+			"duration":    time.Minute,
+			"durationStr": "1h30m",
+			"slice":       g.Slice{1, 2},
+		}), nil)
+
+		v, ok := s.LookupString("string")
+		t.Assert(v, "john")
+		t.Assert(ok, true)
+		v, ok = s.LookupString("int")
+		t.Assert(v, "100")
+		t.Assert(ok, true)
+		_, ok = s.LookupString("slice")
+		t.Assert(ok, false)
+		_, ok = s.LookupString("none")
+		t.Assert(ok, false)
+
+		i, ok := s.LookupInt("int")
+		t.Assert(i, 100)
+		t.Assert(ok, true)
+		i, ok = s.LookupInt("intString")
+		t.Assert(i, 200)
+		t.Assert(ok, true)
+		i, ok = s.LookupInt("float")
+		t.Assert(i, 300)
+		t.Assert(ok, true)
+		_, ok = s.LookupInt("floatFrac")
+		t.Assert(ok, false)
+		_, ok = s.LookupInt("string")
+		t.Assert(ok, false)
+		_, ok = s.LookupInt("bool")
+		t.Assert(ok, false)
+
+		b, ok := s.LookupBool("bool")
+		t.Assert(b, true)
+		t.Assert(ok, true)
+		b, ok = s.LookupBool("boolString")
+		t.Assert(b, false)
+		t.Assert(ok, true)
+		b, ok = s.LookupBool("int")
+		t.Assert(b, true)
+		t.Assert(ok, true)
+		_, ok = s.LookupBool("string")
+		t.Assert(ok, false)
+
+		d, ok := s.LookupDuration("duration")
+		t.Assert(d, time.Minute)
+		t.Assert(ok, true)
+		d, ok = s.LookupDuration("durationStr")
+		t.Assert(d, 90*time.Minute)
+		t.Assert(ok, true)
+		d, ok = s.LookupDuration("int")
+		t.Assert(d, 100*time.Nanosecond)
+		t.Assert(ok, true)
+		_, ok = s.LookupDuration("string")
+		t.Assert(ok, false)
+
+		t.Assert(s.MustGetString("string"), "john")
+		t.Assert(s.MustGetInt("intString"), 200)
+		t.Assert(s.MustGetBool("bool"), true)
+		t.Assert(s.MustGetDuration("durationStr"), 90*time.Minute)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, gsession.NewStorageMemory()).New()
+		defer s.Close()
+		t.Assert(s.Set("string", "john"), nil)
+
+		mustPanic := func(f func()) {
+			defer func() {
+				t.AssertNE(recover(), nil)
+			}()
+			f()
+		}
+		mustPanic(func() { s.MustGetString("none") })
+		mustPanic(func() { s.MustGetInt("string") })
+		mustPanic(func() { s.MustGetBool("string") })
+		mustPanic(func() { s.MustGetDuration("string") })
+	})
+}
+
+func Test_Session_Namespace(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, gsession.NewStorageMemory()).New()
+		defer s.Close()
+		var (
+			a = s.Namespace("a")
+			b = s.Namespace("b")
+		)
+		t.Assert(a.Set("user_id", 1), nil)
+		t.Assert(b.Set("user_id", 2), nil)
+		t.Assert(a.SetMap(g.Map{"name": "john"}), nil)
+		t.Assert(s.Set("user_id", 3), nil)
+
+		t.Assert(a.Get("user_id"), 1)
+		t.Assert(b.Get("user_id"), 2)
+		t.Assert(s.Get("user_id"), 3)
+		t.Assert(s.Get("a:user_id"), 1)
+		t.Assert(b.Get("name", "def"), "def")
+		t.Assert(a.Contains("name"), true)
+		t.Assert(b.Contains("name"), false)
+
+		t.Assert(a.Keys(), g.SliceStr{"name", "user_id"})
+		t.Assert(b.Keys(), g.SliceStr{"user_id"})
+		t.Assert(a.Map(), g.Map{"name": "john", "user_id": 1})
+		t.Assert(a.Size(), 2)
+
+		t.Assert(a.Remove("user_id"), nil)
+		t.Assert(a.Get("user_id"), nil)
+		t.Assert(b.Get("user_id"), 2)
+
+		t.Assert(b.RemoveAll(), nil)
+		t.Assert(b.Size(), 0)
+		t.Assert(a.Keys(), g.SliceStr{"name"})
+		t.Assert(s.Size(), 2)
+	})
+}
+
+func Test_Session_GetAll(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Minute, gsession.NewStorageMemory())
+		s := manager.New()
+		data, err := s.GetAll()
+		t.Assert(err, nil)
+		t.Assert(len(data), 0)
+
+		t.Assert(s.SetMap(g.Map{"k1": "v1", "k2": 2}), nil)
+		data, err = s.GetAll()
+		t.Assert(err, nil)
+		t.Assert(data, g.Map{"k1": "v1", "k2": 2})
+
+		data["k3"] = "v3"
+		t.Assert(s.Contains("k3"), false)
+		t.Assert(s.Size(), 2)
+		s.Close()
+	})
+}
+
+func Test_Session_Lock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			manager = gsession.New(time.Minute, gsession.NewStorageMemory())
+			s       = manager.New()
+			wg      = sync.WaitGroup{}
+			counter = 0
+		)
+		s.Set("k", "v")
+		s.Close()
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unlock, err := manager.New(s.Id()).Lock()
+				if err != nil {
+					return
+				}
+				defer unlock()
+				value := counter
+				time.Sleep(time.Millisecond)
+				counter = value + 1
+			}()
+		}
+		wg.Wait()
+		t.Assert(counter, 10)
+	})
+	// Expired lock is acquired by others.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Minute, gsession.NewStorageMemory())
+		manager.SetLockTTL(50 * time.Millisecond)
+		s := manager.New()
+		_, err := s.Lock()
+		t.Assert(err, nil)
+
+		start := time.Now()
+		unlock, err := manager.New(s.Id()).Lock()
+		t.Assert(err, nil)
+		t.Assert(time.Since(start) >= 40*time.Millisecond, true)
+		unlock()
+	})
+}
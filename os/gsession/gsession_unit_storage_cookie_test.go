@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_StorageCookie(t *testing.T) {
+	var (
+		storage = gsession.NewStorageCookie([]byte("cookie-secret"))
+		value   = ""
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, storage).New()
+		t.Assert(s.SetMap(g.Map{
+			"name":  "john",
+			"score": 100,
+		}), nil)
+		id := s.Id()
+		s.Close()
+
+		value = storage.Value(id)
+		t.AssertNE(value, id)
+		t.Assert(strings.Contains(value, "john"), false)
+	})
+	// Restoring from cookie value with new manager, which has no session data in memory.
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, storage).New(value)
+		t.Assert(s.GetString("name"), "john")
+		t.Assert(s.GetInt("score"), 100)
+		s.Close()
+	})
+	// Tampered cookie value or different secret.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := storage.Decode(value[:len(value)-2]+"xx", 0)
+		t.Assert(err, gsession.ErrorCookieInvalid)
+		_, err = storage.Decode("xx"+value[2:], 0)
+		t.Assert(err, gsession.ErrorCookieInvalid)
+		_, err = gsession.NewStorageCookie([]byte("another")).Decode(value, 0)
+		t.Assert(err, gsession.ErrorCookieInvalid)
+
+		s := gsession.New(time.Minute, storage).New("xx" + value[2:])
+		t.Assert(s.Get("name"), nil)
+		s.Close()
+	})
+}
+
+func Test_StorageCookie_MaxAge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewStorageCookie([]byte("cookie-secret"))
+		storage.SetMaxAge(100 * time.Millisecond)
+		value, err := storage.Encode(g.Map{"name": "john"})
+		t.Assert(err, nil)
+
+		data, err := storage.Decode(value, 100*time.Millisecond)
+		t.Assert(err, nil)
+		t.Assert(data["name"], "john")
+
+		time.Sleep(200 * time.Millisecond)
+		_, err = storage.Decode(value, 100*time.Millisecond)
+		t.Assert(err, gsession.ErrorCookieExpired)
+
+		s := gsession.New(time.Minute, storage).New(value)
+		t.Assert(s.Get("name"), nil)
+		s.Close()
+	})
+}
+
+func Test_StorageCookie_MaxSize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewStorageCookie([]byte("cookie-secret"))
+		_, err := storage.Encode(g.Map{"data": strings.Repeat("x", gsession.CookieMaxSize)})
+		t.Assert(err, gsession.ErrorCookieTooLarge)
+
+		s := gsession.New(time.Minute, storage).New()
+		s.Set("data", strings.Repeat("x", gsession.CookieMaxSize))
+		t.Assert(storage.SetSession(s.Id(), gmap.NewStrAnyMapFrom(s.Map()), time.Minute), gsession.ErrorCookieTooLarge)
+	})
+}
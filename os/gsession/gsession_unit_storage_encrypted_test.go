@@ -0,0 +1,135 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_StorageEncrypted_File(t *testing.T) {
+	path := gfile.TempDir(gtime.TimestampNanoStr())
+	gfile.Mkdir(path)
+	defer gfile.Remove(path)
+
+	var (
+		key       = []byte("0123456789abcdef0123456789abcdef")
+		storage   = gsession.NewEncryptedStorage(gsession.NewStorageFile(path), key)
+		sessionId = ""
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, storage).New()
+		t.Assert(s.SetMap(g.Map{
+			"name":  "john",
+			"score": 100,
+		}), nil)
+		sessionId = s.Id()
+		s.Close()
+
+		content := gfile.GetContents(gfile.Join(path, sessionId))
+		t.Assert(strings.Contains(content, "john"), false)
+	})
+	// Restoring with new manager, which has no session data in memory.
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, storage).New(sessionId)
+		t.Assert(s.GetString("name"), "john")
+		t.Assert(s.GetInt("score"), 100)
+		s.Close()
+	})
+	// Different key cannot decrypt the session.
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewEncryptedStorage(gsession.NewStorageFile(path), []byte("0123456789abcdef0123456789abcdeX"))
+		data, err := storage.GetSession(sessionId, time.Minute, nil)
+		t.AssertNE(err, nil)
+		t.Assert(data, nil)
+	})
+}
+
+func Test_StorageEncrypted_RedisClient(t *testing.T) {
+	var (
+		key     = []byte("0123456789abcdef0123456789abcdef")
+		client  = newFakeRedisClient()
+		storage = gsession.NewEncryptedStorage(gsession.NewStorageRedisClient(client, gsession.RedisOptions{}), key)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Minute, storage).New()
+		t.Assert(s.Set("name", "john"), nil)
+		t.Assert(s.SetMap(g.Map{"k1": "v1", "k2": g.Slice{1, 2}}), nil)
+		t.Assert(s.GetString("name"), "john")
+		t.Assert(s.GetInts("k2"), g.Slice{1, 2})
+		t.Assert(s.Map()["k1"], "v1")
+		t.Assert(s.Size(), 3)
+		id := s.Id()
+		s.Close()
+
+		hash := client.hashes["session:"+id]
+		t.Assert(len(hash), 3)
+		t.AssertNE(hash["name"], "")
+		t.Assert(strings.Contains(hash["name"], "john"), false)
+
+		// The encrypted value cannot be moved to another key.
+		hash["k1"] = hash["name"]
+		t.Assert(storage.Get(id, "k1"), nil)
+		t.Assert(storage.Get(id, "name"), "john")
+	})
+	// Invalid key size.
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gsession.NewEncryptedStorage(gsession.NewStorageMemory(), []byte("short"))
+	})
+}
+
+func Test_StorageEncrypted_Lock(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	// The distributed lock of inner redis storage.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client  = newFakeRedisClient()
+			storage = gsession.NewEncryptedStorage(gsession.NewStorageRedisClient(client, gsession.RedisOptions{}), key)
+			s       = gsession.New(time.Minute, storage).New()
+		)
+		defer s.Close()
+		unlock, err := s.Lock()
+		t.Assert(err, nil)
+		t.Assert(client.values["session:"+s.Id()+":lock"] != "", true)
+
+		ok, err := storage.TryLock(s.Id(), "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+
+		unlock()
+		t.Assert(len(client.values), 0)
+		ok, err = storage.TryLock(s.Id(), "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		t.Assert(storage.Unlock(s.Id(), "another"), nil)
+		t.Assert(len(client.values), 0)
+	})
+	// The in-process lock for inner storage not implementing Locker.
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewEncryptedStorage(gsession.NewStorageMemory(), key)
+		ok, err := storage.TryLock("id", "token", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		ok, err = storage.TryLock("id", "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+		t.Assert(storage.Unlock("id", "token"), nil)
+		ok, err = storage.TryLock("id", "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/database/gredis"
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// gredis.Redis can be used as RedisClient directly.
+var _ gsession.RedisClient = (*gredis.Redis)(nil)
+
+// fakeRedisClient is an in-memory redis client supporting the hash commands,
+// which replies like redigo does.
+type fakeRedisClient struct {
+	mu       sync.Mutex
+	hashes   map[string]map[string]string
+	values   map[string]string
+	expires  map[string]int64
+	commands []string
+}
+
+func newFakeRedisClient() *fakeRedisClient {
+	return &fakeRedisClient{
+		hashes:  make(map[string]map[string]string),
+		values:  make(map[string]string),
+		expires: make(map[string]int64),
+	}
+}
+
+func (c *fakeRedisClient) Do(command string, args ...interface{}) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commands = append(c.commands, command)
+	if strings.ToUpper(command) == "EVAL" {
+		// Only the unlock script comparing and deleting the key is supported.
+		key, token := gconv.String(args[2]), gconv.String(args[3])
+		if v, ok := c.values[key]; ok && v == token {
+			delete(c.values, key)
+			return int64(1), nil
+		}
+		return int64(0), nil
+	}
+	key := gconv.String(args[0])
+	hash := c.hashes[key]
+	switch strings.ToUpper(command) {
+	case "HSET":
+		if hash == nil {
+			hash = make(map[string]string)
+			c.hashes[key] = hash
+		}
+		for i := 1; i+1 < len(args); i += 2 {
+			hash[gconv.String(args[i])] = gconv.String(args[i+1])
+		}
+		return int64(len(args) / 2), nil
+	case "SET":
+		// Only "SET key value NX PX ms" is supported, in which the expiration is ignored.
+		if _, ok := c.values[key]; ok {
+			return nil, nil
+		}
+		c.values[key] = gconv.String(args[1])
+		return "OK", nil
+	case "HGET":
+		if v, ok := hash[gconv.String(args[1])]; ok {
+			return []byte(v), nil
+		}
+		return nil, nil
+	case "HGETALL":
+		array := make([]interface{}, 0)
+		for k, v := range hash {
+			array = append(array, []byte(k), []byte(v))
+		}
+		return array, nil
+	case "HLEN":
+		return int64(len(hash)), nil
+	case "HDEL":
+		delete(hash, gconv.String(args[1]))
+		return int64(1), nil
+	case "DEL":
+		delete(c.hashes, key)
+		delete(c.expires, key)
+		return int64(1), nil
+	case "EXISTS":
+		if _, ok := c.hashes[key]; ok {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case "EXPIRE":
+		c.expires[key] = gconv.Int64(args[1])
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unsupported command: %s", command)
+}
+
+func Test_StorageRedisClient(t *testing.T) {
+	var (
+		client    = newFakeRedisClient()
+		storage   = gsession.NewStorageRedisClient(client, gsession.RedisOptions{})
+		manager   = gsession.New(time.Minute, storage)
+		sessionId = ""
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New()
+		defer s.Close()
+		t.Assert(s.Set("k1", "v1"), nil)
+		t.Assert(s.Set("k2", "v2"), nil)
+		t.Assert(s.SetMap(g.Map{
+			"k3": "v3",
+			"k4": "v4",
+		}), nil)
+		t.Assert(s.IsDirty(), true)
+		sessionId = s.Id()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		key := "session:" + sessionId
+		t.Assert(len(client.hashes[key]), 4)
+		t.Assert(client.hashes[key]["k1"], "v1")
+		t.Assert(client.expires[key], 60)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(sessionId)
+		defer s.Close()
+		t.Assert(s.Get("k1"), "v1")
+		t.Assert(s.Get("k4"), "v4")
+		t.Assert(s.Get("k5"), nil)
+		t.Assert(len(s.Map()), 4)
+		t.Assert(s.Map()["k2"], "v2")
+		t.Assert(s.Size(), 4)
+		t.Assert(s.Contains("k3"), true)
+		t.Assert(s.Contains("k5"), false)
+		t.Assert(s.Remove("k4"), nil)
+		t.Assert(s.Size(), 3)
+		t.Assert(s.Contains("k4"), false)
+		t.Assert(s.RemoveAll(), nil)
+		t.Assert(s.Size(), 0)
+		t.Assert(s.Contains("k1"), false)
+	})
+	// Custom prefix.
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewStorageRedisClient(client, gsession.RedisOptions{Prefix: "custom:"})
+		t.Assert(storage.Set("id", "k", "v", time.Second), nil)
+		t.Assert(client.hashes["custom:id"]["k"], "v")
+		t.Assert(client.expires["custom:id"], 1)
+	})
+}
+
+func Test_StorageRedisClient_Lock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client  = newFakeRedisClient()
+			storage = gsession.NewStorageRedisClient(client, gsession.RedisOptions{})
+			s       = gsession.New(time.Minute, storage).New()
+		)
+		defer s.Close()
+		unlock, err := s.Lock()
+		t.Assert(err, nil)
+		t.Assert(client.values["session:"+s.Id()+":lock"] != "", true)
+
+		ok, err := storage.TryLock(s.Id(), "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+
+		unlock()
+		unlock()
+		t.Assert(len(client.values), 0)
+
+		ok, err = storage.TryLock(s.Id(), "another", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		// Unlocking with wrong token does not release the lock.
+		t.Assert(storage.Unlock(s.Id(), "wrong"), nil)
+		ok, err = storage.TryLock(s.Id(), "third", time.Second)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+	})
+}
@@ -0,0 +1,200 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gsession"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+// fakeSQLRow is a row of the fake session table.
+type fakeSQLRow struct {
+	data     string
+	expireAt int64
+}
+
+// fakeSQLDriver is an in-memory database driver supporting the statements of StorageSQL only.
+type fakeSQLDriver struct {
+	mu      sync.Mutex
+	created bool
+	rows    map[string]*fakeSQLRow
+	queries []string
+}
+
+type fakeSQLConn struct{ d *fakeSQLDriver }
+type fakeSQLStmt struct {
+	d     *fakeSQLDriver
+	query string
+}
+type fakeSQLRows struct {
+	values [][]driver.Value
+}
+
+var fakeSQL = &fakeSQLDriver{rows: make(map[string]*fakeSQLRow)}
+
+func init() {
+	sql.Register("gsession-fake", fakeSQL)
+}
+
+func (d *fakeSQLDriver) Open(name string) (driver.Conn, error) { return &fakeSQLConn{d}, nil }
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{c.d, query}, nil
+}
+func (c *fakeSQLConn) Close() error              { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeSQLConn) Commit() error             { return nil }
+func (c *fakeSQLConn) Rollback() error           { return nil }
+func (s *fakeSQLStmt) Close() error              { return nil }
+func (s *fakeSQLStmt) NumInput() int             { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	if !s.d.created && !strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS session ") {
+		return nil, fmt.Errorf("table not exists")
+	}
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE"):
+		s.d.created = true
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "UPDATE session SET data = ?, expire_at = ? WHERE id = ?"):
+		if row, ok := s.d.rows[gconv.String(args[2])]; ok {
+			row.data, row.expireAt = gconv.String(args[0]), gconv.Int64(args[1])
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "UPDATE session SET expire_at = ? WHERE id = ?"):
+		if row, ok := s.d.rows[gconv.String(args[1])]; ok {
+			row.expireAt = gconv.Int64(args[0])
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "INSERT INTO session"):
+		id := gconv.String(args[0])
+		if _, ok := s.d.rows[id]; ok {
+			return nil, fmt.Errorf("duplicate key: %s", id)
+		}
+		s.d.rows[id] = &fakeSQLRow{gconv.String(args[1]), gconv.Int64(args[2])}
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "DELETE FROM session WHERE expire_at < ?"):
+		count := int64(0)
+		for id, row := range s.d.rows {
+			if row.expireAt < gconv.Int64(args[0]) {
+				delete(s.d.rows, id)
+				count++
+			}
+		}
+		return driver.RowsAffected(count), nil
+	}
+	return nil, fmt.Errorf("unsupported statement: %s", s.query)
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	if !strings.HasPrefix(s.query, "SELECT data, expire_at FROM session WHERE id = ?") {
+		return nil, fmt.Errorf("unsupported query: %s", s.query)
+	}
+	rows := &fakeSQLRows{}
+	if row, ok := s.d.rows[gconv.String(args[0])]; ok {
+		rows.values = append(rows.values, []driver.Value{row.data, row.expireAt})
+	}
+	return rows, nil
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"data", "expire_at"} }
+func (r *fakeSQLRows) Close() error      { return nil }
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func Test_StorageSQL(t *testing.T) {
+	db, err := sql.Open("gsession-fake", "")
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(err, nil)
+	})
+	defer db.Close()
+
+	var (
+		storage   = gsession.NewStorageSQL(db, "session")
+		manager   = gsession.New(time.Second, storage)
+		sessionId = ""
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New()
+		defer s.Close()
+		t.Assert(s.Set("k1", "v1"), nil)
+		t.Assert(s.SetMap(g.Map{
+			"k2": "v2",
+			"k3": 3,
+		}), nil)
+		t.Assert(s.IsDirty(), true)
+		sessionId = s.Id()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(fakeSQL.created, true)
+		t.Assert(len(fakeSQL.rows), 1)
+		t.Assert(strings.Contains(fakeSQL.rows[sessionId].data, `"k1":"v1"`), true)
+		t.Assert(fakeSQL.rows[sessionId].expireAt > time.Now().UnixNano()/1e6, true)
+	})
+	// Restoring from a new manager without memory data.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Second, storage)
+		s := manager.New(sessionId)
+		t.Assert(s.Get("k1"), "v1")
+		t.Assert(s.Get("k3"), 3)
+		t.Assert(s.Size(), 3)
+		t.Assert(s.Remove("k1"), nil)
+		s.Close()
+
+		// Updating existing row.
+		data, err := storage.GetSession(sessionId, time.Second, nil)
+		t.Assert(err, nil)
+		t.Assert(data.Size(), 2)
+		t.Assert(data.Contains("k1"), false)
+		t.Assert(len(fakeSQL.rows), 1)
+
+		data, err = storage.GetSession("none", time.Second, nil)
+		t.Assert(err, nil)
+		t.Assert(data, nil)
+	})
+	// Expiring and GC.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(storage.UpdateTTL(sessionId, -time.Second), nil)
+		data, err := storage.GetSession(sessionId, time.Second, nil)
+		t.Assert(err, nil)
+		t.Assert(data, nil)
+		t.Assert(storage.GC(context.Background()), nil)
+		t.Assert(len(fakeSQL.rows), 0)
+	})
+	// Invalid table name.
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gsession.NewStorageSQL(db, "session; DROP TABLE user")
+	})
+}
@@ -8,6 +8,7 @@ package gsession
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ichunt2019/gf/test/gtest"
 )
@@ -20,3 +21,28 @@ func Test_NewSessionId(t *testing.T) {
 		t.Assert(len(id1), 32)
 	})
 }
+
+func Test_LocalLocker_Sweep(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		locker := newLocalLocker()
+		for _, id := range []string{"1", "2", "3"} {
+			ok, err := locker.TryLock(id, "token", time.Millisecond)
+			t.Assert(err, nil)
+			t.Assert(ok, true)
+		}
+		ok, err := locker.TryLock("4", "token", time.Minute)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		time.Sleep(10 * time.Millisecond)
+		t.Assert(len(locker.locks), 4)
+
+		// The expired locks are removed after the sweep interval.
+		locker.swept = time.Now().Add(-localLockSweepInterval)
+		ok, err = locker.TryLock("5", "token", time.Minute)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		t.Assert(len(locker.locks), 2)
+		_, ok = locker.locks["4"]
+		t.Assert(ok, true)
+	})
+}
@@ -7,15 +7,19 @@
 // Package gview implements a template engine based on text/template.
 //
 // Reserved template variable names:
-//     I18nLanguage: Assign this variable to define i18n language for each page.
+//
+//	I18nLanguage: Assign this variable to define i18n language for each page.
 package gview
 
 import (
+	"sync"
+
 	"github.com/ichunt2019/gf/container/gmap"
 	"github.com/ichunt2019/gf/internal/intlog"
 
 	"github.com/ichunt2019/gf"
 	"github.com/ichunt2019/gf/container/garray"
+	"github.com/ichunt2019/gf/os/gcfg"
 	"github.com/ichunt2019/gf/os/gcmd"
 	"github.com/ichunt2019/gf/os/gfile"
 	"github.com/ichunt2019/gf/os/glog"
@@ -23,11 +27,16 @@ import (
 
 // View object for template engine.
 type View struct {
-	paths        *garray.StrArray       // Searching array for path, NOT concurrent-safe for performance purpose.
-	data         map[string]interface{} // Global template variables.
-	funcMap      map[string]interface{} // Global template function map.
-	fileCacheMap *gmap.StrAnyMap        // File cache map.
-	config       Config                 // Extra configuration for the view.
+	paths            *garray.StrArray       // Searching array for path, NOT concurrent-safe for performance purpose.
+	data             map[string]interface{} // Global template variables.
+	funcMap          map[string]interface{} // Global template function map.
+	funcMapMu        sync.RWMutex           // Mutex for concurrent safety of funcMap.
+	fileCacheMap     *gmap.StrAnyMap        // File cache map.
+	config           Config                 // Extra configuration for the view.
+	configMu         sync.RWMutex           // Mutex for concurrent safety between the configuration applying of BindConfig and parsing.
+	configBinding    *configBinding         // Configuration binding of BindConfig, which is nil if not bound.
+	configListened   []*gcfg.Config         // Configuration objects having change callback registered by BindConfig.
+	assetFingerprint *assetFingerprint      // Asset fingerprinting for cache busting, which is nil if not enabled.
 }
 
 type (
@@ -134,10 +143,16 @@ func New(path ...string) *View {
 		"tolower":    view.buildInFuncToLower,
 		"nl2br":      view.buildInFuncNl2Br,
 		"include":    view.buildInFuncInclude,
+		"extend":     view.buildInFuncExtend,
 		"dump":       view.buildInFuncDump,
 		"map":        view.buildInFuncMap,
 		"maps":       view.buildInFuncMaps,
 		"json":       view.buildInFuncJson,
+		"htmlEncode": view.buildInFuncHtmlEncode,
+		"htmlDecode": view.buildInFuncHtmlDecode,
+		"urlEncode":  view.buildInFuncUrlEncode,
+		"urlDecode":  view.buildInFuncUrlDecode,
+		"jsonEncode": view.buildInFuncJson,
 	})
 
 	return view
@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ichunt2019/gf/container/gmap"
+	"github.com/ichunt2019/gf/errors/gerror"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gfsnotify"
+)
+
+const (
+	// Length of the hex hash string in the asset fingerprint.
+	assetHashLength = 8
+)
+
+// assetFingerprint manages the content hashes of asset files for cache busting.
+type assetFingerprint struct {
+	root     string              // Real path of the asset root directory.
+	hashes   *gmap.StrStrMap     // Asset file real path to its content hash mapping.
+	callback *gfsnotify.Callback // Watching callback for asset files changes.
+}
+
+// EnableAssetFingerprinting enables asset fingerprinting for the view with asset root directory <assetRoot>,
+// which registers a template function "asset" appending the content hash of the asset file
+// as query string to the asset URL for cache busting, eg:
+// {{asset "/static/app.js"}} outputs "/static/app.js?v=1a2b3c4d".
+//
+// The hash is computed from the file content and cached, which is updated automatically
+// if the file changes. The URL is output as it is if the asset file does not exist.
+func (view *View) EnableAssetFingerprinting(assetRoot string) error {
+	root := gfile.RealPath(assetRoot)
+	if root == "" || !gfile.IsDir(root) {
+		return gerror.Newf(`asset root directory "%s" does not exist`, assetRoot)
+	}
+	fingerprint := &assetFingerprint{
+		root:   root,
+		hashes: gmap.NewStrStrMap(true),
+	}
+	callback, err := gfsnotify.Add(root, func(event *gfsnotify.Event) {
+		fingerprint.hashes.Remove(event.Path)
+	}, true)
+	if err != nil {
+		return err
+	}
+	fingerprint.callback = callback
+	if view.assetFingerprint != nil && view.assetFingerprint.callback != nil {
+		if err := gfsnotify.RemoveCallback(view.assetFingerprint.callback.Id); err != nil {
+			intlog.Error(err)
+		}
+	}
+	view.assetFingerprint = fingerprint
+	view.BindFunc("asset", view.buildInFuncAsset)
+	return nil
+}
+
+// buildInFuncAsset implements build-in template function: asset
+// It appends the content hash of asset file <path> as query string to <path>.
+func (view *View) buildInFuncAsset(path string) string {
+	fingerprint := view.assetFingerprint
+	if fingerprint == nil {
+		return path
+	}
+	var (
+		url      = path
+		fragment = ""
+	)
+	if pos := strings.IndexByte(url, '#'); pos != -1 {
+		url, fragment = url[:pos], url[pos:]
+	}
+	filePath := url
+	if pos := strings.IndexByte(filePath, '?'); pos != -1 {
+		filePath = filePath[:pos]
+	}
+	filePath = filepath.Clean(gfile.Join(fingerprint.root, strings.TrimLeft(filePath, "/\\")))
+	// The file should be under the asset root directory, but not its sibling directories
+	// sharing the same prefix like "/assets-private" for asset root "/assets".
+	relPath, err := filepath.Rel(fingerprint.root, filePath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(os.PathSeparator)) {
+		return path
+	}
+	hash := fingerprint.hashes.GetOrSetFuncLock(filePath, func() string {
+		if !gfile.IsFile(filePath) {
+			return ""
+		}
+		sum := sha256.Sum256(gfile.GetBytes(filePath))
+		return hex.EncodeToString(sum[:])[:assetHashLength]
+	})
+	if hash == "" {
+		return path
+	}
+	if strings.Contains(url, "?") {
+		return url + "&v=" + hash + fragment
+	}
+	return url + "?v=" + hash + fragment
+}
@@ -122,27 +122,56 @@ func (view *View) buildInFuncInclude(file interface{}, data ...map[string]interf
 	return htmltpl.HTML(content)
 }
 
+// buildInFuncExtend implements build-in template function: extend
+// The "extend" directive is resolved before parsing, see View.Parse. This function
+// outputs nothing, which just makes the directive valid for parsing template files
+// into the template object of their folder.
+func (view *View) buildInFuncExtend(file interface{}) string {
+	return ""
+}
+
 // buildInFuncText implements build-in template function: text
 func (view *View) buildInFuncText(html interface{}) string {
 	return ghtml.StripTags(gconv.String(html))
 }
 
-// buildInFuncHtmlEncode implements build-in template function: html
+// buildInFuncHtmlEncode implements build-in template function: html, htmlencode and htmlEncode,
+// which escapes special characters like "<" to become "&lt;" following html.EscapeString.
+//
+// Example:
+//
+//	{{"<b>gf</b>" | htmlEncode}} outputs: &lt;b&gt;gf&lt;/b&gt;
 func (view *View) buildInFuncHtmlEncode(html interface{}) string {
 	return ghtml.Entities(gconv.String(html))
 }
 
-// buildInFuncHtmlDecode implements build-in template function: htmldecode
+// buildInFuncHtmlDecode implements build-in template function: htmldecode and htmlDecode,
+// which unescapes entities like "&lt;" to become "<" following html.UnescapeString.
+//
+// Example:
+//
+//	{{"&lt;b&gt;gf&lt;/b&gt;" | htmlDecode}} outputs: <b>gf</b>
 func (view *View) buildInFuncHtmlDecode(html interface{}) string {
 	return ghtml.EntitiesDecode(gconv.String(html))
 }
 
-// buildInFuncUrlEncode implements build-in template function: url
+// buildInFuncUrlEncode implements build-in template function: url, urlencode and urlEncode,
+// which escapes the string so it can be safely placed inside a URL query following url.QueryEscape.
+//
+// Example:
+//
+//	{{"a b&c" | urlEncode}} outputs: a+b%26c
 func (view *View) buildInFuncUrlEncode(url interface{}) string {
 	return gurl.Encode(gconv.String(url))
 }
 
-// buildInFuncUrlDecode implements build-in template function: urldecode
+// buildInFuncUrlDecode implements build-in template function: urldecode and urlDecode,
+// which does the inverse transformation of urlEncode following url.QueryUnescape.
+// It outputs the error message if the string is not a valid escaped string.
+//
+// Example:
+//
+//	{{"a+b%26c" | urlDecode}} outputs: a b&c
 func (view *View) buildInFuncUrlDecode(url interface{}) string {
 	if content, err := gurl.Decode(gconv.String(url)); err == nil {
 		return content
@@ -217,8 +246,12 @@ func (view *View) buildInFuncNl2Br(str interface{}) string {
 	return gstr.Nl2Br(gconv.String(str))
 }
 
-// buildInFuncJson implements build-in template function: json ,
-// which encodes and returns <value> as JSON string.
+// buildInFuncJson implements build-in template function: json and jsonEncode,
+// which encodes and returns <value> as JSON string following json.Marshal.
+//
+// Example:
+//
+//	{{.user | jsonEncode}} outputs: {"name":"gf"}
 func (view *View) buildInFuncJson(value interface{}) (string, error) {
 	b, err := json.Marshal(value)
 	return gconv.UnsafeBytesToStr(b), err
@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"strings"
+	"time"
+)
+
+// CacheEntry is the statistics of a cached template file.
+type CacheEntry struct {
+	Path     string    // Absolute path of the template file.
+	LoadTime time.Time // Time when the template file is loaded.
+	Hits     int64     // Hit count of the cache, not including the loading.
+}
+
+// CacheStats returns the statistics of the cached template files of the view,
+// which is keyed by the template file name passed to Parse.
+func (view *View) CacheStats() map[string]CacheEntry {
+	stats := make(map[string]CacheEntry)
+	view.fileCacheMap.Iterator(func(k string, v interface{}) bool {
+		if item, ok := v.(*fileCacheItem); ok {
+			stats[k] = CacheEntry{
+				Path:     item.path,
+				LoadTime: item.loadTime,
+				Hits:     item.hits.Val(),
+			}
+		}
+		return true
+	})
+	return stats
+}
+
+// InvalidateCache removes the cache of template file <file>, which is the file name passed to Parse,
+// so that the next parsing reloads it from disk. It does what the file watcher does if the template
+// file is changed, but only for <file>.
+func (view *View) InvalidateCache(file string) {
+	r := view.fileCacheMap.Remove(file)
+	if r == nil {
+		return
+	}
+	// The template object is cached with key prefixed with its file path.
+	prefix := r.(*fileCacheItem).path + "_"
+	keys := make([]string, 0)
+	templates.Iterator(func(k string, v interface{}) bool {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	templates.Removes(keys)
+}
+
+// InvalidateAllCache removes all the template caches, so that the next parsing reloads
+// the template files from disk. It does what the file watcher does if any template file is changed.
+func (view *View) InvalidateAllCache() {
+	view.fileCacheMap.Clear()
+	templates.Clear()
+}
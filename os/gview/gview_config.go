@@ -26,6 +26,7 @@ type Config struct {
 	DefaultFile string                 `json:"defaultFile"` // Default template file for parsing.
 	Delimiters  []string               `json:"delimiters"`  // Custom template delimiters.
 	AutoEncode  bool                   `json:"autoEncode"`  // Automatically encodes and provides safe html output, which is good for avoiding XSS.
+	DebugMode   bool                   `json:"debugMode"`   // Debug mode, in which the execution error contains the source line of the failing template node.
 	I18nManager *gi18n.Manager         `json:"-"`           // I18n manager for the view.
 }
 
@@ -242,7 +243,9 @@ func (view *View) SetAutoEncode(enable bool) {
 // with given function <function> to current view object.
 // The <name> is the function name which can be called in template content.
 func (view *View) BindFunc(name string, function interface{}) {
+	view.funcMapMu.Lock()
 	view.funcMap[name] = function
+	view.funcMapMu.Unlock()
 	// Clear global template object cache.
 	templates.Clear()
 }
@@ -251,13 +254,50 @@ func (view *View) BindFunc(name string, function interface{}) {
 // The key of map is the template function name
 // and the value of map is the address of customized function.
 func (view *View) BindFuncMap(funcMap FuncMap) {
+	view.funcMapMu.Lock()
 	for k, v := range funcMap {
 		view.funcMap[k] = v
 	}
+	view.funcMapMu.Unlock()
 	// Clear global template object cache.
 	templates.Clear()
 }
 
+// RegisterFunc registers customized global template function named <name> with <fn> like BindFunc,
+// and returns the view object for chaining, eg:
+//
+//	view.RegisterFunc("upper", strings.ToUpper).RegisterFunc("lower", strings.ToLower)
+//
+// It is concurrent-safe. The cached templates are invalidated after registration,
+// so that the templates referencing the overwritten function use the new one.
+func (view *View) RegisterFunc(name string, fn interface{}) *View {
+	view.BindFunc(name, fn)
+	return view
+}
+
+// RegisterFuncMap registers customized global template functions by map like BindFuncMap,
+// and returns the view object for chaining. Both text/template.FuncMap and html/template.FuncMap
+// can be passed as <funcs>.
+//
+// It is concurrent-safe. The cached templates are invalidated after registration,
+// so that the templates referencing the overwritten functions use the new ones.
+func (view *View) RegisterFuncMap(funcs FuncMap) *View {
+	view.BindFuncMap(funcs)
+	return view
+}
+
+// getFuncMap returns a copy of the template function map of current view object,
+// which is used for template object creating.
+func (view *View) getFuncMap() FuncMap {
+	view.funcMapMu.RLock()
+	defer view.funcMapMu.RUnlock()
+	funcMap := make(FuncMap, len(view.funcMap))
+	for k, v := range view.funcMap {
+		funcMap[k] = v
+	}
+	return funcMap
+}
+
 // SetI18n binds i18n manager to current view engine.
 func (view *View) SetI18n(manager *gi18n.Manager) {
 	view.config.I18nManager = manager
@@ -0,0 +1,110 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ichunt2019/gf/i18n/gi18n"
+	"github.com/ichunt2019/gf/internal/intlog"
+	"github.com/ichunt2019/gf/os/gcfg"
+	"github.com/ichunt2019/gf/util/gconv"
+	"github.com/ichunt2019/gf/util/gutil"
+)
+
+// configBinding is the configuration binding of BindConfig.
+type configBinding struct {
+	cfg     *gcfg.Config // Bound configuration object.
+	section string       // Bound configuration section.
+}
+
+// BindConfig applies the view configuration of section <section> of configuration object <cfg>,
+// and re-applies it whenever the configuration changes.
+//
+// The section has the same configuration items as SetConfigWithMap, in which the "paths" or "path"
+// replaces the current template paths. The optional "i18n" item configures a new i18n manager
+// for the view, with items of gi18n.Options, eg:
+//
+//	[viewer]
+//	    paths      = ["template"]
+//	    delimiters = ["${", "}"]
+//	    [viewer.i18n]
+//	        path     = "i18n"
+//	        language = "en"
+//
+// The template caches are cleared in every applying, so that the changes take effect
+// in the next parsing. The applying is synchronized with the concurrent parsing,
+// which uses either the old or the new configuration completely.
+//
+// Calling BindConfig again replaces the previous binding, which stops applying the changes of
+// the previously bound configuration object.
+func (view *View) BindConfig(cfg *gcfg.Config, section string) error {
+	view.configMu.Lock()
+	defer view.configMu.Unlock()
+	if err := view.applyConfig(cfg, section); err != nil {
+		return err
+	}
+	view.configBinding = &configBinding{
+		cfg:     cfg,
+		section: section,
+	}
+	// The change callback is registered only once for each configuration object,
+	// as the callback cannot be removed from the configuration object.
+	for _, v := range view.configListened {
+		if v == cfg {
+			return nil
+		}
+	}
+	view.configListened = append(view.configListened, cfg)
+	cfg.OnChange(func(file string) {
+		view.configMu.Lock()
+		defer view.configMu.Unlock()
+		binding := view.configBinding
+		if binding == nil || binding.cfg != cfg || file != cfg.GetFileName() {
+			return
+		}
+		if err := view.applyConfig(cfg, binding.section); err != nil {
+			intlog.Error(err)
+		}
+	})
+	return nil
+}
+
+// applyConfig applies the view configuration of section <section> of configuration object <cfg>.
+// Note that it should be called with configMu locked.
+func (view *View) applyConfig(cfg *gcfg.Config, section string) error {
+	m := cfg.GetMap(section)
+	if len(m) == 0 {
+		return errors.New(fmt.Sprintf(`[gview] configuration section "%s" not found`, section))
+	}
+	m = gutil.MapCopy(m)
+	var i18nOptions *gi18n.Options
+	if k, v := gutil.MapPossibleItemByKey(m, "i18n"); v != nil {
+		delete(m, k)
+		i18nOptions = &gi18n.Options{}
+		if err := gconv.Struct(v, i18nOptions); err != nil {
+			return err
+		}
+	}
+	// The paths are replaced instead of appended.
+	_, v1 := gutil.MapPossibleItemByKey(m, "paths")
+	_, v2 := gutil.MapPossibleItemByKey(m, "path")
+	if v1 != nil || v2 != nil {
+		view.paths.Clear()
+	}
+	if len(m) > 0 {
+		if err := view.SetConfigWithMap(m); err != nil {
+			return err
+		}
+	}
+	if i18nOptions != nil {
+		view.SetI18n(gi18n.New(*i18nOptions))
+	}
+	view.InvalidateAllCache()
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ichunt2019/gf/encoding/ghtml"
+	"github.com/ichunt2019/gf/errors/gerror"
+	"github.com/ichunt2019/gf/os/glog"
+	"github.com/ichunt2019/gf/text/gregex"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+const (
+	// Pattern for retrieving the line number and failing node from template execution error.
+	debugErrorPattern = `template: .+?:(\d+):\d+: executing ".*?" at <(.+?)>`
+
+	// Count of source lines displayed around the failing line in debug snippet.
+	debugContextLines = 2
+)
+
+// debugInfo holds the source location of a failing template node.
+type debugInfo struct {
+	file   string // Template file name or path.
+	line   int    // Line number of the failing node, starting from 1.
+	source string // Source content of the template.
+	err    error  // Original execution error.
+}
+
+// SetDebugMode enables/disables the debug mode for the view.
+// In debug mode, the template execution error contains the file name and line
+// number of the failing template node.
+func (view *View) SetDebugMode(enabled bool) {
+	view.config.DebugMode = enabled
+}
+
+// handleExecutionError handles the template execution error <err> of template <file>
+// with template content <content>.
+//
+// It returns the original error if debug mode is disabled. Or else it returns an error
+// containing the source location. The returned <result> is an HTML snippet describing the error
+// if AutoEncode feature is enabled, or else the error is printed to the logger.
+func (view *View) handleExecutionError(file, content string, err error) (result string, newErr error) {
+	view.configMu.RLock()
+	debugMode, autoEncode := view.config.DebugMode, view.config.AutoEncode
+	view.configMu.RUnlock()
+	if !debugMode {
+		return "", err
+	}
+	info := newDebugInfo(file, content, err)
+	newErr = gerror.Wrapf(
+		err, `[gview] template execution failed in "%s" at line %d: %s`,
+		info.file, info.line, strings.TrimSpace(info.lineContent(info.line)),
+	)
+	if autoEncode {
+		return info.htmlSnippet(), newErr
+	}
+	if errorPrint() {
+		glog.Errorf(
+			`[gview] template execution failed: file="%s" line=%d source="%s" error="%s"`,
+			info.file, info.line, strings.TrimSpace(info.lineContent(info.line)), err.Error(),
+		)
+	}
+	return "", newErr
+}
+
+// newDebugInfo creates and returns a debugInfo object for execution error <err>.
+// The line number is retrieved from the error message. If the error message carries no
+// valid line number, it is calculated by counting newlines in the template source up to
+// the offset of the failing node.
+func newDebugInfo(file, content string, err error) *debugInfo {
+	info := &debugInfo{
+		file:   file,
+		source: content,
+		err:    err,
+	}
+	match, _ := gregex.MatchString(debugErrorPattern, err.Error())
+	if len(match) > 2 {
+		info.line = gconv.Int(match[1])
+		if info.line <= 0 {
+			if offset := strings.Index(content, match[2]); offset >= 0 {
+				info.line = lineNumberByOffset(content, offset)
+			}
+		}
+	}
+	return info
+}
+
+// lineNumberByOffset returns the line number of position <offset> in <content>,
+// which starts from 1.
+func lineNumberByOffset(content string, offset int) int {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// lineContent returns the content of source line <line>.
+// It returns empty string if <line> is out of range.
+func (info *debugInfo) lineContent(line int) string {
+	lines := strings.Split(info.source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[line-1], "\r")
+}
+
+// htmlSnippet returns a browser-renderable HTML snippet displaying the error
+// and the source lines around the failing line, in which the failing line is highlighted.
+func (info *debugInfo) htmlSnippet() string {
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteString(`<div class="gview-debug">`)
+	buffer.WriteString(fmt.Sprintf(
+		`<p><strong>Template Error:</strong> %s:%d</p>`,
+		ghtml.SpecialChars(info.file), info.line,
+	))
+	buffer.WriteString(fmt.Sprintf(`<p>%s</p>`, ghtml.SpecialChars(info.err.Error())))
+	if info.line > 0 {
+		buffer.WriteString(`<pre>`)
+		for i := info.line - debugContextLines; i <= info.line+debugContextLines; i++ {
+			if i < 1 || i > strings.Count(info.source, "\n")+1 {
+				continue
+			}
+			content := fmt.Sprintf(`%4d | %s`, i, ghtml.SpecialChars(info.lineContent(i)))
+			if i == info.line {
+				buffer.WriteString(fmt.Sprintf(`<mark>%s</mark>`, content))
+			} else {
+				buffer.WriteString(content)
+			}
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(`</pre>`)
+	}
+	buffer.WriteString(`</div>`)
+	return buffer.String()
+}
@@ -0,0 +1,241 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Max depth of the template inheritance chain.
+	extendMaxDepth = 10
+)
+
+// templateAction is an action of template content, eg: {{if .name}}.
+type templateAction struct {
+	start int    // Start position of the action in content, which is the position of the left delimiter.
+	end   int    // End position of the action in content, which is the position after the right delimiter.
+	word  string // First word of the action, eg: if, block, end.
+	args  string // Arguments of the action following the first word.
+}
+
+// resolveExtend resolves the template inheritance of <content> from template file <path>.
+//
+// The template content beginning with directive {{extend "base.html"}} inherits the layout of
+// the parent template "base.html", whose blocks {{block "name" .}}...{{end}} are replaced with the
+// blocks of the same name defined by the child template using either {{block}} or {{define}}.
+// The content of child template outside its blocks is ignored. The parent template can also
+// extend another template, in which case the blocks of the child take precedence.
+//
+// It returns <content> directly if it does not begin with the "extend" directive.
+func (view *View) resolveExtend(path, content string) (string, error) {
+	if !strings.Contains(content, "extend") {
+		return content, nil
+	}
+	var (
+		visited = map[string]struct{}{path: {}}
+		blocks  = make(map[string]string)
+	)
+	for depth := 0; ; depth++ {
+		actions := view.scanActions(content)
+		if len(actions) == 0 || actions[0].word != "extend" {
+			break
+		}
+		if depth >= extendMaxDepth {
+			return "", errors.New(fmt.Sprintf(`template "%s" extends too deep, max depth is %d`, path, extendMaxDepth))
+		}
+		parent, err := strconv.Unquote(actions[0].args)
+		if err != nil || parent == "" {
+			return "", errors.New(fmt.Sprintf(`invalid extend directive in template "%s": %s`, path, actions[0].args))
+		}
+		childBlocks, err := topLevelBlocks(content, actions)
+		if err != nil {
+			return "", errors.New(fmt.Sprintf(`%s in template "%s"`, err.Error(), path))
+		}
+		// The blocks of child template take precedence over the ones of parent template.
+		for name, body := range childBlocks {
+			if _, ok := blocks[name]; !ok {
+				blocks[name] = body
+			}
+		}
+		item, err := view.getFileCacheItem(parent)
+		if err != nil {
+			return "", err
+		}
+		if item == nil {
+			return "", errors.New(fmt.Sprintf(`template file "%s" not found`, parent))
+		}
+		if _, ok := visited[item.path]; ok {
+			return "", errors.New(fmt.Sprintf(`circular extend of template "%s"`, item.path))
+		}
+		visited[item.path] = struct{}{}
+		content = item.content
+	}
+	if len(visited) == 1 {
+		return content, nil
+	}
+	return view.replaceBlocks(content, blocks), nil
+}
+
+// replaceBlocks replaces the bodies of {{block}} actions in <content> with the ones in <blocks>
+// of the same names. The nested blocks of the replaced bodies are replaced recursively.
+func (view *View) replaceBlocks(content string, blocks map[string]string) string {
+	var (
+		actions = view.scanActions(content)
+		buffer  = bytes.NewBuffer(nil)
+		last    = 0
+	)
+	for i := 0; i < len(actions); i++ {
+		if actions[i].word != "block" {
+			continue
+		}
+		name, ok := actionName(actions[i].args)
+		if !ok {
+			continue
+		}
+		body, ok := blocks[name]
+		if !ok {
+			continue
+		}
+		end := matchEnd(actions, i)
+		if end == -1 {
+			// It leaves the error to template parsing.
+			break
+		}
+		// The block itself is excluded for the nested blocks replacing, to avoid infinite recursion.
+		nestedBlocks := make(map[string]string, len(blocks))
+		for k, v := range blocks {
+			if k != name {
+				nestedBlocks[k] = v
+			}
+		}
+		buffer.WriteString(content[last:actions[i].end])
+		buffer.WriteString(view.replaceBlocks(body, nestedBlocks))
+		last = actions[end].start
+		i = end
+	}
+	buffer.WriteString(content[last:])
+	return buffer.String()
+}
+
+// scanActions scans and returns all the actions of <content> using configured delimiters.
+func (view *View) scanActions(content string) []templateAction {
+	var (
+		left    = view.config.Delimiters[0]
+		right   = view.config.Delimiters[1]
+		actions = make([]templateAction, 0)
+	)
+	if left == "" || right == "" {
+		return actions
+	}
+	for pos := 0; pos < len(content); {
+		i := strings.Index(content[pos:], left)
+		if i == -1 {
+			break
+		}
+		start := pos + i
+		j := strings.Index(content[start+len(left):], right)
+		if j == -1 {
+			break
+		}
+		var (
+			end  = start + len(left) + j + len(right)
+			text = content[start+len(left) : start+len(left)+j]
+		)
+		// Trim markers, eg: {{- if .name -}}.
+		if strings.HasPrefix(text, "- ") {
+			text = text[1:]
+		}
+		if strings.HasSuffix(text, " -") {
+			text = text[:len(text)-1]
+		}
+		text = strings.TrimSpace(text)
+		action := templateAction{
+			start: start,
+			end:   end,
+			word:  text,
+		}
+		if k := strings.IndexAny(text, " \t\r\n"); k != -1 {
+			action.word = text[:k]
+			action.args = strings.TrimSpace(text[k+1:])
+		}
+		actions = append(actions, action)
+		pos = end
+	}
+	return actions
+}
+
+// topLevelBlocks returns the top level {{block}} and {{define}} actions of child template <content>
+// as name to body mapping, exclusive of the first "extend" action.
+func topLevelBlocks(content string, actions []templateAction) (map[string]string, error) {
+	blocks := make(map[string]string)
+	for i := 1; i < len(actions); i++ {
+		if !isOpeningAction(actions[i].word) {
+			continue
+		}
+		end := matchEnd(actions, i)
+		if end == -1 {
+			return nil, errors.New(fmt.Sprintf(`unclosed action "%s"`, actions[i].word))
+		}
+		if actions[i].word == "block" || actions[i].word == "define" {
+			if name, ok := actionName(actions[i].args); ok {
+				blocks[name] = content[actions[i].end:actions[end].start]
+			}
+		}
+		i = end
+	}
+	return blocks, nil
+}
+
+// matchEnd returns the index of the {{end}} action of the opening action at <index> of <actions>.
+// It returns -1 if not found.
+func matchEnd(actions []templateAction, index int) int {
+	depth := 0
+	for i := index + 1; i < len(actions); i++ {
+		if isOpeningAction(actions[i].word) {
+			depth++
+		} else if actions[i].word == "end" {
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// isOpeningAction checks and returns whether the action of <word> requires an {{end}} action.
+func isOpeningAction(word string) bool {
+	switch word {
+	case "if", "range", "with", "block", "define":
+		return true
+	}
+	return false
+}
+
+// actionName retrieves and returns the quoted template name of {{block}} or {{define}} arguments <args>.
+func actionName(args string) (string, bool) {
+	if len(args) < 2 || (args[0] != '"' && args[0] != '`') {
+		return "", false
+	}
+	quote := args[0]
+	for i := 1; i < len(args); i++ {
+		if args[i] == '\\' && quote == '"' {
+			i++
+			continue
+		}
+		if args[i] == quote {
+			name, err := strconv.Unquote(args[:i+1])
+			return name, err == nil
+		}
+	}
+	return "", false
+}
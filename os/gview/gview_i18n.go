@@ -10,14 +10,17 @@ import "github.com/ichunt2019/gf/util/gconv"
 
 // i18nTranslate translate the content with i18n feature.
 func (view *View) i18nTranslate(content string, params Params) string {
-	if view.config.I18nManager != nil {
+	view.configMu.RLock()
+	manager := view.config.I18nManager
+	view.configMu.RUnlock()
+	if manager != nil {
 		if v, ok := params["I18nLanguage"]; ok {
 			language := gconv.String(v)
 			if language != "" {
-				return view.config.I18nManager.T(content, language)
+				return manager.T(content, language)
 			}
 		}
-		return view.config.I18nManager.T(content)
+		return manager.T(content)
 	}
 	return content
 }
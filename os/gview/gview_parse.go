@@ -10,18 +10,18 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"github.com/ichunt2019/gf/encoding/ghash"
+	"github.com/ichunt2019/gf/container/gtype"
 	"github.com/ichunt2019/gf/errors/gerror"
 	"github.com/ichunt2019/gf/internal/intlog"
 	"github.com/ichunt2019/gf/os/gfsnotify"
-	"github.com/ichunt2019/gf/os/gmlock"
 	"github.com/ichunt2019/gf/text/gstr"
 	"github.com/ichunt2019/gf/util/gconv"
 	"github.com/ichunt2019/gf/util/gutil"
 	htmltpl "html/template"
-	"strconv"
+	"io"
 	"strings"
 	texttpl "text/template"
+	"time"
 
 	"github.com/ichunt2019/gf/os/gres"
 
@@ -38,9 +38,11 @@ const (
 
 // fileCacheItem is the cache item for template file.
 type fileCacheItem struct {
-	path    string
-	folder  string
-	content string
+	path     string
+	folder   string
+	content  string
+	loadTime time.Time    // Time when the file is loaded.
+	hits     *gtype.Int64 // Hit count of the cache item, not including the loading.
 }
 
 var (
@@ -55,9 +57,168 @@ var (
 // Parse parses given template file <file> with given template variables <params>
 // and returns the parsed template content.
 func (view *View) Parse(file string, params ...Params) (result string, err error) {
-	var tpl interface{}
-	// It caches the file, folder and its content to enhance performance.
+	tpl, item, content, err := view.parseFile(file)
+	if tpl == nil {
+		return "", err
+	}
+	variables := view.mergeVariables(params...)
+	buffer := bytes.NewBuffer(nil)
+	if err = view.executeTemplate(tpl, buffer, variables); err != nil {
+		return view.handleExecutionError(item.path, content, err)
+	}
+	// TODO any graceful plan to replace "<no value>"?
+	result = gstr.Replace(buffer.String(), "<no value>", "")
+	result = view.i18nTranslate(result, variables)
+	return result, nil
+}
+
+// WriteTo parses given template file <file> with given template variables <params>
+// and writes the parsed template content to <w> as it is produced,
+// which does not buffer the entire content like Parse.
+//
+// Note that the content is written line by line, as the removing of "<no value>"
+// and the i18n translation are performed on complete lines.
+func (view *View) WriteTo(w io.Writer, file string, params Params) error {
+	tpl, item, content, err := view.parseFile(file)
+	if tpl == nil {
+		return err
+	}
+	var (
+		variables = view.mergeVariables(params)
+		writer    = view.newStreamWriter(w, variables)
+	)
+	if err = view.executeTemplate(tpl, writer, variables); err == nil {
+		return writer.Flush()
+	}
+	writer.Flush()
+	result, err := view.handleExecutionError(item.path, content, err)
+	if result != "" {
+		io.WriteString(w, result)
+	}
+	return err
+}
+
+// RenderBlock parses given template file <file> with given template variables <params>,
+// and returns the parsed content of the template block <blockName> only,
+// which is defined using "define" or "block" action in the template file.
+// It is usually used for responding partial HTML fragments of a page.
+func (view *View) RenderBlock(file, blockName string, params Params) (string, error) {
+	tpl, item, content, err := view.parseFile(file)
+	if tpl == nil {
+		if err == nil {
+			err = gerror.Newf(`template block "%s" not found in "%s"`, blockName, file)
+		}
+		return "", err
+	}
+	// The template object of the block shares the namespace with the file template object,
+	// so that the block can use the other blocks of the file.
+	var block interface{}
+	if t, ok := tpl.(*htmltpl.Template); ok {
+		if t = t.Lookup(blockName); t != nil {
+			block = t
+		}
+	} else {
+		if t := tpl.(*texttpl.Template).Lookup(blockName); t != nil {
+			block = t
+		}
+	}
+	if block == nil {
+		return "", gerror.Newf(`template block "%s" not found in "%s"`, blockName, item.path)
+	}
+	var (
+		variables = view.mergeVariables(params)
+		buffer    = bytes.NewBuffer(nil)
+	)
+	if err = view.executeTemplate(block, buffer, variables); err != nil {
+		return view.handleExecutionError(item.path, content, err)
+	}
+	result := gstr.Replace(buffer.String(), "<no value>", "")
+	result = view.i18nTranslate(result, variables)
+	return result, nil
+}
+
+// parseFile searches and parses template file <file>, and returns the parsed template object,
+// the cache item and the template content resolving the "extend" directive.
+// The returned <tpl> is nil if error occurs or the template content is empty.
+func (view *View) parseFile(file string) (tpl interface{}, item *fileCacheItem, content string, err error) {
+	// The configuration should not be changed by BindConfig during parsing.
+	view.configMu.RLock()
+	defer view.configMu.RUnlock()
+	item, err = view.getFileCacheItem(file)
+	if item == nil {
+		return
+	}
+	// It's not necessary continuing parsing if template content is empty.
+	if item.content == "" {
+		return nil, item, "", nil
+	}
+	// Resolving the template inheritance of "extend" directive.
+	content, err = view.resolveExtend(item.path, item.content)
+	if err != nil {
+		return nil, item, "", err
+	}
+	// Get the template object instance for <folder>.
+	tpl, err = view.getTemplate(item.path, item.folder, fmt.Sprintf(`*%s`, gfile.Ext(item.path)))
+	if err != nil {
+		return nil, item, content, err
+	}
+	// The cached template is shared by all parsing of the folder, so it parses and executes
+	// the content on its clone for concurrent safety.
+	if view.config.AutoEncode {
+		var newTpl *htmltpl.Template
+		if newTpl, err = tpl.(*htmltpl.Template).Clone(); err == nil {
+			tpl, err = newTpl.Parse(content)
+		}
+	} else {
+		var newTpl *texttpl.Template
+		if newTpl, err = tpl.(*texttpl.Template).Clone(); err == nil {
+			tpl, err = newTpl.Parse(content)
+		}
+	}
+	if err != nil && item.path != "" {
+		err = gerror.Wrap(err, item.path)
+	}
+	if err != nil {
+		return nil, item, content, err
+	}
+	return tpl, item, content, nil
+}
+
+// mergeVariables merges <params> and the view variables into a new map.
+//
+// Note that the template variable assignment cannot change the value
+// of the existing <params> or view.data because both variables are pointers.
+// It needs to merge the values of the two maps into a new map.
+func (view *View) mergeVariables(params ...Params) map[string]interface{} {
+	variables := gutil.MapMergeCopy(params...)
+	view.configMu.RLock()
+	defer view.configMu.RUnlock()
+	if len(view.data) > 0 {
+		gutil.MapMerge(variables, view.data)
+	}
+	return variables
+}
+
+// executeTemplate executes the parsed template object <tpl> with <variables>,
+// writing the output to <w>.
+// It checks the type of <tpl> instead of the AutoEncode configuration, which might be changed
+// by BindConfig after parsing.
+func (view *View) executeTemplate(tpl interface{}, w io.Writer, variables map[string]interface{}) error {
+	if t, ok := tpl.(*htmltpl.Template); ok {
+		return t.Execute(w, variables)
+	}
+	return tpl.(*texttpl.Template).Execute(w, variables)
+}
+
+// getFileCacheItem searches and returns the cache item of template file <file>.
+// It caches the file, folder and its content to enhance performance.
+func (view *View) getFileCacheItem(file string) (*fileCacheItem, error) {
+	var (
+		err    error
+		loaded bool
+	)
 	r := view.fileCacheMap.GetOrSetFuncLock(file, func() interface{} {
+		loaded = true
 		var (
 			path     string
 			folder   string
@@ -72,7 +233,9 @@ func (view *View) Parse(file string, params ...Params) (result string, err error
 		if resource != nil {
 			content = gconv.UnsafeBytesToStr(resource.Content())
 		} else {
-			content = gfile.GetContentsWithCache(path)
+			// It reads the file without gfile cache, as the content is cached by fileCacheMap,
+			// which makes the invalidation of fileCacheMap reloading the file from disk.
+			content = gfile.GetContents(path)
 		}
 		// Monitor template files changes using fsnotify asynchronously.
 		if resource == nil {
@@ -86,69 +249,29 @@ func (view *View) Parse(file string, params ...Params) (result string, err error
 			}
 		}
 		return &fileCacheItem{
-			path:    path,
-			folder:  folder,
-			content: content,
+			path:     path,
+			folder:   folder,
+			content:  content,
+			loadTime: time.Now(),
+			hits:     gtype.NewInt64(),
 		}
 	})
 	if r == nil {
-		return
+		return nil, err
 	}
 	item := r.(*fileCacheItem)
-	// It's not necessary continuing parsing if template content is empty.
-	if item.content == "" {
-		return "", nil
-	}
-	// Get the template object instance for <folder>.
-	tpl, err = view.getTemplate(item.path, item.folder, fmt.Sprintf(`*%s`, gfile.Ext(item.path)))
-	if err != nil {
-		return "", err
-	}
-	// Using memory lock to ensure concurrent safety for template parsing.
-	gmlock.LockFunc("gview.Parse:"+item.path, func() {
-		if view.config.AutoEncode {
-			tpl, err = tpl.(*htmltpl.Template).Parse(item.content)
-		} else {
-			tpl, err = tpl.(*texttpl.Template).Parse(item.content)
-		}
-		if err != nil && item.path != "" {
-			err = gerror.Wrap(err, item.path)
-		}
-	})
-	if err != nil {
-		return "", err
+	if !loaded {
+		item.hits.Add(1)
 	}
-	// Note that the template variable assignment cannot change the value
-	// of the existing <params> or view.data because both variables are pointers.
-	// It needs to merge the values of the two maps into a new map.
-	variables := gutil.MapMergeCopy(params...)
-	if len(view.data) > 0 {
-		gutil.MapMerge(variables, view.data)
-	}
-	buffer := bytes.NewBuffer(nil)
-	if view.config.AutoEncode {
-		newTpl, err := tpl.(*htmltpl.Template).Clone()
-		if err != nil {
-			return "", err
-		}
-		if err := newTpl.Execute(buffer, variables); err != nil {
-			return "", err
-		}
-	} else {
-		if err := tpl.(*texttpl.Template).Execute(buffer, variables); err != nil {
-			return "", err
-		}
-	}
-
-	// TODO any graceful plan to replace "<no value>"?
-	result = gstr.Replace(buffer.String(), "<no value>", "")
-	result = view.i18nTranslate(result, variables)
-	return result, nil
+	return item, nil
 }
 
 // ParseDefault parses the default template file with params.
 func (view *View) ParseDefault(params ...Params) (result string, err error) {
-	return view.Parse(view.config.DefaultFile, params...)
+	view.configMu.RLock()
+	file := view.config.DefaultFile
+	view.configMu.RUnlock()
+	return view.Parse(file, params...)
 }
 
 // ParseContent parses given template content <content>  with template variables <params>
@@ -158,57 +281,55 @@ func (view *View) ParseContent(content string, params ...Params) (string, error)
 	if content == "" {
 		return "", nil
 	}
-	err := (error)(nil)
+	tpl, err := view.parseContent(content)
+	if err != nil {
+		return "", err
+	}
+	var (
+		variables = view.mergeVariables(params...)
+		buffer    = bytes.NewBuffer(nil)
+	)
+	if err = view.executeTemplate(tpl, buffer, variables); err != nil {
+		return view.handleExecutionError(templateNameForContentParsing, content, err)
+	}
+	// TODO any graceful plan to replace "<no value>"?
+	result := gstr.Replace(buffer.String(), "<no value>", "")
+	result = view.i18nTranslate(result, variables)
+	return result, nil
+}
+
+// parseContent parses template content <content>, and returns the parsed template object.
+func (view *View) parseContent(content string) (tpl interface{}, err error) {
+	// The configuration should not be changed by BindConfig during parsing.
+	view.configMu.RLock()
+	defer view.configMu.RUnlock()
 	key := fmt.Sprintf("%s_%v_%v", templateNameForContentParsing, view.config.Delimiters, view.config.AutoEncode)
-	tpl := templates.GetOrSetFuncLock(key, func() interface{} {
+	tpl = templates.GetOrSetFuncLock(key, func() interface{} {
 		if view.config.AutoEncode {
 			return htmltpl.New(templateNameForContentParsing).Delims(
 				view.config.Delimiters[0],
 				view.config.Delimiters[1],
-			).Funcs(view.funcMap)
+			).Funcs(view.getFuncMap())
 		}
 		return texttpl.New(templateNameForContentParsing).Delims(
 			view.config.Delimiters[0],
 			view.config.Delimiters[1],
-		).Funcs(view.funcMap)
-	})
-	// Using memory lock to ensure concurrent safety for content parsing.
-	hash := strconv.FormatUint(ghash.DJBHash64([]byte(content)), 10)
-	gmlock.LockFunc("gview.ParseContent:"+hash, func() {
-		if view.config.AutoEncode {
-			tpl, err = tpl.(*htmltpl.Template).Parse(content)
-		} else {
-			tpl, err = tpl.(*texttpl.Template).Parse(content)
-		}
+		).Funcs(view.getFuncMap())
 	})
-	if err != nil {
-		return "", err
-	}
-	// Note that the template variable assignment cannot change the value
-	// of the existing <params> or view.data because both variables are pointers.
-	// It needs to merge the values of the two maps into a new map.
-	variables := gutil.MapMergeCopy(params...)
-	if len(view.data) > 0 {
-		gutil.MapMerge(variables, view.data)
-	}
-	buffer := bytes.NewBuffer(nil)
+	// The cached template is shared by all content parsing, so it parses and executes
+	// the content on its clone for concurrent safety.
 	if view.config.AutoEncode {
-		newTpl, err := tpl.(*htmltpl.Template).Clone()
-		if err != nil {
-			return "", err
-		}
-		if err := newTpl.Execute(buffer, variables); err != nil {
-			return "", err
+		var newTpl *htmltpl.Template
+		if newTpl, err = tpl.(*htmltpl.Template).Clone(); err == nil {
+			return newTpl.Parse(content)
 		}
 	} else {
-		if err := tpl.(*texttpl.Template).Execute(buffer, variables); err != nil {
-			return "", err
+		var newTpl *texttpl.Template
+		if newTpl, err = tpl.(*texttpl.Template).Clone(); err == nil {
+			return newTpl.Parse(content)
 		}
 	}
-	// TODO any graceful plan to replace "<no value>"?
-	result := gstr.Replace(buffer.String(), "<no value>", "")
-	result = view.i18nTranslate(result, variables)
-	return result, nil
+	return nil, err
 }
 
 // getTemplate returns the template object associated with given template file <path>.
@@ -224,12 +345,12 @@ func (view *View) getTemplate(filePath, folderPath, pattern string) (tpl interfa
 			tpl = htmltpl.New(tplName).Delims(
 				view.config.Delimiters[0],
 				view.config.Delimiters[1],
-			).Funcs(view.funcMap)
+			).Funcs(view.getFuncMap())
 		} else {
 			tpl = texttpl.New(tplName).Delims(
 				view.config.Delimiters[0],
 				view.config.Delimiters[1],
-			).Funcs(view.funcMap)
+			).Funcs(view.getFuncMap())
 		}
 		// Firstly checking the resource manager.
 		if !gres.IsEmpty() {
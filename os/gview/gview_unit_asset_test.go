@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/os/gview"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gregex"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_AssetFingerprinting(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		root := gfile.TempDir(gtime.TimestampNanoStr())
+		t.Assert(gfile.PutContents(gfile.Join(root, "static", "app.js"), "console.log(1)"), nil)
+		defer gfile.Remove(root)
+
+		view := gview.New()
+		t.Assert(view.EnableAssetFingerprinting(root), nil)
+
+		result1, err := view.ParseContent(`{{asset "/static/app.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(gregex.IsMatchString(`^/static/app\.js\?v=[0-9a-f]{8}$`, result1), true)
+
+		// Cached.
+		result2, err := view.ParseContent(`{{asset "/static/app.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(result2, result1)
+
+		// Query string and fragment.
+		result3, err := view.ParseContent(`{{asset "/static/app.js?a=1#top"}}`)
+		t.Assert(err, nil)
+		t.Assert(result3, gstr.Replace(result1, "?v=", "?a=1&v=")+"#top")
+
+		// Not exist.
+		result4, err := view.ParseContent(`{{asset "/static/none.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(result4, "/static/none.js")
+
+		// File changes.
+		t.Assert(gfile.PutContents(gfile.Join(root, "static", "app.js"), "console.log(2)"), nil)
+		time.Sleep(500 * time.Millisecond)
+		result5, err := view.ParseContent(`{{asset "/static/app.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(gregex.IsMatchString(`^/static/app\.js\?v=[0-9a-f]{8}$`, result5), true)
+		t.AssertNE(result5, result1)
+	})
+	// Files out of the asset root directory are not fingerprinted.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir     = gfile.TempDir(gtime.TimestampNanoStr())
+			root    = gfile.Join(dir, "assets")
+			sibling = gfile.Join(dir, "assets-private")
+		)
+		t.Assert(gfile.PutContents(gfile.Join(root, "app.js"), "console.log(1)"), nil)
+		t.Assert(gfile.PutContents(gfile.Join(sibling, "secret.js"), "secret"), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dir, "outside.js"), "outside"), nil)
+		defer gfile.Remove(dir)
+
+		view := gview.New()
+		t.Assert(view.EnableAssetFingerprinting(root), nil)
+
+		result, err := view.ParseContent(`{{asset "/app.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(gregex.IsMatchString(`^/app\.js\?v=[0-9a-f]{8}$`, result), true)
+
+		result, err = view.ParseContent(`{{asset "/../assets-private/secret.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(result, "/../assets-private/secret.js")
+
+		result, err = view.ParseContent(`{{asset "/../outside.js"}}`)
+		t.Assert(err, nil)
+		t.Assert(result, "/../outside.js")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		t.AssertNE(view.EnableAssetFingerprinting("/not-exist-asset-root"), nil)
+	})
+}
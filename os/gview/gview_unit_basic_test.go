@@ -10,10 +10,13 @@ import (
 	"github.com/ichunt2019/gf/encoding/ghtml"
 	"github.com/ichunt2019/gf/os/gtime"
 	"github.com/ichunt2019/gf/util/gconv"
+	htmltpl "html/template"
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	texttpl "text/template"
 	"time"
 
 	"github.com/ichunt2019/gf/frame/g"
@@ -425,3 +428,172 @@ func Test_BuildInFuncJson(t *testing.T) {
 		t.Assert(r, `{"name":"john"}`)
 	})
 }
+
+func Test_RegisterFunc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		r := view.RegisterFunc("GetName", func() string { return "gf" }).
+			RegisterFuncMap(htmltpl.FuncMap{"GetVersion": func() string { return "1.0" }}).
+			RegisterFuncMap(texttpl.FuncMap{"GetAuthor": func() string { return "john" }})
+		t.Assert(r, view)
+		result, err := view.ParseContent(`{{GetName}} {{GetVersion}} {{GetAuthor}}`)
+		t.Assert(err, nil)
+		t.Assert(result, "gf 1.0 john")
+
+		// Overwriting.
+		view.RegisterFunc("GetName", func() string { return "goframe" })
+		result, err = view.ParseContent(`{{GetName}} {{GetVersion}} {{GetAuthor}}`)
+		t.Assert(err, nil)
+		t.Assert(result, "goframe 1.0 john")
+	})
+	// Cached template file invalidating.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Join(gfile.TempDir(), gtime.TimestampNanoStr())
+		defer gfile.Remove(path)
+		t.Assert(gfile.PutContents(gfile.Join(path, "index.html"), `{{double 2}}`), nil)
+
+		view := gview.New(path)
+		view.RegisterFunc("double", func(i int) int { return i * 2 })
+		result, err := view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "4")
+
+		view.RegisterFunc("double", func(i int) int { return i * 20 })
+		result, err = view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "40")
+	})
+	// Concurrent safety.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			view = gview.New()
+			wg   = sync.WaitGroup{}
+		)
+		view.RegisterFunc("value", func() int { return 0 })
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				view.RegisterFunc("value", func() int { return i }).RegisterFunc("value"+gconv.String(i), func() int { return i })
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, err := view.ParseContent(`{{value}}`)
+				t.Assert(err, nil)
+			}()
+		}
+		wg.Wait()
+		result, err := view.ParseContent(`{{value9}}`)
+		t.Assert(err, nil)
+		t.Assert(result, "9")
+	})
+	// Concurrent parsing and executing of the cached templates.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Join(gfile.TempDir(), gtime.TimestampNanoStr())
+		defer gfile.Remove(path)
+		t.Assert(gfile.PutContents(gfile.Join(path, "index.html"), `{{double .n}}`), nil)
+
+		var (
+			view = gview.New(path)
+			wg   = sync.WaitGroup{}
+		)
+		view.RegisterFunc("double", func(i int) int { return i * 2 })
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				result, err := view.Parse("index.html", g.Map{"n": i})
+				t.Assert(err, nil)
+				t.Assert(result, gconv.String(i*2))
+			}(i)
+			go func(i int) {
+				defer wg.Done()
+				result, err := view.ParseContent(`{{double ` + gconv.String(i) + `}}`)
+				t.Assert(err, nil)
+				t.Assert(result, gconv.String(i*2))
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func Test_BuildInFuncEncoding(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		v := gview.New()
+		v.Assign("html", "<b>gf</b>")
+		v.Assign("url", "a b&c")
+		v.Assign("user", g.Map{"name": "gf"})
+		r, err := v.ParseContent(`{{.html | htmlEncode}}|{{.html | htmlEncode | htmlDecode}}`)
+		t.Assert(err, nil)
+		t.Assert(r, `&lt;b&gt;gf&lt;/b&gt;|<b>gf</b>`)
+
+		r, err = v.ParseContent(`{{.url | urlEncode}}|{{.url | urlEncode | urlDecode}}|{{urlDecode "%zz"}}`)
+		t.Assert(err, nil)
+		t.Assert(gstr.HasPrefix(r, `a+b%26c|a b&c|`), true)
+		t.Assert(gstr.Contains(r, `invalid URL escape`), true)
+
+		r, err = v.ParseContent(`{{.user | jsonEncode}}`)
+		t.Assert(err, nil)
+		t.Assert(r, `{"name":"gf"}`)
+	})
+}
+
+// countWriter is a writer recording the count of writes.
+type countWriter struct {
+	builder strings.Builder
+	count   int
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.count++
+	return w.builder.Write(p)
+}
+
+func (w *countWriter) String() string {
+	return w.builder.String()
+}
+
+func Test_WriteTo(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.Join(
+			gfile.TempDir(),
+			"testdata",
+			"template-"+gconv.String(gtime.TimestampNano()),
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "index.html"), "{{range .list}}<p>{{.}}</p>\n{{end}}{{.none}}end"), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "split.tpl"), `a{{"<no"}}{{" value>"}}b{{"<no"}}`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "error.tpl"), `a{{.name.Fail}}`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "empty.tpl"), ``), nil)
+
+		view := gview.New(dirPath)
+		view.Assign("name", "gf")
+
+		writer := &countWriter{}
+		err := view.WriteTo(writer, "index.html", g.Map{"list": g.Slice{1, 2, 3}})
+		t.Assert(err, nil)
+		t.Assert(writer.String(), "<p>1</p>\n<p>2</p>\n<p>3</p>\nend")
+		t.Assert(writer.count > 1, true)
+
+		result, err := view.Parse("index.html", g.Map{"list": g.Slice{1, 2, 3}})
+		t.Assert(err, nil)
+		t.Assert(result, writer.String())
+
+		view.SetAutoEncode(false)
+		writer = &countWriter{}
+		err = view.WriteTo(writer, "split.tpl", nil)
+		t.Assert(err, nil)
+		t.Assert(writer.String(), `ab<no`)
+
+		writer = &countWriter{}
+		err = view.WriteTo(writer, "error.tpl", nil)
+		t.AssertNE(err, nil)
+		t.Assert(writer.String(), `a`)
+
+		writer = &countWriter{}
+		t.Assert(view.WriteTo(writer, "empty.tpl", nil), nil)
+		t.Assert(writer.count, 0)
+
+		t.AssertNE(view.WriteTo(writer, "none.tpl", nil), nil)
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/os/gview"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+func Test_RenderBlock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		content := `{{define "header"}}<h1>{{.title}}</h1>{{end}}` +
+			`<html>{{template "header" .}}<ul>{{block "list" .}}{{range .items}}<li>{{.}}</li>{{end}}{{end}}</ul>{{.none}}</html>`
+		params := g.Map{
+			"title": "<gf>",
+			"items": g.Slice{1, 2},
+		}
+		for _, autoEncode := range []bool{false, true} {
+			// The template objects are cached by file path, which does not support
+			// switching AutoEncode for the same file, so it uses different folders.
+			dirPath := gfile.Join(
+				gfile.TempDir(),
+				"testdata",
+				"template-"+gconv.String(gtime.TimestampNano()),
+			)
+			defer gfile.Remove(dirPath)
+			t.Assert(gfile.PutContents(gfile.Join(dirPath, "index.html"), content), nil)
+			t.Assert(gfile.PutContents(gfile.Join(dirPath, "empty.html"), ""), nil)
+
+			view := gview.New(dirPath)
+			view.SetAutoEncode(autoEncode)
+
+			result, err := view.RenderBlock("index.html", "header", params)
+			t.Assert(err, nil)
+			if autoEncode {
+				t.Assert(result, `<h1>&lt;gf&gt;</h1>`)
+			} else {
+				t.Assert(result, `<h1><gf></h1>`)
+			}
+
+			result, err = view.RenderBlock("index.html", "list", params)
+			t.Assert(err, nil)
+			t.Assert(result, `<li>1</li><li>2</li>`)
+
+			// The whole file can still be parsed after rendering blocks.
+			result, err = view.Parse("index.html", params)
+			t.Assert(err, nil)
+			t.Assert(gstr.Contains(result, `<ul><li>1</li><li>2</li></ul></html>`), true)
+
+			_, err = view.RenderBlock("index.html", "footer", params)
+			t.AssertNE(err, nil)
+			_, err = view.RenderBlock("empty.html", "header", params)
+			t.AssertNE(err, nil)
+			_, err = view.RenderBlock("none.html", "header", params)
+			t.AssertNE(err, nil)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/os/gview"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+func Test_CacheStats(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.Join(
+			gfile.TempDir(),
+			"testdata",
+			"template-"+gconv.String(gtime.TimestampNano()),
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "a.html"), "a:{{.var}}"), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "b.html"), "b:{{.var}}"), nil)
+
+		view := gview.New(dirPath)
+		t.Assert(len(view.CacheStats()), 0)
+
+		for i := 0; i < 3; i++ {
+			result, err := view.Parse("a.html", g.Map{"var": i})
+			t.Assert(err, nil)
+			t.Assert(result, "a:"+gconv.String(i))
+		}
+		result, err := view.Parse("b.html", g.Map{"var": 1})
+		t.Assert(err, nil)
+		t.Assert(result, "b:1")
+
+		stats := view.CacheStats()
+		t.Assert(len(stats), 2)
+		t.Assert(stats["a.html"].Path, gfile.Join(dirPath, "a.html"))
+		t.Assert(stats["a.html"].Hits, 2)
+		t.Assert(stats["a.html"].LoadTime.IsZero(), false)
+		t.Assert(stats["b.html"].Hits, 0)
+	})
+}
+
+func Test_InvalidateCache(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.Join(
+			gfile.TempDir(),
+			"testdata",
+			"template-"+gconv.String(gtime.TimestampNano()),
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "a.html"), "a:{{.var}}"), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "b.html"), "b:{{.var}}"), nil)
+
+		view := gview.New(dirPath)
+		_, err := view.Parse("a.html", g.Map{"var": 1})
+		t.Assert(err, nil)
+		_, err = view.Parse("b.html", g.Map{"var": 1})
+		t.Assert(err, nil)
+
+		// Invalidate single file.
+		view.InvalidateCache("a.html")
+		view.InvalidateCache("none.html")
+		stats := view.CacheStats()
+		t.Assert(len(stats), 1)
+		t.Assert(stats["b.html"].Path, gfile.Join(dirPath, "b.html"))
+
+		// Reloading from disk.
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "a.html"), "a2:{{.var}}"), nil)
+		time.Sleep(100 * time.Millisecond)
+		view.InvalidateCache("a.html")
+		result, err := view.Parse("a.html", g.Map{"var": 2})
+		t.Assert(err, nil)
+		t.Assert(result, "a2:2")
+		t.Assert(view.CacheStats()["a.html"].Hits, 0)
+
+		// Invalidate all.
+		view.InvalidateAllCache()
+		t.Assert(len(view.CacheStats()), 0)
+		result, err = view.Parse("b.html", g.Map{"var": 3})
+		t.Assert(err, nil)
+		t.Assert(result, "b:3")
+	})
+}
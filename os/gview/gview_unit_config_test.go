@@ -7,10 +7,16 @@
 package gview_test
 
 import (
+	"fmt"
 	"github.com/ichunt2019/gf/debug/gdebug"
 	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gcfg"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
 	"github.com/ichunt2019/gf/os/gview"
 	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+	"sync"
 	"testing"
 )
 
@@ -64,3 +70,121 @@ func Test_ConfigWithMap(t *testing.T) {
 		t.Assert(result, "name:gf")
 	})
 }
+
+func Test_BindConfig(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath = gfile.TempDir(gtime.TimestampNanoStr())
+			tplPath = gfile.Join(dirPath, "template")
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(tplPath, "index.html"), `${.name}:{#hello}`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.json"), fmt.Sprintf(`{
+			"viewer": {
+				"path":       "%s",
+				"delimiters": ["${", "}"],
+				"data":       {"name": "gf"},
+				"i18n":       {"path": "%s", "language": "zh-CN"}
+			}
+		}`, gstr.Replace(tplPath, `\`, `\\`), gstr.Replace(gdebug.TestDataPath("i18n"), `\`, `\\`))), nil)
+
+		cfg := gcfg.New("config.json")
+		t.Assert(cfg.SetPath(dirPath), nil)
+		defer cfg.StopWatchAll()
+
+		view := gview.New()
+		t.Assert(view.BindConfig(cfg, "viewer"), nil)
+		result, err := view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "gf:你好")
+
+		// Re-applies on configuration changes.
+		t.Assert(gfile.PutContents(gfile.Join(tplPath, "index.tpl"), `{{.name}}:{#hello}`), nil)
+		t.Assert(cfg.Set("viewer.delimiters", g.Slice{"{{", "}}"}), nil)
+		t.Assert(cfg.Set("viewer.data.name", "john"), nil)
+		t.Assert(cfg.Set("viewer.i18n.language", "en"), nil)
+		result, err = view.Parse("index.tpl")
+		t.Assert(err, nil)
+		t.Assert(result, "john:Hello")
+
+		t.AssertNE(view.BindConfig(cfg, "none"), nil)
+	})
+	// Rebinding replaces the previous binding.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath = gfile.TempDir(gtime.TimestampNanoStr())
+			tplPath = gfile.Join(dirPath, "template")
+			content = `{"viewer": {"path": "%s", "data": {"name": "%s"}}}`
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(tplPath, "index.html"), `{{.name}}`), nil)
+		tplPath = gstr.Replace(tplPath, `\`, `\\`)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config1.json"), fmt.Sprintf(content, tplPath, "cfg1")), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config2.json"), fmt.Sprintf(content, tplPath, "cfg2")), nil)
+
+		cfg1 := gcfg.New("config1.json")
+		t.Assert(cfg1.SetPath(dirPath), nil)
+		defer cfg1.StopWatchAll()
+		cfg2 := gcfg.New("config2.json")
+		t.Assert(cfg2.SetPath(dirPath), nil)
+		defer cfg2.StopWatchAll()
+
+		view := gview.New()
+		t.Assert(view.BindConfig(cfg1, "viewer"), nil)
+		t.Assert(view.BindConfig(cfg2, "viewer"), nil)
+		result, err := view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "cfg2")
+
+		// The changes of the previously bound configuration are not applied.
+		t.Assert(cfg1.Set("viewer.data.name", "john"), nil)
+		result, err = view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "cfg2")
+
+		t.Assert(cfg2.Set("viewer.data.name", "smith"), nil)
+		result, err = view.Parse("index.html")
+		t.Assert(err, nil)
+		t.Assert(result, "smith")
+	})
+	// Concurrent safety between applying and parsing.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath = gfile.TempDir(gtime.TimestampNanoStr())
+			tplPath = gfile.Join(dirPath, "template")
+			wg      = sync.WaitGroup{}
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(tplPath, "index.html"), `{{.name}}`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.json"), fmt.Sprintf(
+			`{"viewer": {"path": "%s", "data": {"name": "gf"}}}`, gstr.Replace(tplPath, `\`, `\\`),
+		)), nil)
+
+		cfg := gcfg.New("config.json")
+		t.Assert(cfg.SetPath(dirPath), nil)
+		defer cfg.StopWatchAll()
+
+		view := gview.New()
+		t.Assert(view.BindConfig(cfg, "viewer"), nil)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				t.Assert(cfg.Set("viewer.data.name", "gf"), nil)
+			}
+		}()
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := view.Parse("index.html")
+				t.Assert(err, nil)
+				t.Assert(result, "gf")
+				result, err = view.ParseContent(`{{.name}}`)
+				t.Assert(err, nil)
+				t.Assert(result, "gf")
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gview"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_DebugMode(t *testing.T) {
+	content := "line1\nline2\n{{.name.Value}}\nline4"
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		result, err := view.ParseContent(content, g.Map{"name": 1})
+		t.AssertNE(err, nil)
+		t.Assert(result, "")
+		t.Assert(gstr.Contains(err.Error(), "at line"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		view.SetDebugMode(true)
+		result, err := view.ParseContent(content, g.Map{"name": 1})
+		t.AssertNE(err, nil)
+		t.Assert(result, "")
+		t.Assert(gstr.Contains(err.Error(), "at line 3: {{.name.Value}}"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		view.SetDebugMode(true)
+		view.SetAutoEncode(true)
+		result, err := view.ParseContent(content, g.Map{"name": 1})
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), "at line 3"), true)
+		t.Assert(gstr.Contains(result, `<div class="gview-debug">`), true)
+		t.Assert(gstr.Contains(result, "<mark>   3 | {{.name.Value}}</mark>"), true)
+		t.Assert(gstr.Contains(result, "   2 | line2"), true)
+	})
+}
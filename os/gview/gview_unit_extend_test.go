@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/os/gfile"
+	"github.com/ichunt2019/gf/os/gtime"
+	"github.com/ichunt2019/gf/os/gview"
+	"github.com/ichunt2019/gf/test/gtest"
+)
+
+func Test_Extend(t *testing.T) {
+	path := gfile.Join(gfile.TempDir(), "gview-extend", gtime.TimestampNanoStr())
+	files := g.MapStrStr{
+		"base.html":   `<title>{{block "title" .}}Default{{end}}</title><body>{{block "body" .}}<main>{{block "content" .}}empty{{end}}</main>{{end}}</body>`,
+		"layout.html": `{{extend "base.html"}}{{block "body" .}}<nav>nav</nav><main>{{block "content" .}}layout{{end}}</main>{{end}}`,
+		"page.html":   "{{extend \"layout.html\"}}\nignored\n{{define \"title\"}}Page {{.name}}{{end}}\n{{define \"content\"}}{{if .name}}hello {{.name}}{{end}}{{end}}",
+		"other.html":  `{{- extend "base.html" -}}{{block "content" .}}other{{end}}`,
+		"plain.html":  `no extend {{.name}}`,
+		"cycle1.html": `{{extend "cycle2.html"}}{{block "title" .}}1{{end}}`,
+		"cycle2.html": `{{extend "cycle1.html"}}{{block "title" .}}2{{end}}`,
+		"none.html":   `{{extend "none-parent.html"}}`,
+	}
+	for name, content := range files {
+		if err := gfile.PutContents(gfile.Join(path, name), content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer gfile.Remove(path)
+
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New(path)
+		result, err := view.Parse("page.html", g.Map{"name": "john"})
+		t.Assert(err, nil)
+		t.Assert(result, `<title>Page john</title><body><nav>nav</nav><main>hello john</main></body>`)
+
+		// The blocks of different templates do not affect each other.
+		result, err = view.Parse("other.html")
+		t.Assert(err, nil)
+		t.Assert(result, `<title>Default</title><body><main>other</main></body>`)
+
+		result, err = view.Parse("layout.html")
+		t.Assert(err, nil)
+		t.Assert(result, `<title>Default</title><body><nav>nav</nav><main>layout</main></body>`)
+
+		result, err = view.Parse("page.html", g.Map{"name": "smith"})
+		t.Assert(err, nil)
+		t.Assert(result, `<title>Page smith</title><body><nav>nav</nav><main>hello smith</main></body>`)
+
+		result, err = view.Parse("plain.html", g.Map{"name": "john"})
+		t.Assert(err, nil)
+		t.Assert(result, `no extend john`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New(path)
+		view.SetAutoEncode(true)
+		result, err := view.Parse("page.html", g.Map{"name": "<b>"})
+		t.Assert(err, nil)
+		t.Assert(result, `<title>Page &lt;b&gt;</title><body><nav>nav</nav><main>hello &lt;b&gt;</main></body>`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New(path)
+		_, err := view.Parse("cycle1.html")
+		t.AssertNE(err, nil)
+		_, err = view.Parse("none.html")
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gview
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+// streamWriter is a writer wrapping the underlying writer for streaming template output,
+// which removes "<no value>" and translates i18n variables of the written content
+// like Parse does.
+//
+// As neither "<no value>" nor i18n variables span multiple lines, it writes the content
+// to the underlying writer line by line, holding back the last incomplete line until
+// following writes or Flush.
+type streamWriter struct {
+	view      *View
+	writer    io.Writer
+	variables Params
+	pending   []byte
+}
+
+// newStreamWriter creates and returns a streamWriter writing to <w>.
+func (view *View) newStreamWriter(w io.Writer, variables Params) *streamWriter {
+	return &streamWriter{
+		view:      view,
+		writer:    w,
+		variables: variables,
+	}
+}
+
+// Write implements the interface of io.Writer.
+func (w *streamWriter) Write(p []byte) (n int, err error) {
+	w.pending = append(w.pending, p...)
+	pos := bytes.LastIndexByte(w.pending, '\n')
+	if pos == -1 {
+		return len(p), nil
+	}
+	if err = w.write(w.pending[:pos+1]); err != nil {
+		return 0, err
+	}
+	w.pending = append(w.pending[:0], w.pending[pos+1:]...)
+	return len(p), nil
+}
+
+// Flush writes the held back content to the underlying writer.
+func (w *streamWriter) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	err := w.write(w.pending)
+	w.pending = w.pending[:0]
+	return err
+}
+
+// write filters and writes complete content <data> to the underlying writer.
+func (w *streamWriter) write(data []byte) error {
+	content := gstr.Replace(string(data), "<no value>", "")
+	content = w.view.i18nTranslate(content, w.variables)
+	if content == "" {
+		return nil
+	}
+	_, err := io.WriteString(w.writer, content)
+	return err
+}
@@ -110,6 +110,39 @@ func ReplaceIByMap(origin string, replaces map[string]string) string {
 	return origin
 }
 
+// Template returns a copy of <tpl>, in which the placeholders "{{key}}" are replaced by
+// the values of <vars>, eg: Template("Hello {{name}}!", map[string]string{"name": "Alice"}).
+// The spaces around the key are ignored, and the placeholders of unknown keys are left as they are.
+// It is lighter than text/template for simple substitution, and the replaced values are not
+// parsed as placeholders again.
+func Template(tpl string, vars map[string]string) string {
+	var (
+		buffer = bytes.NewBuffer(make([]byte, 0, len(tpl)))
+		offset = 0
+	)
+	for {
+		start := strings.Index(tpl[offset:], "{{")
+		if start < 0 {
+			break
+		}
+		start += offset
+		end := strings.Index(tpl[start+2:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+		buffer.WriteString(tpl[offset:start])
+		if v, ok := vars[strings.TrimSpace(tpl[start+2:end])]; ok {
+			buffer.WriteString(v)
+		} else {
+			buffer.WriteString(tpl[start : end+2])
+		}
+		offset = end + 2
+	}
+	buffer.WriteString(tpl[offset:])
+	return buffer.String()
+}
+
 // ToLower returns a copy of the string s with all Unicode letters mapped to their lower case.
 func ToLower(s string) string {
 	return strings.ToLower(s)
@@ -235,6 +268,27 @@ func StrLimitRune(str string, length int, suffix ...string) string {
 	return string(rs[0:length]) + addStr
 }
 
+// Truncate returns at most <maxRunes> runes of <str>, which appends <suffix> if <str> is truncated.
+// Unlike StrLimitRune, the length of the result including <suffix> never exceeds <maxRunes>,
+// and the <suffix> is dropped if it is not shorter than <maxRunes>.
+// Truncate considers parameter <str> as unicode string, which never breaks a multi-byte character.
+func Truncate(str string, maxRunes int, suffix string) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(str) <= maxRunes {
+		return str
+	}
+	var (
+		rs     = []rune(str)
+		length = maxRunes - utf8.RuneCountInString(suffix)
+	)
+	if length <= 0 {
+		return string(rs[:maxRunes])
+	}
+	return string(rs[:length]) + suffix
+}
+
 // Reverse returns a string which is the reverse of <str>.
 func Reverse(str string) string {
 	runes := []rune(str)
@@ -382,7 +436,7 @@ func CountChars(str string, noSpace ...bool) map[string]int {
 }
 
 // WordWrap wraps a string to a given number of characters.
-// TODO: Enable cut parameter, see http://php.net/manual/en/function.wordwrap.php.
+// See WordWrapCut for the unicode-aware wrapping which can break long words.
 func WordWrap(str string, width int, br string) string {
 	if br == "" {
 		br = "\n"
@@ -442,6 +496,52 @@ func WordWrap(str string, width int, br string) string {
 	return buf.String()
 }
 
+// WordWrapCut wraps <str> at word boundaries to lines of at most <width> runes, which counts
+// the width in runes instead of bytes. The words longer than <width> are hard-broken if <breakLong>
+// is true, or else they are kept in their own lines.
+//
+// The newlines "\r\n", "\n" and "\r" of <str> are all kept as "\n", and the consecutive spaces
+// between words are collapsed to single space. It returns <str> unchanged if <width> <= 0.
+func WordWrapCut(str string, width int, breakLong bool) string {
+	if width <= 0 {
+		return str
+	}
+	var (
+		lines  = strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(str), "\n")
+		buffer = bytes.NewBuffer(make([]byte, 0, len(str)))
+	)
+	for i, line := range lines {
+		if i > 0 {
+			buffer.WriteByte('\n')
+		}
+		current := 0
+		for _, word := range strings.Fields(line) {
+			length := utf8.RuneCountInString(word)
+			if current > 0 {
+				if current+1+length <= width {
+					buffer.WriteByte(' ')
+					buffer.WriteString(word)
+					current += 1 + length
+					continue
+				}
+				buffer.WriteByte('\n')
+				current = 0
+			}
+			if breakLong {
+				for runes := []rune(word); len(runes) > width; runes = runes[width:] {
+					buffer.WriteString(string(runes[:width]))
+					buffer.WriteByte('\n')
+					word = string(runes[width:])
+				}
+				length = utf8.RuneCountInString(word)
+			}
+			buffer.WriteString(word)
+			current = length
+		}
+	}
+	return buffer.String()
+}
+
 // RuneLen returns string length of unicode.
 // Deprecated, use LenRune instead.
 func RuneLen(str string) int {
@@ -458,6 +558,36 @@ func Repeat(input string, multiplier int) string {
 	return strings.Repeat(input, multiplier)
 }
 
+// PadLeft pads <str> on the left with <pad> to <width> runes, eg: PadLeft("hi", 5, "·→") is "·→·hi".
+// The <pad> can be multiple runes, which is repeated and trimmed as necessary.
+// It returns <str> unchanged if <pad> is empty or <str> is not shorter than <width> in runes.
+func PadLeft(str string, width int, pad string) string {
+	return padding(str, width, pad) + str
+}
+
+// PadRight pads <str> on the right with <pad> to <width> runes, eg: PadRight("hi", 5, "·→") is "hi·→·".
+// The <pad> can be multiple runes, which is repeated and trimmed as necessary.
+// It returns <str> unchanged if <pad> is empty or <str> is not shorter than <width> in runes.
+func PadRight(str string, width int, pad string) string {
+	return str + padding(str, width, pad)
+}
+
+// padding returns the padding of <str> to <width> runes, which is <pad> repeated and trimmed.
+func padding(str string, width int, pad string) string {
+	var (
+		padRunes = []rune(pad)
+		length   = width - utf8.RuneCountInString(str)
+	)
+	if len(padRunes) == 0 || length <= 0 {
+		return ""
+	}
+	runes := make([]rune, length)
+	for i := range runes {
+		runes[i] = padRunes[i%len(padRunes)]
+	}
+	return string(runes)
+}
+
 // Str returns part of <haystack> string starting from and including
 // the first occurrence of <needle> to the end of <haystack>.
 // See http://php.net/manual/en/function.strstr.php.
@@ -492,8 +622,16 @@ func Split(str, delimiter string) []string {
 // and calls Trim to every element of this array. It ignores the elements
 // which are empty after Trim.
 func SplitAndTrim(str, delimiter string, characterMask ...string) []string {
+	return SplitAndTrimN(str, delimiter, -1, characterMask...)
+}
+
+// SplitAndTrimN splits string <str> by a string <delimiter> to at most <n> elements like strings.SplitN,
+// and calls Trim to every element of this array. It ignores the elements which are empty after Trim,
+// eg: SplitAndTrimN(" a , , b , c ", ",", 3) returns ["a", "b , c"].
+// It splits all elements if <n> < 0, and returns an empty array if <n> == 0.
+func SplitAndTrimN(str, delimiter string, n int, characterMask ...string) []string {
 	array := make([]string, 0)
-	for _, v := range strings.Split(str, delimiter) {
+	for _, v := range strings.SplitN(str, delimiter, n) {
 		v = Trim(v, characterMask...)
 		if v != "" {
 			array = append(array, v)
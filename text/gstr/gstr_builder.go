@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StringBuilder is a fluent string builder wrapping strings.Builder, whose writing methods
+// return the builder itself for chaining, eg:
+//
+//	s := NewStringBuilder().Write("SELECT * FROM user").WriteIf(id > 0, " WHERE id=?").String()
+//
+// The zero value of StringBuilder is ready to use. Note that it is not concurrent-safe.
+type StringBuilder struct {
+	builder strings.Builder
+}
+
+// NewStringBuilder creates and returns a new StringBuilder.
+func NewStringBuilder() *StringBuilder {
+	return &StringBuilder{}
+}
+
+// Write appends <s> to the builder.
+func (b *StringBuilder) Write(s string) *StringBuilder {
+	b.builder.WriteString(s)
+	return b
+}
+
+// Writef appends the string formatted by <format> and <args> to the builder, like fmt.Sprintf.
+func (b *StringBuilder) Writef(format string, args ...interface{}) *StringBuilder {
+	fmt.Fprintf(&b.builder, format, args...)
+	return b
+}
+
+// WriteIf appends <s> to the builder only if <cond> is true.
+func (b *StringBuilder) WriteIf(cond bool, s string) *StringBuilder {
+	if cond {
+		b.builder.WriteString(s)
+	}
+	return b
+}
+
+// Join appends the elements of <parts> to the builder, with <sep> placed between elements.
+func (b *StringBuilder) Join(parts []string, sep string) *StringBuilder {
+	for i, part := range parts {
+		if i > 0 {
+			b.builder.WriteString(sep)
+		}
+		b.builder.WriteString(part)
+	}
+	return b
+}
+
+// Len returns the count of bytes written to the builder.
+func (b *StringBuilder) Len() int {
+	return b.builder.Len()
+}
+
+// Reset resets the builder to be empty.
+func (b *StringBuilder) Reset() *StringBuilder {
+	b.builder.Reset()
+	return b
+}
+
+// String returns the built string.
+func (b *StringBuilder) String() string {
+	return b.builder.String()
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+var (
+	// extractEmailRegex matches the email addresses.
+	extractEmailRegex = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9\-]+(?:\.[a-zA-Z0-9\-]+)*\.[a-zA-Z]{2,}`)
+	// extractUrlRegex matches the URLs starting with "http://", "https://" or "www.".
+	extractUrlRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'` + "`" + `]+`)
+	// extractIpRegex matches the IPv4 and IPv6 address candidates, which are validated by net.ParseIP.
+	// The IPv6 address candidate can have an embedded IPv4 tail, eg: "::ffff:1.2.3.4".
+	extractIpRegex = regexp.MustCompile(
+		`\b\d{1,3}(?:\.\d{1,3}){3}\b|(?:[0-9a-fA-F]{0,4}:){2,7}(?:\d{1,3}(?:\.\d{1,3}){3}|[0-9a-fA-F]{0,4})`,
+	)
+)
+
+const (
+	// urlTrailingPunctuations are the punctuations trimmed from the end of the extracted URLs,
+	// which are commonly the sentence punctuations rather than the part of URLs.
+	urlTrailingPunctuations = `.,;:!?)]}`
+)
+
+// ExtractEmails returns all the email addresses in <s> in order of appearance.
+func ExtractEmails(s string) []string {
+	return extractAll(extractEmailRegex, s, nil)
+}
+
+// ExtractURLs returns all the URLs starting with "http://", "https://" or "www." in <s>
+// in order of appearance. The trailing punctuations of the URLs are trimmed, eg: the full stop
+// of the sentence "See https://goframe.org.".
+func ExtractURLs(s string) []string {
+	return extractAll(extractUrlRegex, s, func(match string) string {
+		return strings.TrimRight(match, urlTrailingPunctuations)
+	})
+}
+
+// ExtractIPs returns all the valid IPv4 and IPv6 addresses in <s> in order of appearance.
+// The address should not be adjacent to alphanumeric characters or colons, so the text like
+// "std::vector" is not considered as an IPv6 address.
+func ExtractIPs(s string) []string {
+	array := make([]string, 0)
+	for _, index := range extractIpRegex.FindAllStringIndex(s, -1) {
+		if (index[0] > 0 && isIpAdjacentChar(s[index[0]-1])) ||
+			(index[1] < len(s) && isIpAdjacentChar(s[index[1]])) {
+			continue
+		}
+		if match := s[index[0]:index[1]]; net.ParseIP(match) != nil {
+			array = append(array, match)
+		}
+	}
+	return array
+}
+
+// isIpAdjacentChar checks and returns whether <c> cannot be adjacent to an IP address,
+// that is, <c> is an alphanumeric character or colon.
+func isIpAdjacentChar(c byte) bool {
+	return c == ':' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// extractAll returns all the non-overlapping matches of <regex> in <s>, which are handled by
+// <handler> if it is given. The match is ignored if <handler> returns an empty string.
+func extractAll(regex *regexp.Regexp, s string, handler func(match string) string) []string {
+	array := make([]string, 0)
+	for _, match := range regex.FindAllString(s, -1) {
+		if handler != nil {
+			match = handler(match)
+		}
+		if match != "" {
+			array = append(array, match)
+		}
+	}
+	return array
+}
@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsASCII checks whether all characters of <s> are ASCII characters.
+// It returns false if <s> is empty.
+func IsASCII(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlpha checks whether all characters of <s> are unicode letters.
+// It returns false if <s> is empty.
+func IsAlpha(s string) bool {
+	return isAll(s, unicode.IsLetter)
+}
+
+// IsAlphanumeric checks whether all characters of <s> are unicode letters or digits.
+// It returns false if <s> is empty.
+func IsAlphanumeric(s string) bool {
+	return isAll(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	})
+}
+
+// IsLowerCase checks whether all letters of <s> are in lower case, eg: "user_1" is lower case.
+// The characters other than letters are ignored, and it returns false if <s> contains no letters.
+func IsLowerCase(s string) bool {
+	return isCase(s, unicode.IsLower, unicode.IsUpper)
+}
+
+// IsUpperCase checks whether all letters of <s> are in upper case, eg: "USER_1" is upper case.
+// The characters other than letters are ignored, and it returns false if <s> contains no letters.
+func IsUpperCase(s string) bool {
+	return isCase(s, unicode.IsUpper, unicode.IsLower)
+}
+
+// isAll checks whether all characters of <s> satisfy <f>, which returns false if <s> is empty.
+func isAll(s string, f func(r rune) bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !f(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCase checks whether <s> contains characters satisfying <is> but none satisfying <not>.
+func isCase(s string, is, not func(r rune) bool) bool {
+	found := false
+	for _, r := range s {
+		if not(r) {
+			return false
+		}
+		if is(r) {
+			found = true
+		}
+	}
+	return found
+}
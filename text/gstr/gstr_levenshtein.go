@@ -59,3 +59,66 @@ func Levenshtein(str1, str2 string, costIns, costRep, costDel int) int {
 
 	return c0
 }
+
+// LevenshteinDistance calculates the Levenshtein distance between <s> and <t>, which is the minimum
+// count of single character insertions, deletions and substitutions changing <s> into <t>.
+// Unlike Levenshtein, it considers <s> and <t> as unicode strings and has no length limit.
+func LevenshteinDistance(s, t string) int {
+	return editDistance([]rune(s), []rune(t), false)
+}
+
+// DamerauLevenshtein calculates the Damerau-Levenshtein distance between <s> and <t>, which
+// additionally allows transpositions of two adjacent characters than LevenshteinDistance,
+// eg: the distance between "ab" and "ba" is 1.
+// It is the optimal string alignment distance, in which no substring is edited more than once.
+func DamerauLevenshtein(s, t string) int {
+	return editDistance([]rune(s), []rune(t), true)
+}
+
+// editDistance calculates the edit distance between <s> and <t> using dynamic programming with
+// rows of length min(len(s), len(t))+1. The transpositions are allowed if <transposition> is true.
+func editDistance(s, t []rune, transposition bool) int {
+	if len(s) < len(t) {
+		s, t = t, s
+	}
+	if len(t) == 0 {
+		return len(s)
+	}
+	var (
+		prev2 []int // Row of i-2, only used for transposition.
+		prev  = make([]int, len(t)+1)
+		curr  = make([]int, len(t)+1)
+	)
+	if transposition {
+		prev2 = make([]int, len(t)+1)
+	}
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			if transposition && i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+		}
+		if transposition {
+			prev2, prev, curr = prev, curr, prev2
+		} else {
+			prev, curr = curr, prev
+		}
+	}
+	return prev[len(t)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
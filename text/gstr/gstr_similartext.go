@@ -43,6 +43,9 @@ func SimilarText(first, second string, percent *float64) int {
 
 	l1, l2 := len(first), len(second)
 	if l1+l2 == 0 {
+		if percent != nil {
+			*percent = 0
+		}
 		return 0
 	}
 	sim := similarText(first, second, l1, l2)
@@ -51,3 +54,10 @@ func SimilarText(first, second string, percent *float64) int {
 	}
 	return sim
 }
+
+// SimilarTextPercent calculates the similarity between two strings like SimilarText, which returns
+// both the count of matching characters <count> and the similarity <percent> in range [0, 100].
+func SimilarTextPercent(first, second string) (count int, percent float64) {
+	count = SimilarText(first, second, &percent)
+	return
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// SlugMaxRetries is the max retrying count of UniqueSlug for unique slug generating,
+	// which prevents infinite loops.
+	SlugMaxRetries = 1000
+
+	// slugTransliterations is the transliteration table of common European characters in lower case.
+	slugTransliterations = map[rune]string{
+		'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+		'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ĉ': "c", 'ċ': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+		'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+		'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g", 'ĥ': "h", 'ħ': "h",
+		'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+		'ĳ': "ij", 'ĵ': "j", 'ķ': "k", 'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+		'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n", 'ŋ': "n",
+		'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+		'œ': "oe", 'ŕ': "r", 'ŗ': "r", 'ř': "r", 'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+		'ß': "ss", 'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t", 'þ': "th",
+		'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+		'ŵ': "w", 'ý': "y", 'ÿ': "y", 'ŷ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+	}
+)
+
+// Slugify converts <s> to a URL slug, which is in lower case with the common European characters
+// transliterated to ASCII, and any character other than letters and digits collapsed to single hyphen,
+// eg: "Crème Brûlée, s'il vous plaît!" to "creme-brulee-s-il-vous-plait".
+// The leading and trailing hyphens are stripped, and the letters without transliteration, eg: Chinese,
+// are kept as they are. It is idempotent, that is, the slug is unchanged by Slugify.
+func Slugify(s string) string {
+	var (
+		builder = strings.Builder{}
+		hyphen  = false
+	)
+	for _, r := range strings.ToLower(s) {
+		t, ok := slugTransliterations[r]
+		if !ok && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			hyphen = true
+			continue
+		}
+		if hyphen && builder.Len() > 0 {
+			builder.WriteByte('-')
+		}
+		hyphen = false
+		if ok {
+			builder.WriteString(t)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// UniqueSlug generates a slug from <s> using Slugify, and appends "-2", "-3", etc. to the slug
+// until <exists> returns false for the slug. The callback function <exists> performs
+// whatever uniqueness check the caller needs, like database query or map lookup.
+//
+// It returns an empty string if no unique slug is found within SlugMaxRetries attempts.
+func UniqueSlug(s string, exists func(slug string) bool) string {
+	slug := Slugify(s)
+	if !exists(slug) {
+		return slug
+	}
+	for i := 2; i <= SlugMaxRetries; i++ {
+		candidate := slug + "-" + strconv.Itoa(i)
+		if !exists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
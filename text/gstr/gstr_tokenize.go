@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// TokenRule is the rule for Tokenize, which matches the token of <Type> using regular expression <Pattern>.
+type TokenRule struct {
+	Pattern string // Regular expression pattern for the token, which is matched at the current position.
+	Type    string // Type of the token, eg: "number", "ident", "space".
+}
+
+// Token is the token produced by Tokenize.
+type Token struct {
+	Type  string // Type of the token, which is the Type of the matched rule.
+	Value string // Matched string of the token.
+	Pos   int    // Byte offset of the token in the tokenized string.
+}
+
+// Tokenize splits <s> into tokens using <rules>, which is useful for parsing DSLs,
+// config expressions and log formats.
+//
+// The rules are tried in order at the current position, and the first matching pattern wins,
+// so the more specific patterns should be placed before the general ones, eg: keywords before identifiers.
+// It returns error if any pattern is invalid or matches empty string,
+// or there's no rule matching at some position of <s>.
+//
+// Example:
+// Tokenize("a = 1", []TokenRule{{`\d+`, "number"}, {`\w+`, "ident"}, {`=`, "assign"}, {`\s+`, "space"}})
+// -> [{ident a 0} {space " " 1} {assign = 2} {space " " 3} {number 1 4}]
+func Tokenize(s string, rules []TokenRule) ([]Token, error) {
+	if len(rules) == 0 {
+		return nil, fmt.Errorf(`tokenize rules cannot be empty`)
+	}
+	// Compile all rules, which are anchored at the current position.
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		re, err := regexp.Compile(`^(?:` + rule.Pattern + `)`)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid pattern "%s" of token type "%s": %v`, rule.Pattern, rule.Type, err)
+		}
+		if re.MatchString("") {
+			return nil, fmt.Errorf(`pattern "%s" of token type "%s" matches empty string`, rule.Pattern, rule.Type)
+		}
+		patterns[i] = re
+	}
+	var (
+		pos    = 0
+		tokens = make([]Token, 0)
+	)
+	for pos < len(s) {
+		matched := false
+		for i, re := range patterns {
+			loc := re.FindStringIndex(s[pos:])
+			if loc == nil {
+				continue
+			}
+			if loc[1] == 0 {
+				// Some patterns only match empty string at specific positions, eg: `\b`.
+				return nil, fmt.Errorf(
+					`pattern "%s" of token type "%s" matches empty string at position %d`,
+					rules[i].Pattern, rules[i].Type, pos,
+				)
+			}
+			tokens = append(tokens, Token{
+				Type:  rules[i].Type,
+				Value: s[pos : pos+loc[1]],
+				Pos:   pos,
+			})
+			pos += loc[1]
+			matched = true
+			break
+		}
+		if !matched {
+			r, _ := utf8.DecodeRuneInString(s[pos:])
+			return nil, fmt.Errorf(`unexpected character "%c" at position %d`, r, pos)
+		}
+	}
+	return tokens, nil
+}
@@ -174,6 +174,19 @@ func Test_StrLimitRune(t *testing.T) {
 	})
 }
 
+func Test_Truncate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.Truncate("我爱GoFrame", 9, "..."), "我爱GoFrame")
+		t.Assert(gstr.Truncate("我爱GoFrame", 10, "..."), "我爱GoFrame")
+		t.Assert(gstr.Truncate("我爱GoFrame", 8, "..."), "我爱GoF...")
+		t.Assert(gstr.Truncate("我爱GoFrame", 5, "…"), "我爱Go…")
+		t.Assert(gstr.Truncate("我爱GoFrame", 2, ""), "我爱")
+		t.Assert(gstr.Truncate("我爱GoFrame", 3, "..."), "我爱G")
+		t.Assert(gstr.Truncate("我爱GoFrame", 0, "..."), "")
+		t.Assert(gstr.Truncate("", 3, "..."), "")
+	})
+}
+
 func Test_HasPrefix(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		t.Assert(gstr.HasPrefix("我爱GoFrame", "我爱"), true)
@@ -228,6 +241,17 @@ func Test_SplitAndTrim(t *testing.T) {
 	})
 }
 
+func Test_SplitAndTrimN(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.SplitAndTrim(" a , b ,  , c ", ","), []string{"a", "b", "c"})
+		t.Assert(gstr.SplitAndTrimN(" a , b ,  , c ", ",", -1), []string{"a", "b", "c"})
+		t.Assert(gstr.SplitAndTrimN(" a , , b , c ", ",", 3), []string{"a", "b , c"})
+		t.Assert(gstr.SplitAndTrimN(" a , b ", ",", 1), []string{"a , b"})
+		t.Assert(gstr.SplitAndTrimN(" a , b ", ",", 0), []string{})
+		t.Assert(gstr.SplitAndTrimN("0a0,0b0", ",", 2, "0"), []string{"a", "b"})
+	})
+}
+
 func Test_Fields(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		t.Assert(gstr.Fields("我爱 Go Frame"), []string{
@@ -273,6 +297,22 @@ func Test_WordWrap(t *testing.T) {
 	})
 }
 
+func Test_WordWrapCut(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.WordWrapCut("12 34", 2, false), "12\n34")
+		t.Assert(gstr.WordWrapCut("12  34 5", 5, false), "12 34\n5")
+		t.Assert(gstr.WordWrapCut("我爱 GoFrame 框架", 4, false), "我爱\nGoFrame\n框架")
+		t.Assert(gstr.WordWrapCut("我爱 GoFrame 框架", 4, true), "我爱\nGoFr\name\n框架")
+		t.Assert(gstr.WordWrapCut("abcdefgh", 3, true), "abc\ndef\ngh")
+		t.Assert(gstr.WordWrapCut("abcdef", 3, true), "abc\ndef")
+		t.Assert(gstr.WordWrapCut("A very long woooooooooooooooooord. and something", 7, false),
+			"A very\nlong\nwoooooooooooooooooord.\nand\nsomething")
+		t.Assert(gstr.WordWrapCut("a b\r\nc d\re f\ng h", 3, false), "a b\nc d\ne f\ng h")
+		t.Assert(gstr.WordWrapCut("a b\n\nc", 1, false), "a\nb\n\nc")
+		t.Assert(gstr.WordWrapCut("a b", 0, false), "a b")
+	})
+}
+
 func Test_RuneLen(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		t.Assert(gstr.RuneLen("1234"), 4)
@@ -280,6 +320,27 @@ func Test_RuneLen(t *testing.T) {
 	})
 }
 
+func Test_PadLeft(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.PadLeft("hi", 5, " "), "   hi")
+		t.Assert(gstr.PadLeft("hi", 10, "·→"), "·→·→·→·→hi")
+		t.Assert(gstr.PadLeft("hi", 5, "·→"), "·→·hi")
+		t.Assert(gstr.PadLeft("我爱", 4, "0"), "00我爱")
+		t.Assert(gstr.PadLeft("hello", 3, "0"), "hello")
+		t.Assert(gstr.PadLeft("hi", 5, ""), "hi")
+	})
+}
+
+func Test_PadRight(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.PadRight("hi", 5, " "), "hi   ")
+		t.Assert(gstr.PadRight("hi", 5, "·→"), "hi·→·")
+		t.Assert(gstr.PadRight("我爱", 4, "好的"), "我爱好的")
+		t.Assert(gstr.PadRight("hello", 5, "0"), "hello")
+		t.Assert(gstr.PadRight("hi", 5, ""), "hi")
+	})
+}
+
 func Test_Repeat(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		t.Assert(gstr.Repeat("go", 3), "gogogo")
@@ -461,3 +522,21 @@ func Test_InArray(t *testing.T) {
 		t.AssertEQ(gstr.InArray(a, "d"), false)
 	})
 }
+
+func Test_Template(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		vars := map[string]string{
+			"name": "Alice",
+			"site": "GoFrame",
+			"tpl":  "{{name}}",
+		}
+		t.Assert(gstr.Template("Hello {{name}}!", vars), "Hello Alice!")
+		t.Assert(gstr.Template("{{name}} loves {{ site }}", vars), "Alice loves GoFrame")
+		t.Assert(gstr.Template("Hello {{unknown}}, {{name}}", vars), "Hello {{unknown}}, Alice")
+		t.Assert(gstr.Template("{{tpl}}", vars), "{{name}}")
+		t.Assert(gstr.Template("{{name", vars), "{{name")
+		t.Assert(gstr.Template("name}} {{{name}}}", vars), "name}} {{{name}}}")
+		t.Assert(gstr.Template("", vars), "")
+		t.Assert(gstr.Template("{{name}}", nil), "{{name}}")
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_StringBuilder(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gstr.NewStringBuilder().
+			Write("SELECT ").
+			Join([]string{"id", "name"}, ",").
+			Write(" FROM user").
+			WriteIf(true, " WHERE id=1").
+			WriteIf(false, " AND name=?").
+			Writef(" LIMIT %d", 10).
+			String()
+		t.Assert(s, "SELECT id,name FROM user WHERE id=1 LIMIT 10")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var b gstr.StringBuilder
+		t.Assert(b.String(), "")
+		t.Assert(b.Join(nil, ",").String(), "")
+		t.Assert(b.Join([]string{"a"}, ",").Write("我爱").Len(), 7)
+		t.Assert(b.Reset().Write("GF").String(), "GF")
+	})
+}
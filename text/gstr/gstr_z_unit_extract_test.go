@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_ExtractEmails(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.ExtractEmails("no email here"), []string{})
+		t.Assert(
+			gstr.ExtractEmails("Contact john.doe+gf@example.com, or admin@mail.goframe.org. Not: a@b, @x.com"),
+			[]string{"john.doe+gf@example.com", "admin@mail.goframe.org"},
+		)
+	})
+}
+
+func Test_ExtractURLs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.ExtractURLs("no url here"), []string{})
+		t.Assert(
+			gstr.ExtractURLs(`See https://goframe.org/docs?a=1&b=2. Or (http://example.com/path), www.github.com!`),
+			[]string{"https://goframe.org/docs?a=1&b=2", "http://example.com/path", "www.github.com"},
+		)
+		t.Assert(gstr.ExtractURLs(`<a href="HTTPS://GoFrame.org">`), []string{"HTTPS://GoFrame.org"})
+	})
+}
+
+func Test_ExtractIPs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.ExtractIPs("no ip here at 12:30:45"), []string{})
+		t.Assert(
+			gstr.ExtractIPs("from 192.168.1.1 to 10.0.0.255, invalid 256.1.1.1, ipv6 ::1 and 2001:db8::8a2e:370:7334."),
+			[]string{"192.168.1.1", "10.0.0.255", "::1", "2001:db8::8a2e:370:7334"},
+		)
+	})
+	// The text adjacent to alphanumeric characters is not an IP address.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.ExtractIPs("use std::vector"), []string{})
+		t.Assert(gstr.ExtractIPs("xabc::1 and 1::2x"), []string{})
+		t.Assert(gstr.ExtractIPs("abc::1"), []string{"abc::1"})
+	})
+	// IPv6 address with embedded IPv4 tail.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.ExtractIPs("mapped ::ffff:1.2.3.4 address"), []string{"::ffff:1.2.3.4"})
+		t.Assert(gstr.ExtractIPs("(::ffff:192.168.1.1), 10.0.0.1"), []string{"::ffff:192.168.1.1", "10.0.0.1"})
+	})
+}
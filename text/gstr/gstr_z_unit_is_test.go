@@ -0,0 +1,61 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_IsASCII(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.IsASCII(""), false)
+		t.Assert(gstr.IsASCII("GoFrame 2.0!"), true)
+		t.Assert(gstr.IsASCII("我爱GoFrame"), false)
+	})
+}
+
+func Test_IsAlpha(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.IsAlpha(""), false)
+		t.Assert(gstr.IsAlpha("GoFrame"), true)
+		t.Assert(gstr.IsAlpha("我爱GoFrame"), true)
+		t.Assert(gstr.IsAlpha("GoFrame2"), false)
+		t.Assert(gstr.IsAlpha("Go Frame"), false)
+	})
+}
+
+func Test_IsAlphanumeric(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.IsAlphanumeric(""), false)
+		t.Assert(gstr.IsAlphanumeric("GoFrame2"), true)
+		t.Assert(gstr.IsAlphanumeric("123"), true)
+		t.Assert(gstr.IsAlphanumeric("GoFrame_2"), false)
+	})
+}
+
+func Test_IsLowerCase(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.IsLowerCase(""), false)
+		t.Assert(gstr.IsLowerCase("123_"), false)
+		t.Assert(gstr.IsLowerCase("goframe"), true)
+		t.Assert(gstr.IsLowerCase("user_1"), true)
+		t.Assert(gstr.IsLowerCase("GoFrame"), false)
+	})
+}
+
+func Test_IsUpperCase(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.IsUpperCase(""), false)
+		t.Assert(gstr.IsUpperCase("123_"), false)
+		t.Assert(gstr.IsUpperCase("GOFRAME"), true)
+		t.Assert(gstr.IsUpperCase("USER_1"), true)
+		t.Assert(gstr.IsUpperCase("GoFrame"), false)
+	})
+}
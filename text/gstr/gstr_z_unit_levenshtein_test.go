@@ -0,0 +1,39 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_LevenshteinDistance(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.LevenshteinDistance("", ""), 0)
+		t.Assert(gstr.LevenshteinDistance("", "abc"), 3)
+		t.Assert(gstr.LevenshteinDistance("abc", ""), 3)
+		t.Assert(gstr.LevenshteinDistance("kitten", "sitting"), 3)
+		t.Assert(gstr.LevenshteinDistance("sitting", "kitten"), 3)
+		t.Assert(gstr.LevenshteinDistance("ab", "ba"), 2)
+		t.Assert(gstr.LevenshteinDistance("我爱GoFrame", "我爱GF"), 5)
+		t.Assert(gstr.LevenshteinDistance("我爱", "你爱"), 1)
+	})
+}
+
+func Test_DamerauLevenshtein(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.DamerauLevenshtein("", ""), 0)
+		t.Assert(gstr.DamerauLevenshtein("", "abc"), 3)
+		t.Assert(gstr.DamerauLevenshtein("kitten", "sitting"), 3)
+		t.Assert(gstr.DamerauLevenshtein("ab", "ba"), 1)
+		t.Assert(gstr.DamerauLevenshtein("abcdef", "abdcfe"), 2)
+		t.Assert(gstr.DamerauLevenshtein("ca", "abc"), 3)
+		t.Assert(gstr.DamerauLevenshtein("我爱", "爱我"), 1)
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_SimilarText(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var percent float64
+		t.Assert(gstr.SimilarText("World", "Word", &percent), 4)
+		t.Assert(int(percent*100), 8888)
+		// Swapping the arguments may yield a different result, which is the same as PHP.
+		t.Assert(gstr.SimilarText("bafoobar", "barfoo", nil), 5)
+		t.Assert(gstr.SimilarText("barfoo", "bafoobar", nil), 3)
+		t.Assert(gstr.SimilarText("", "", &percent), 0)
+		t.Assert(percent, 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count, percent := gstr.SimilarTextPercent("bafoobar", "barfoo")
+		t.Assert(count, 5)
+		t.Assert(int(percent*100), 7142)
+		count, percent = gstr.SimilarTextPercent("GoFrame", "GoFrame")
+		t.Assert(count, 7)
+		t.Assert(percent, 100)
+		count, percent = gstr.SimilarTextPercent("abc", "xyz")
+		t.Assert(count, 0)
+		t.Assert(percent, 0)
+	})
+}
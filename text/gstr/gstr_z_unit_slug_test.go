@@ -0,0 +1,68 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_Slugify(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.Slugify(""), "")
+		t.Assert(gstr.Slugify(" Hello, World! "), "hello-world")
+		t.Assert(gstr.Slugify("--Hello---World--"), "hello-world")
+		t.Assert(gstr.Slugify("Crème Brûlée, s'il vous plaît!"), "creme-brulee-s-il-vous-plait")
+		t.Assert(gstr.Slugify("Straße Łódź Æsir Øresund"), "strasse-lodz-aesir-oresund")
+		t.Assert(gstr.Slugify("GoFrame 框架 2.0"), "goframe-框架-2-0")
+		// Idempotent on slugs.
+		for _, s := range []string{"Crème Brûlée", "Hello, World!", "GoFrame 框架"} {
+			slug := gstr.Slugify(s)
+			t.Assert(gstr.Slugify(slug), slug)
+		}
+	})
+}
+
+func Test_UniqueSlug(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.UniqueSlug(" Hello, World! ", func(slug string) bool {
+			return false
+		}), "hello-world")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		attempts := make([]string, 0)
+		slug := gstr.UniqueSlug("Hello World", func(slug string) bool {
+			attempts = append(attempts, slug)
+			return len(attempts) <= 5
+		})
+		t.Assert(slug, "hello-world-6")
+		t.Assert(attempts, []string{
+			"hello-world",
+			"hello-world-2",
+			"hello-world-3",
+			"hello-world-4",
+			"hello-world-5",
+			"hello-world-6",
+		})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		maxRetries := gstr.SlugMaxRetries
+		defer func() {
+			gstr.SlugMaxRetries = maxRetries
+		}()
+		gstr.SlugMaxRetries = 3
+		count := 0
+		slug := gstr.UniqueSlug("Hello World", func(slug string) bool {
+			count++
+			return true
+		})
+		t.Assert(slug, "")
+		t.Assert(count, 3)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/text/gstr"
+)
+
+func Test_Tokenize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		tokens, err := gstr.Tokenize("a = 10 + b1", []gstr.TokenRule{
+			{Pattern: `\d+`, Type: "number"},
+			{Pattern: `\w+`, Type: "ident"},
+			{Pattern: `[=+]`, Type: "operator"},
+			{Pattern: `\s+`, Type: "space"},
+		})
+		t.Assert(err, nil)
+		t.Assert(tokens, []gstr.Token{
+			{Type: "ident", Value: "a", Pos: 0},
+			{Type: "space", Value: " ", Pos: 1},
+			{Type: "operator", Value: "=", Pos: 2},
+			{Type: "space", Value: " ", Pos: 3},
+			{Type: "number", Value: "10", Pos: 4},
+			{Type: "space", Value: " ", Pos: 6},
+			{Type: "operator", Value: "+", Pos: 7},
+			{Type: "space", Value: " ", Pos: 8},
+			{Type: "ident", Value: "b1", Pos: 9},
+		})
+	})
+	// Overlapping patterns, the first matching rule wins.
+	gtest.C(t, func(t *gtest.T) {
+		tokens, err := gstr.Tokenize("if iff", []gstr.TokenRule{
+			{Pattern: `if\b`, Type: "keyword"},
+			{Pattern: `[a-z]+`, Type: "ident"},
+			{Pattern: `\s+`, Type: "space"},
+		})
+		t.Assert(err, nil)
+		t.Assert(len(tokens), 3)
+		t.Assert(tokens[0].Type, "keyword")
+		t.Assert(tokens[2].Type, "ident")
+		t.Assert(tokens[2].Value, "iff")
+
+		tokens, err = gstr.Tokenize("if iff", []gstr.TokenRule{
+			{Pattern: `[a-z]+`, Type: "ident"},
+			{Pattern: `if\b`, Type: "keyword"},
+			{Pattern: `\s+`, Type: "space"},
+		})
+		t.Assert(err, nil)
+		t.Assert(tokens[0].Type, "ident")
+		t.Assert(tokens[2].Type, "ident")
+	})
+	// Unicode input.
+	gtest.C(t, func(t *gtest.T) {
+		tokens, err := gstr.Tokenize("名字=张三", []gstr.TokenRule{
+			{Pattern: `\p{Han}+`, Type: "word"},
+			{Pattern: `=`, Type: "assign"},
+		})
+		t.Assert(err, nil)
+		t.Assert(tokens, []gstr.Token{
+			{Type: "word", Value: "名字", Pos: 0},
+			{Type: "assign", Value: "=", Pos: 6},
+			{Type: "word", Value: "张三", Pos: 7},
+		})
+
+		_, err = gstr.Tokenize("a=名", []gstr.TokenRule{
+			{Pattern: `[a-z]+`, Type: "word"},
+			{Pattern: `=`, Type: "assign"},
+		})
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), `"名" at position 2`), true)
+	})
+	// Empty string.
+	gtest.C(t, func(t *gtest.T) {
+		tokens, err := gstr.Tokenize("", []gstr.TokenRule{{Pattern: `\w+`, Type: "word"}})
+		t.Assert(err, nil)
+		t.Assert(len(tokens), 0)
+	})
+}
+
+func Test_Tokenize_InvalidRules(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// Patterns matching empty string.
+		_, err := gstr.Tokenize("abc", []gstr.TokenRule{{Pattern: `a*`, Type: "a"}})
+		t.AssertNE(err, nil)
+		_, err = gstr.Tokenize("abc", []gstr.TokenRule{{Pattern: `x?`, Type: "x"}})
+		t.AssertNE(err, nil)
+		_, err = gstr.Tokenize("abc", []gstr.TokenRule{{Pattern: `\b`, Type: "boundary"}})
+		t.AssertNE(err, nil)
+
+		// Invalid pattern.
+		_, err = gstr.Tokenize("abc", []gstr.TokenRule{{Pattern: `(`, Type: "invalid"}})
+		t.AssertNE(err, nil)
+
+		// Empty rules.
+		_, err = gstr.Tokenize("abc", nil)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ichunt2019/gf/errors/gerror"
+)
+
+type (
+	// ConverterFunc is the custom converting function, which converts <value>
+	// to the registered type.
+	ConverterFunc = func(value interface{}) (interface{}, error)
+
+	// ConverterContextFunc is the custom converting function with context, which converts <value>
+	// to the registered type. The context is passed from StructContext, which can be used for
+	// cancellation when the converting fetches data from external systems.
+	ConverterContextFunc = func(ctx context.Context, value interface{}) (interface{}, error)
+)
+
+var (
+	// customConverters stores the custom converting functions, the key is the converted type.
+	customConverters = make(map[reflect.Type]ConverterContextFunc)
+
+	// customConvertersMu is the mutex for concurrent safety of customConverters.
+	customConvertersMu sync.RWMutex
+
+	// customConvertersCount is the count of customConverters, which is used for
+	// quick checking without locking as there's usually no converter registered.
+	customConvertersCount int32
+)
+
+// RegisterConverter registers custom converting function <fn> for the type of <object>.
+// The struct attribute of the type is converted using <fn> in Struct/StructContext.
+//
+// The parameter <object> is any value of the converted type, eg: User{} or (*User)(nil).
+// The parameter <fn> should be type of ConverterFunc or ConverterContextFunc.
+func RegisterConverter(object interface{}, fn interface{}) error {
+	if object == nil {
+		return gerror.New("converted object type cannot be nil")
+	}
+	var converter ConverterContextFunc
+	switch f := fn.(type) {
+	case ConverterContextFunc:
+		converter = f
+	case ConverterFunc:
+		converter = func(ctx context.Context, value interface{}) (interface{}, error) {
+			return f(value)
+		}
+	default:
+		return gerror.Newf(`invalid converter function type "%T"`, fn)
+	}
+	customConvertersMu.Lock()
+	customConverters[reflect.TypeOf(object)] = converter
+	atomic.StoreInt32(&customConvertersCount, int32(len(customConverters)))
+	customConvertersMu.Unlock()
+	return nil
+}
+
+// UnregisterConverter removes the custom converting function for the type of <object>.
+func UnregisterConverter(object interface{}) {
+	customConvertersMu.Lock()
+	delete(customConverters, reflect.TypeOf(object))
+	atomic.StoreInt32(&customConvertersCount, int32(len(customConverters)))
+	customConvertersMu.Unlock()
+}
+
+// getConverter returns the custom converting function for type <t>.
+// It returns nil if there's no converter registered for <t>.
+func getConverter(t reflect.Type) ConverterContextFunc {
+	if atomic.LoadInt32(&customConvertersCount) == 0 {
+		return nil
+	}
+	customConvertersMu.RLock()
+	defer customConvertersMu.RUnlock()
+	return customConverters[t]
+}
+
+// bindVarToReflectValueWithConverter does binding using registered custom converter.
+// The returned <ok> is false if there's no converter registered for type of <reflectValue>.
+func bindVarToReflectValueWithConverter(ctx context.Context, reflectValue reflect.Value, value interface{}) (err error, ok bool) {
+	converter := getConverter(reflectValue.Type())
+	if converter == nil {
+		return nil, false
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = ctx.Err(); err != nil {
+		return err, true
+	}
+	result, err := converter(ctx, value)
+	if err != nil {
+		return err, true
+	}
+	if result == nil {
+		reflectValue.Set(reflect.Zero(reflectValue.Type()))
+		return nil, true
+	}
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.Type().AssignableTo(reflectValue.Type()) {
+		return gerror.Newf(
+			`converter result type "%s" is not assignable to type "%s"`,
+			resultValue.Type().String(), reflectValue.Type().String(),
+		), true
+	}
+	reflectValue.Set(resultValue)
+	return nil, true
+}
@@ -15,6 +15,10 @@ import (
 // <pointer> to implement the converting.
 // It calls function Struct if <pointer> is type of *struct/**struct to do the converting.
 // It calls function Structs if <pointer> is type of *[]struct/*[]*struct to do the converting.
+//
+// The parameter <params> can also be a struct of another type, which is commonly used for converting
+// between two struct types sharing field names, eg: the entity and the API response. The fields are
+// mapped by name case-insensitively, and the nested struct fields are converted recursively.
 func Scan(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
 	t := reflect.TypeOf(pointer)
 	k := t.Kind()
@@ -7,6 +7,7 @@
 package gconv
 
 import (
+	"context"
 	"fmt"
 	"github.com/ichunt2019/gf/errors/gerror"
 	"github.com/ichunt2019/gf/internal/empty"
@@ -32,17 +33,23 @@ import (
 //    in mapping procedure to do the matching.
 //    It ignores the map key, if it does not match.
 func Struct(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
-	return doStruct(params, pointer, mapping...)
+	return doStruct(context.Background(), params, pointer, mapping...)
+}
+
+// StructContext does the same as Struct, but it passes <ctx> to the custom converters
+// registered by RegisterConverter, which can be used for cancellation of the converting.
+func StructContext(ctx context.Context, params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
+	return doStruct(ctx, params, pointer, mapping...)
 }
 
 // StructDeep do Struct function recursively.
 // Deprecated, use Struct instead.
 func StructDeep(params interface{}, pointer interface{}, mapping ...map[string]string) error {
-	return doStruct(params, pointer, mapping...)
+	return doStruct(context.Background(), params, pointer, mapping...)
 }
 
 // doStruct is the core internal converting function for any data to struct.
-func doStruct(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
+func doStruct(ctx context.Context, params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
 	if params == nil {
 		// If <params> is nil, no conversion.
 		return nil
@@ -179,7 +186,7 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 					continue
 				}
 			}
-			if err = doStruct(paramsMap, elemFieldValue, mapping...); err != nil {
+			if err = doStruct(ctx, paramsMap, elemFieldValue, mapping...); err != nil {
 				return err
 			}
 		} else {
@@ -194,7 +201,7 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 	// The key of the tagMap is the attribute name of the struct,
 	// and the value is its replaced tag name for later comparison to improve performance.
 	tagMap := make(map[string]string)
-	tagToNameMap, err := structs.TagMapName(pointerElemReflectValue, StructTagPriority)
+	tagToNameMap, err := structs.TagMapName(pointerElemReflectValue, structTagPriority(ctx))
 	if err != nil {
 		return err
 	}
@@ -254,7 +261,7 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 		}
 		// Mark it done.
 		doneMap[attrName] = struct{}{}
-		if err := bindVarToStructAttr(pointerElemReflectValue, attrName, mapV, mapping...); err != nil {
+		if err := bindVarToStructAttr(ctx, pointerElemReflectValue, attrName, mapV, mapping...); err != nil {
 			return err
 		}
 	}
@@ -262,7 +269,7 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 }
 
 // bindVarToStructAttr sets value to struct object attribute by name.
-func bindVarToStructAttr(elem reflect.Value, name string, value interface{}, mapping ...map[string]string) (err error) {
+func bindVarToStructAttr(ctx context.Context, elem reflect.Value, name string, value interface{}, mapping ...map[string]string) (err error) {
 	structFieldValue := elem.FieldByName(name)
 	if !structFieldValue.IsValid() {
 		return nil
@@ -271,9 +278,16 @@ func bindVarToStructAttr(elem reflect.Value, name string, value interface{}, map
 	if !structFieldValue.CanSet() {
 		return nil
 	}
+	// Custom converter checks.
+	if err, ok := bindVarToReflectValueWithConverter(ctx, structFieldValue, value); ok {
+		if err != nil {
+			err = gerror.Wrapf(err, `error binding value to attribute "%s"`, name)
+		}
+		return err
+	}
 	defer func() {
 		if e := recover(); e != nil {
-			if err = bindVarToReflectValue(structFieldValue, value, mapping...); err != nil {
+			if err = bindVarToReflectValue(ctx, structFieldValue, value, mapping...); err != nil {
 				err = gerror.Wrapf(err, `error binding value to attribute "%s"`, name)
 			}
 		}
@@ -322,7 +336,7 @@ func bindVarToReflectValueWithInterfaceCheck(reflectValue reflect.Value, value i
 }
 
 // bindVarToReflectValue sets <value> to reflect value object <structFieldValue>.
-func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, mapping ...map[string]string) (err error) {
+func bindVarToReflectValue(ctx context.Context, structFieldValue reflect.Value, value interface{}, mapping ...map[string]string) (err error) {
 	if err, ok := bindVarToReflectValueWithInterfaceCheck(structFieldValue, value); ok {
 		return err
 	}
@@ -342,7 +356,7 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 	switch kind {
 	case reflect.Struct:
 		// Recursively converting for struct attribute.
-		if err := doStruct(value, structFieldValue); err != nil {
+		if err := doStruct(ctx, value, structFieldValue); err != nil {
 			// Note there's reflect conversion mechanism here.
 			structFieldValue.Set(reflect.ValueOf(value).Convert(structFieldValue.Type()))
 		}
@@ -350,6 +364,11 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 	// Note that the slice element might be type of struct,
 	// so it uses Struct function doing the converting internally.
 	case reflect.Slice, reflect.Array:
+		// String to []byte, eg: the "!!binary" value of YAML.
+		if s, ok := value.(string); ok && kind == reflect.Slice && structFieldValue.Type().Elem().Kind() == reflect.Uint8 {
+			structFieldValue.SetBytes([]byte(s))
+			return nil
+		}
 		a := reflect.Value{}
 		v := reflect.ValueOf(value)
 		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
@@ -359,14 +378,14 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 				for i := 0; i < v.Len(); i++ {
 					if t.Kind() == reflect.Ptr {
 						e := reflect.New(t.Elem()).Elem()
-						if err := doStruct(v.Index(i).Interface(), e); err != nil {
+						if err := doStruct(ctx, v.Index(i).Interface(), e); err != nil {
 							// Note there's reflect conversion mechanism here.
 							e.Set(reflect.ValueOf(v.Index(i).Interface()).Convert(t))
 						}
 						a.Index(i).Set(e.Addr())
 					} else {
 						e := reflect.New(t).Elem()
-						if err := doStruct(v.Index(i).Interface(), e); err != nil {
+						if err := doStruct(ctx, v.Index(i).Interface(), e); err != nil {
 							// Note there's reflect conversion mechanism here.
 							e.Set(reflect.ValueOf(v.Index(i).Interface()).Convert(t))
 						}
@@ -379,14 +398,14 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 			t := a.Index(0).Type()
 			if t.Kind() == reflect.Ptr {
 				e := reflect.New(t.Elem()).Elem()
-				if err := doStruct(value, e); err != nil {
+				if err := doStruct(ctx, value, e); err != nil {
 					// Note there's reflect conversion mechanism here.
 					e.Set(reflect.ValueOf(value).Convert(t))
 				}
 				a.Index(0).Set(e.Addr())
 			} else {
 				e := reflect.New(t).Elem()
-				if err := doStruct(value, e); err != nil {
+				if err := doStruct(ctx, value, e); err != nil {
 					// Note there's reflect conversion mechanism here.
 					e.Set(reflect.ValueOf(value).Convert(t))
 				}
@@ -402,7 +421,7 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 			return err
 		}
 		elem := item.Elem()
-		if err = bindVarToReflectValue(elem, value, mapping...); err == nil {
+		if err = bindVarToReflectValue(ctx, elem, value, mapping...); err == nil {
 			structFieldValue.Set(elem.Addr())
 		}
 
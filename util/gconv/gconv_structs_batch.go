@@ -0,0 +1,95 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ichunt2019/gf/errors/gerror"
+)
+
+// ConversionError is the error of converting the element at <Index> in batch struct conversion.
+type ConversionError struct {
+	Index int   // Index of the failed element in the params slice, which is -1 if the pointer is invalid.
+	Err   error // The conversion error of the element.
+}
+
+// ConversionErrors is the slice of ConversionError, which implements the error interface.
+type ConversionErrors []*ConversionError
+
+// Error implements the interface of error.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf(`index %d: %v`, e.Index, e.Err)
+}
+
+// Unwrap returns the conversion error of the element.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// Error implements the interface of error, which joins all the element errors.
+func (e ConversionErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// StructsBatch converts each element of <params> to the struct element of <pointer> independently,
+// which accumulates the errors of failed elements instead of stopping at the first error like Structs.
+// The parameter <pointer> should be type of *[]T or *[]*T.
+//
+// It returns the converted slice, whose elements at the failed indexes are zero values,
+// and the ConversionErrors of the failed elements in ascending order of their indexes.
+// The returned ConversionErrors is nil if all elements are converted successfully.
+func StructsBatch(params []interface{}, pointer interface{}, mapping ...map[string]string) (interface{}, ConversionErrors) {
+	if pointer == nil {
+		return nil, ConversionErrors{{Index: -1, Err: gerror.New("object pointer cannot be nil")}}
+	}
+	pointerRv, ok := pointer.(reflect.Value)
+	if !ok {
+		pointerRv = reflect.ValueOf(pointer)
+	}
+	if pointerRv.Kind() != reflect.Ptr || pointerRv.Elem().Kind() != reflect.Slice {
+		return nil, ConversionErrors{{
+			Index: -1,
+			Err:   gerror.Newf("pointer should be type of pointer to slice, but got: %v", pointerRv.Type()),
+		}}
+	}
+	var (
+		errs          ConversionErrors
+		sliceType     = pointerRv.Elem().Type()
+		itemType      = sliceType.Elem()
+		isPointerItem = itemType.Kind() == reflect.Ptr
+		slice         = reflect.MakeSlice(sliceType, len(params), len(params))
+	)
+	for i, param := range params {
+		var item reflect.Value
+		if isPointerItem {
+			item = reflect.New(itemType.Elem())
+		} else {
+			item = reflect.New(itemType)
+		}
+		if err := Struct(param, item.Interface(), mapping...); err != nil {
+			errs = append(errs, &ConversionError{
+				Index: i,
+				Err:   err,
+			})
+			continue
+		}
+		if isPointerItem {
+			slice.Index(i).Set(item)
+		} else {
+			slice.Index(i).Set(item.Elem())
+		}
+	}
+	pointerRv.Elem().Set(slice)
+	return slice.Interface(), errs
+}
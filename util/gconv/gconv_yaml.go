@@ -0,0 +1,67 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/ichunt2019/gf/errors/gerror"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// yamlStructTag is the struct tag for YAML, which has the highest priority in StructFromYAML.
+	yamlStructTag = "yaml"
+)
+
+// structTagPriorityCtxKey is the context key for custom struct tag priority of doStruct.
+type structTagPriorityCtxKey struct{}
+
+// StructFromYAML parses the YAML <yamlContent> and maps the parsed data to struct <pointer>.
+// It respects the "yaml" tags, with fallback to the tags of StructTagPriority like "gconv" and "json".
+//
+// The YAML timestamps are converted to time.Time, and the "!!binary" values are decoded to []byte.
+// Note that it supports only single document YAML, it returns error for multi-document YAML.
+func StructFromYAML(yamlContent []byte, pointer interface{}) error {
+	var (
+		data    interface{}
+		decoder = yaml.NewDecoder(bytes.NewReader(yamlContent))
+	)
+	if err := decoder.Decode(&data); err != nil {
+		if err == io.EOF {
+			// Empty document.
+			return nil
+		}
+		return gerror.Wrap(err, "yaml decoding failed")
+	}
+	var next interface{}
+	if err := decoder.Decode(&next); err != io.EOF {
+		if err != nil {
+			return gerror.Wrap(err, "yaml decoding failed")
+		}
+		return gerror.New("multi-document yaml is not supported")
+	}
+	ctx := context.WithValue(
+		context.Background(),
+		structTagPriorityCtxKey{},
+		append([]string{yamlStructTag}, StructTagPriority...),
+	)
+	return doStruct(ctx, data, pointer)
+}
+
+// structTagPriority returns the struct tag priority from <ctx>,
+// or else it returns the default StructTagPriority.
+func structTagPriority(ctx context.Context) []string {
+	if ctx != nil {
+		if priority, ok := ctx.Value(structTagPriorityCtxKey{}).([]string); ok {
+			return priority
+		}
+	}
+	return StructTagPriority
+}
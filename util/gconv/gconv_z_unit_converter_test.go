@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ichunt2019/gf/errors/gerror"
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+type converterUser struct {
+	Id   int
+	Name string
+}
+
+type converterOrder struct {
+	Id   int
+	User *converterUser
+}
+
+func Test_RegisterConverter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gconv.RegisterConverter((*converterUser)(nil), func(value interface{}) (interface{}, error) {
+			id := gconv.Int(value)
+			return &converterUser{Id: id, Name: "user" + gconv.String(id)}, nil
+		})
+		t.Assert(err, nil)
+		defer gconv.UnregisterConverter((*converterUser)(nil))
+
+		order := new(converterOrder)
+		err = gconv.Struct(g.Map{"id": 1, "user": 100}, order)
+		t.Assert(err, nil)
+		t.Assert(order.Id, 1)
+		t.Assert(order.User.Id, 100)
+		t.Assert(order.User.Name, "user100")
+
+		// Default converting after unregistering.
+		gconv.UnregisterConverter((*converterUser)(nil))
+		order = new(converterOrder)
+		err = gconv.Struct(g.Map{"id": 1, "user": g.Map{"id": 200}}, order)
+		t.Assert(err, nil)
+		t.Assert(order.User.Id, 200)
+		t.Assert(order.User.Name, "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gconv.RegisterConverter((*converterUser)(nil), func(value interface{}) interface{} {
+			return nil
+		})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_StructContext(t *testing.T) {
+	type ctxKey string
+	gtest.C(t, func(t *gtest.T) {
+		err := gconv.RegisterConverter((*converterUser)(nil), func(ctx context.Context, value interface{}) (interface{}, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return &converterUser{
+				Id:   gconv.Int(value),
+				Name: gconv.String(ctx.Value(ctxKey("name"))),
+			}, nil
+		})
+		t.Assert(err, nil)
+		defer gconv.UnregisterConverter((*converterUser)(nil))
+
+		var (
+			ctx   = context.WithValue(context.Background(), ctxKey("name"), "john")
+			order = new(converterOrder)
+		)
+		err = gconv.StructContext(ctx, g.Map{"id": 1, "user": 100}, order)
+		t.Assert(err, nil)
+		t.Assert(order.User.Id, 100)
+		t.Assert(order.User.Name, "john")
+
+		// Cancelled context.
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		order = new(converterOrder)
+		err = gconv.StructContext(ctx, g.Map{"id": 1, "user": 100}, order)
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Cause(err), context.Canceled)
+		t.Assert(order.User, nil)
+	})
+}
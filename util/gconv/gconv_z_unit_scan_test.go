@@ -76,6 +76,57 @@ func Test_Scan(t *testing.T) {
 	})
 }
 
+func Test_Scan_StructToStruct(t *testing.T) {
+	type EntityAddress struct {
+		City string
+		Zip  int
+	}
+	type Entity struct {
+		Id        int
+		Name      string
+		Address   EntityAddress
+		Addresses []*EntityAddress
+		Extra     *EntityAddress
+	}
+	type ResponseAddress struct {
+		CITY string
+		Zip  string
+	}
+	type Response struct {
+		ID        string
+		NAME      string
+		Address   ResponseAddress
+		Addresses []ResponseAddress
+		Extra     *ResponseAddress
+		Missing   string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		entity := &Entity{
+			Id:        1,
+			Name:      "john",
+			Address:   EntityAddress{City: "Beijing", Zip: 100000},
+			Addresses: []*EntityAddress{{City: "Shanghai", Zip: 200000}},
+			Extra:     &EntityAddress{City: "Chengdu", Zip: 610000},
+		}
+		var response *Response
+		t.Assert(gconv.Scan(entity, &response), nil)
+		t.Assert(response.ID, "1")
+		t.Assert(response.NAME, "john")
+		t.Assert(response.Address, ResponseAddress{CITY: "Beijing", Zip: "100000"})
+		t.Assert(response.Addresses, []ResponseAddress{{CITY: "Shanghai", Zip: "200000"}})
+		t.Assert(response.Extra, &ResponseAddress{CITY: "Chengdu", Zip: "610000"})
+		t.Assert(response.Missing, "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		entities := []Entity{{Id: 1, Name: "john"}, {Id: 2, Name: "smith"}}
+		var responses []Response
+		t.Assert(gconv.Scan(entities, &responses), nil)
+		t.Assert(len(responses), 2)
+		t.Assert(responses[0].ID, "1")
+		t.Assert(responses[1].NAME, "smith")
+	})
+}
+
 func Test_ScanStr(t *testing.T) {
 	type User struct {
 		Uid   int
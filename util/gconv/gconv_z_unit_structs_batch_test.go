@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ichunt2019/gf/frame/g"
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+func Test_StructsBatch(t *testing.T) {
+	type User struct {
+		Uid  int
+		Name string
+	}
+	// All succeed.
+	gtest.C(t, func(t *gtest.T) {
+		var users []*User
+		result, errs := gconv.StructsBatch([]interface{}{
+			g.Map{"uid": 1, "name": "john"},
+			g.Map{"uid": 2, "name": "smith"},
+		}, &users)
+		t.Assert(errs, nil)
+		t.Assert(len(users), 2)
+		t.Assert(users[0], &User{Uid: 1, Name: "john"})
+		t.Assert(users[1], &User{Uid: 2, Name: "smith"})
+		t.Assert(result, users)
+	})
+	// Some fail and some succeed, pointer elements.
+	gtest.C(t, func(t *gtest.T) {
+		var users []*User
+		_, errs := gconv.StructsBatch([]interface{}{
+			g.Map{"uid": 1, "name": "john"},
+			"invalid",
+			g.Map{"uid": 3, "name": "smith"},
+			123,
+		}, &users)
+		t.Assert(len(errs), 2)
+		t.Assert(errs[0].Index, 1)
+		t.Assert(errs[1].Index, 3)
+		t.AssertNE(errors.Unwrap(errs[0]), nil)
+
+		t.Assert(len(users), 4)
+		t.Assert(users[0], &User{Uid: 1, Name: "john"})
+		t.Assert(users[1] == nil, true)
+		t.Assert(users[2], &User{Uid: 3, Name: "smith"})
+		t.Assert(users[3] == nil, true)
+
+		var err error = errs
+		t.Assert(err.Error(), errs[0].Error()+"; "+errs[1].Error())
+		t.Assert(strings.HasPrefix(errs[0].Error(), "index 1: "), true)
+	})
+	// Some fail and some succeed, struct elements.
+	gtest.C(t, func(t *gtest.T) {
+		var users []User
+		result, errs := gconv.StructsBatch([]interface{}{
+			"invalid",
+			g.Map{"uid": 2, "name": "smith"},
+		}, &users)
+		t.Assert(len(errs), 1)
+		t.Assert(errs[0].Index, 0)
+		t.Assert(users, []User{{}, {Uid: 2, Name: "smith"}})
+		t.Assert(result, users)
+	})
+	// Invalid pointer.
+	gtest.C(t, func(t *gtest.T) {
+		var users []User
+		_, errs := gconv.StructsBatch([]interface{}{g.Map{"uid": 1}}, users)
+		t.Assert(len(errs), 1)
+		t.Assert(errs[0].Index, -1)
+		_, errs = gconv.StructsBatch([]interface{}{g.Map{"uid": 1}}, nil)
+		t.Assert(len(errs), 1)
+		t.Assert(errs[0].Index, -1)
+
+		result, errs := gconv.StructsBatch(nil, &users)
+		t.Assert(errs, nil)
+		t.Assert(len(users), 0)
+		t.Assert(result, []User{})
+	})
+}
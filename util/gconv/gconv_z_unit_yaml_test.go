@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/ichunt2019/gf.
+
+package gconv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ichunt2019/gf/test/gtest"
+	"github.com/ichunt2019/gf/util/gconv"
+)
+
+type yamlDatabase struct {
+	Host string `yaml:"db_host" json:"host"`
+	Port int    `json:"db_port"`
+	User string `gconv:"db_user"`
+}
+
+type yamlConfig struct {
+	Name     string         `yaml:"app_name"`
+	Created  time.Time      `yaml:"created"`
+	Logo     []byte         `yaml:"logo"`
+	Tags     []string       `yaml:"tags"`
+	Master   *yamlDatabase  `yaml:"master"`
+	Slaves   []yamlDatabase `yaml:"slaves"`
+	Optional *yamlDatabase  `yaml:"optional"`
+	Comment  string         `yaml:"comment"`
+}
+
+func Test_StructFromYAML(t *testing.T) {
+	content := `
+app_name: gf
+created: 2021-01-02T15:04:05Z
+logo: !!binary aGVsbG8=
+tags: [a, b]
+base: &base
+  db_host: 127.0.0.1
+  db_port: 3306
+  db_user: root
+master: *base
+slaves:
+  - <<: *base
+    db_host: 127.0.0.2
+  - <<: *base
+    db_port: 3307
+optional: null
+comment: ~
+`
+	gtest.C(t, func(t *gtest.T) {
+		var config *yamlConfig
+		err := gconv.StructFromYAML([]byte(content), &config)
+		t.Assert(err, nil)
+		t.Assert(config.Name, "gf")
+		t.Assert(config.Created.Equal(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)), true)
+		t.Assert(config.Logo, []byte("hello"))
+		t.Assert(config.Tags, []string{"a", "b"})
+		// Anchors.
+		t.Assert(config.Master.Host, "127.0.0.1")
+		t.Assert(config.Master.Port, 3306)
+		t.Assert(config.Master.User, "root")
+		t.Assert(len(config.Slaves), 2)
+		t.Assert(config.Slaves[0].Host, "127.0.0.2")
+		t.Assert(config.Slaves[0].Port, 3306)
+		t.Assert(config.Slaves[1].Host, "127.0.0.1")
+		t.Assert(config.Slaves[1].Port, 3307)
+		// Null values.
+		t.Assert(config.Optional, nil)
+		t.Assert(config.Comment, "")
+	})
+	// Multi-document.
+	gtest.C(t, func(t *gtest.T) {
+		var config *yamlConfig
+		err := gconv.StructFromYAML([]byte("app_name: gf1\n---\napp_name: gf2\n"), &config)
+		t.AssertNE(err, nil)
+	})
+	// Invalid and empty content.
+	gtest.C(t, func(t *gtest.T) {
+		config := new(yamlConfig)
+		t.AssertNE(gconv.StructFromYAML([]byte("app_name: [gf"), config), nil)
+		t.Assert(gconv.StructFromYAML([]byte(""), config), nil)
+		t.Assert(config.Name, "")
+	})
+}